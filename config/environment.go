@@ -9,7 +9,7 @@ import (
 type Environment struct {
 	ENV_MODE              string
 	DISCORD_BOT_TOKEN     string
-	TOURNAMENT_CHANNEL_ID string
+	TOURNAMENT_CHANNEL_ID string `env:"optional"` // superseded by the per guild /config channel
 	DB_HOST               string
 	DB_NAME               string
 	DB_PORT               string
@@ -28,7 +28,7 @@ func initEnvironment() error {
 		envVar := field.Name
 
 		value := os.Getenv(envVar)
-		if value == "" {
+		if value == "" && field.Tag.Get("env") != "optional" {
 			return errors.New("environment variable " + envVar + " is required")
 		}
 
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_logs(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  guild_id VARCHAR(32) NULL,
+  actor_id VARCHAR(64) NOT NULL,
+  action VARCHAR(32) NOT NULL,
+  tournament_id CHAR(36) NULL,
+  before_data TEXT NULL,
+  after_data TEXT NULL,
+  created_at BIGINT NOT NULL,
+  INDEX idx_guild_id (guild_id),
+  INDEX idx_tournament_id (tournament_id)
+);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `matches`;
\ No newline at end of file
@@ -0,0 +1,33 @@
+BEGIN;
+
+-- the matches of tournaments played before this migration are rebuilt from match_histories when the bot
+-- starts since their layout depends on the bracket template, see MatchModel.Backfill
+
+CREATE TABLE IF NOT EXISTS matches(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  tournament_id CHAR(36) NOT NULL,
+  round INT NOT NULL,
+  number INT NOT NULL,
+  p1_attendee_id INT NULL,
+  p2_attendee_id INT NULL,
+  p1_seat INT NOT NULL,
+  p2_seat INT NOT NULL,
+  winner_to INT NOT NULL,
+  winner_attendee_id INT NULL,
+  status ENUM('pending', 'ready', 'live', 'completed', 'walkover') DEFAULT 'pending',
+  p1_score INT DEFAULT 0,
+  p2_score INT DEFAULT 0,
+  channel_id VARCHAR(32) NULL,
+  message_id VARCHAR(32) NULL,
+  started_at BIGINT NULL,
+  completed_at BIGINT NULL,
+  created_at BIGINT NOT NULL,
+  FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+  FOREIGN KEY (p1_attendee_id) REFERENCES attendees(id),
+  FOREIGN KEY (p2_attendee_id) REFERENCES attendees(id),
+  FOREIGN KEY (winner_attendee_id) REFERENCES attendees(id),
+  UNIQUE KEY uniq_tournament_number (tournament_id, number),
+  INDEX idx_tournament_id (tournament_id)
+);
+
+COMMIT;
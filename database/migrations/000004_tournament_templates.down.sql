@@ -0,0 +1,7 @@
+ALTER TABLE tournaments DROP FOREIGN KEY fk_tournaments_template;
+ALTER TABLE tournaments
+  DROP COLUMN template_id,
+  DROP COLUMN self_register,
+  DROP COLUMN best_of,
+  DROP COLUMN guild_id;
+DROP TABLE IF EXISTS `tournament_templates`;
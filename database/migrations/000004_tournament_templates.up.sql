@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tournament_templates(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  guild_id VARCHAR(32) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  name_pattern VARCHAR(128) NOT NULL,
+  description TEXT NULL,
+  rules TEXT NULL,
+  tournament_types_id INT,
+  best_of INT DEFAULT 1,
+  self_register BOOLEAN DEFAULT true,
+  uses INT DEFAULT 0,
+  created_by VARCHAR(64) NOT NULL,
+  created_at BIGINT NOT NULL,
+  FOREIGN KEY (tournament_types_id) REFERENCES tournament_types(id),
+  UNIQUE KEY uniq_guild_name (guild_id, name)
+);
+
+ALTER TABLE tournaments
+  ADD COLUMN guild_id VARCHAR(32) NULL,
+  ADD COLUMN best_of INT DEFAULT 1,
+  ADD COLUMN self_register BOOLEAN DEFAULT true,
+  ADD COLUMN template_id INT NULL,
+  ADD CONSTRAINT fk_tournaments_template FOREIGN KEY (template_id) REFERENCES tournament_templates(id) ON DELETE SET NULL;
+
+COMMIT;
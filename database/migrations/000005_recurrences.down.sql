@@ -0,0 +1,3 @@
+ALTER TABLE tournaments DROP COLUMN seed_from;
+DROP TABLE IF EXISTS `recurrences`;
+DROP TABLE IF EXISTS `scheduled_jobs`;
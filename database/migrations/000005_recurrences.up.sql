@@ -0,0 +1,38 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scheduled_jobs(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  kind VARCHAR(32) NOT NULL,
+  tournament_id CHAR(36) NULL,
+  reference_id INT NULL,
+  run_at BIGINT NOT NULL,
+  status ENUM('pending', 'running', 'done', 'failed', 'cancelled') DEFAULT 'pending',
+  attempts INT DEFAULT 0,
+  last_error TEXT NULL,
+  created_at BIGINT NOT NULL,
+  INDEX idx_status_run_at (status, run_at),
+  INDEX idx_tournament_id (tournament_id)
+);
+
+CREATE TABLE IF NOT EXISTS recurrences(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  guild_id VARCHAR(32) NOT NULL,
+  tournament_id CHAR(36) NOT NULL,
+  last_tournament_id CHAR(36) NOT NULL,
+  name_pattern VARCHAR(128) NOT NULL,
+  edition INT DEFAULT 1,
+  weekday TINYINT NOT NULL,
+  time_of_day CHAR(5) NOT NULL,
+  timezone VARCHAR(64) DEFAULT 'UTC',
+  open_before_hours INT DEFAULT 24,
+  carry_seeding BOOLEAN DEFAULT false,
+  enabled BOOLEAN DEFAULT true,
+  created_by VARCHAR(64) NOT NULL,
+  created_at BIGINT NOT NULL,
+  INDEX idx_tournament_id (tournament_id),
+  INDEX idx_last_tournament_id (last_tournament_id)
+);
+
+ALTER TABLE tournaments ADD COLUMN seed_from CHAR(36) NULL;
+
+COMMIT;
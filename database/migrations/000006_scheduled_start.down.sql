@@ -0,0 +1,3 @@
+ALTER TABLE tournaments
+  DROP COLUMN registration_open,
+  DROP COLUMN started_at;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE tournaments
+  ADD COLUMN started_at BIGINT NULL,
+  ADD COLUMN registration_open BOOLEAN DEFAULT true;
+
+-- starting_at used to be set only once a tournament is started
+UPDATE tournaments SET started_at = starting_at, registration_open = false WHERE starting_at IS NOT NULL;
+
+COMMIT;
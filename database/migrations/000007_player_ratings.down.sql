@@ -0,0 +1,2 @@
+ALTER TABLE tournaments DROP COLUMN game;
+DROP TABLE IF EXISTS `player_ratings`;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS player_ratings(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  guild_id VARCHAR(32) NOT NULL,
+  -- empty for the rating across every game of the guild
+  game VARCHAR(64) NOT NULL DEFAULT '',
+  player_id CHAR(36) NOT NULL,
+  rating DOUBLE NOT NULL,
+  deviation DOUBLE NOT NULL,
+  volatility DOUBLE NOT NULL,
+  elo DOUBLE NOT NULL,
+  wins INT DEFAULT 0,
+  losses INT DEFAULT 0,
+  updated_at BIGINT NOT NULL,
+  FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+  UNIQUE KEY uq_guild_game_player (guild_id, game, player_id)
+);
+
+ALTER TABLE tournaments ADD COLUMN game VARCHAR(64) NULL;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS seasons(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  guild_id VARCHAR(32) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  starts_at BIGINT NOT NULL,
+  ends_at BIGINT NOT NULL,
+  -- placement tiers and their points, such as 1=100,2=70,3=50
+  point_tiers VARCHAR(255) NOT NULL,
+  archived BOOLEAN DEFAULT false,
+  archived_at BIGINT NULL,
+  created_by VARCHAR(64) NOT NULL,
+  created_at BIGINT NOT NULL,
+  UNIQUE KEY uq_guild_name (guild_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS season_standings(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  season_id INT NOT NULL,
+  player_id CHAR(36) NOT NULL,
+  position INT NOT NULL,
+  points INT NOT NULL,
+  tournaments INT NOT NULL,
+  tournament_wins INT NOT NULL,
+  FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE,
+  FOREIGN KEY (player_id) REFERENCES players(id),
+  UNIQUE KEY uq_season_player (season_id, player_id)
+);
+
+COMMIT;
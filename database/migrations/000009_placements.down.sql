@@ -0,0 +1,3 @@
+ALTER TABLE tournaments DROP COLUMN completed_at;
+ALTER TABLE tournaments DROP COLUMN completed;
+DROP TABLE IF EXISTS `placements`;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS placements(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  tournament_id CHAR(36) NOT NULL,
+  attendee_id INT NOT NULL,
+  -- players knocked out in the same round share the place
+  place INT NOT NULL,
+  wins INT DEFAULT 0,
+  losses INT DEFAULT 0,
+  created_at BIGINT NOT NULL,
+  FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+  FOREIGN KEY (attendee_id) REFERENCES attendees(id) ON DELETE CASCADE,
+  UNIQUE KEY uq_tournament_attendee (tournament_id, attendee_id),
+  INDEX idx_tournament_place (tournament_id, place)
+);
+
+ALTER TABLE tournaments ADD COLUMN completed BOOLEAN DEFAULT false;
+ALTER TABLE tournaments ADD COLUMN completed_at BIGINT NULL;
+
+COMMIT;
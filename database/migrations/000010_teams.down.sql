@@ -0,0 +1,7 @@
+ALTER TABLE tournaments DROP COLUMN roster_limit;
+ALTER TABLE tournaments DROP COLUMN team_size;
+ALTER TABLE attendees DROP FOREIGN KEY fk_attendees_team;
+ALTER TABLE attendees DROP COLUMN team_id;
+DROP TABLE IF EXISTS `team_invites`;
+DROP TABLE IF EXISTS `team_members`;
+DROP TABLE IF EXISTS `teams`;
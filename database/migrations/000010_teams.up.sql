@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS teams(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  tournament_id CHAR(36) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  captain_id CHAR(36) NOT NULL,
+  created_at BIGINT NOT NULL,
+  FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+  FOREIGN KEY (captain_id) REFERENCES players(id),
+  UNIQUE KEY uq_tournament_name (tournament_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS team_members(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  team_id INT NOT NULL,
+  -- kept on the member so a player can only join one team per tournament
+  tournament_id CHAR(36) NOT NULL,
+  player_id CHAR(36) NOT NULL,
+  joined_at BIGINT NOT NULL,
+  FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+  FOREIGN KEY (player_id) REFERENCES players(id),
+  UNIQUE KEY uq_tournament_player (tournament_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS team_invites(
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  team_id INT NOT NULL,
+  player_id CHAR(36) NOT NULL,
+  invited_by CHAR(36) NOT NULL,
+  created_at BIGINT NOT NULL,
+  FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+  FOREIGN KEY (player_id) REFERENCES players(id),
+  UNIQUE KEY uq_team_player (team_id, player_id)
+);
+
+ALTER TABLE attendees ADD COLUMN team_id INT NULL;
+ALTER TABLE attendees ADD CONSTRAINT fk_attendees_team FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+
+-- a team size of 1 is a solo tournament, roster_limit allows substitutes on top of the team size
+ALTER TABLE tournaments ADD COLUMN team_size INT NOT NULL DEFAULT 1;
+ALTER TABLE tournaments ADD COLUMN roster_limit INT NULL;
+
+COMMIT;
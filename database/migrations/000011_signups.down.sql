@@ -0,0 +1,2 @@
+ALTER TABLE tournaments DROP COLUMN signup_message_id;
+ALTER TABLE attendees DROP COLUMN waitlisted_at;
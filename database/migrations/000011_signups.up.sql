@@ -0,0 +1,7 @@
+BEGIN;
+
+-- attendees past the player cap wait in line, oldest first
+ALTER TABLE attendees ADD COLUMN waitlisted_at BIGINT NULL;
+ALTER TABLE tournaments ADD COLUMN signup_message_id VARCHAR(32) NULL;
+
+COMMIT;
@@ -0,0 +1,4 @@
+ALTER TABLE attendees DROP COLUMN checked_in_at;
+ALTER TABLE tournaments DROP COLUMN check_in_message_id;
+ALTER TABLE tournaments DROP COLUMN check_in_opened_at;
+ALTER TABLE tournaments DROP COLUMN check_in_minutes;
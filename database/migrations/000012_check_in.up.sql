@@ -0,0 +1,9 @@
+BEGIN;
+
+-- check-in opens this many minutes before the planned start, NULL disables it
+ALTER TABLE tournaments ADD COLUMN check_in_minutes INT NULL;
+ALTER TABLE tournaments ADD COLUMN check_in_opened_at BIGINT NULL;
+ALTER TABLE tournaments ADD COLUMN check_in_message_id VARCHAR(32) NULL;
+ALTER TABLE attendees ADD COLUMN checked_in_at BIGINT NULL;
+
+COMMIT;
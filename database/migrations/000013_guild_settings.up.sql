@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS guild_settings(
+  guild_id VARCHAR(32) PRIMARY KEY,
+  tournament_channel_id VARCHAR(32) NULL,
+  manager_role_id VARCHAR(32) NULL,
+  default_tournament_types_id INT NULL,
+  -- IANA time zone start times are entered in, such as Asia/Jakarta
+  timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+  locale VARCHAR(16) NOT NULL DEFAULT 'en',
+  embed_color INT NULL,
+  updated_by VARCHAR(64) NOT NULL,
+  updated_at BIGINT NOT NULL,
+  FOREIGN KEY (default_tournament_types_id) REFERENCES tournament_types(id) ON DELETE SET NULL
+);
+
+COMMIT;
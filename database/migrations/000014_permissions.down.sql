@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS tournament_staff;
+ALTER TABLE tournaments DROP COLUMN owner_id;
@@ -0,0 +1,25 @@
+BEGIN;
+
+-- discord id of the member that created the tournament, NULL for tournaments created before owners existed
+ALTER TABLE tournaments ADD COLUMN owner_id VARCHAR(32) NULL;
+
+CREATE TABLE IF NOT EXISTS tournament_staff(
+  tournament_id CHAR(36) NOT NULL,
+  user_id VARCHAR(32) NOT NULL,
+  -- organizer or referee
+  role VARCHAR(16) NOT NULL,
+  added_by VARCHAR(64) NOT NULL,
+  added_at BIGINT NOT NULL,
+  PRIMARY KEY (tournament_id, user_id),
+  FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
+
+-- guild roles whose members are organizers or referees of every tournament of the guild
+CREATE TABLE IF NOT EXISTS role_permissions(
+  guild_id VARCHAR(32) NOT NULL,
+  role_id VARCHAR(32) NOT NULL,
+  role VARCHAR(16) NOT NULL,
+  PRIMARY KEY (guild_id, role_id)
+);
+
+COMMIT;
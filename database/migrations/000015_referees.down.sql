@@ -0,0 +1,2 @@
+ALTER TABLE tournaments DROP COLUMN auto_referee;
+ALTER TABLE matches DROP COLUMN referee_id;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- discord id of the member that referees the match, only they and managers can report its result
+ALTER TABLE matches ADD COLUMN referee_id VARCHAR(32) NULL;
+ALTER TABLE tournaments ADD COLUMN auto_referee BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,3 @@
+ALTER TABLE matches DROP COLUMN p2_checked_in_at;
+ALTER TABLE matches DROP COLUMN p1_checked_in_at;
+ALTER TABLE tournaments DROP COLUMN match_threads;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- matches of these tournaments are played in a private thread per match
+ALTER TABLE tournaments ADD COLUMN match_threads BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE matches ADD COLUMN p1_checked_in_at BIGINT NULL;
+ALTER TABLE matches ADD COLUMN p2_checked_in_at BIGINT NULL;
+
+COMMIT;
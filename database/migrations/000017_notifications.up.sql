@@ -0,0 +1,13 @@
+BEGIN;
+
+-- members without a row get every notification
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    discord_id VARCHAR(32) NOT NULL PRIMARY KEY,
+    match_ready BOOLEAN NOT NULL DEFAULT true,
+    opponent_checked_in BOOLEAN NOT NULL DEFAULT true,
+    results BOOLEAN NOT NULL DEFAULT true,
+    starting_soon BOOLEAN NOT NULL DEFAULT true,
+    updated_at BIGINT NOT NULL
+);
+
+COMMIT;
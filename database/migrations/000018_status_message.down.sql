@@ -0,0 +1 @@
+ALTER TABLE tournaments DROP COLUMN status_message_id;
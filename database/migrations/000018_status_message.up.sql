@@ -0,0 +1,6 @@
+BEGIN;
+
+-- pinned message in the tournament thread the bot keeps up to date while the tournament runs
+ALTER TABLE tournaments ADD COLUMN status_message_id VARCHAR(32) NULL;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- occurrence the latest edition of a recurrence was created for, a retried job finds the edition
+-- instead of creating another one
+ALTER TABLE recurrences ADD COLUMN last_occurrence_at BIGINT NULL;
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE tournament_templates DROP COLUMN game;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- game of the tournaments created from the template, they are rated in that game's pool
+ALTER TABLE tournament_templates ADD COLUMN game VARCHAR(64) NULL;
+
+COMMIT;
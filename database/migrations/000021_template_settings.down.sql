@@ -0,0 +1,6 @@
+ALTER TABLE tournament_templates
+  DROP COLUMN team_size,
+  DROP COLUMN roster_limit,
+  DROP COLUMN check_in_minutes,
+  DROP COLUMN auto_referee,
+  DROP COLUMN match_threads;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- team, check-in, referee and match thread settings of the tournaments created from the template,
+-- they default to the settings of a tournament that doesn't set them
+ALTER TABLE tournament_templates
+  ADD COLUMN team_size INT NOT NULL DEFAULT 1,
+  ADD COLUMN roster_limit INT NULL,
+  ADD COLUMN check_in_minutes INT NULL,
+  ADD COLUMN auto_referee BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN match_threads BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,51 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// CheckInEmbed shows who checked in for the tournament, attendees that are still missing when the
+// tournament starts lose their spot
+func CheckInEmbed(l i18n.Locale, t *models.Tournament, attendees, waitlist []models.Attendee) *discordgo.MessageEmbed {
+	var checkedIn, missing []models.Attendee
+	for _, a := range attendees {
+		if a.CheckedInAt.Valid {
+			checkedIn = append(checkedIn, a)
+		} else {
+			missing = append(missing, a)
+		}
+	}
+
+	waiting := 0
+	for _, a := range waitlist {
+		if a.CheckedInAt.Valid {
+			waiting++
+		}
+	}
+
+	description := i18n.T(l, "check_in.description", t.Name)
+	if t.Starting_At.Valid {
+		description += " " + i18n.T(l, "check_in.closes", t.Starting_At.Int64)
+	}
+	if t.Started_At.Valid {
+		description = i18n.T(l, "check_in.closed")
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: i18n.T(l, "check_in.checked_in"), Value: fmt.Sprintf("%d/%d", len(checkedIn), len(attendees)), Inline: true},
+		{Name: i18n.T(l, "check_in.waitlist_ready"), Value: fmt.Sprintf("%d", waiting), Inline: true},
+	}
+	if len(missing) > 0 && !t.Started_At.Valid {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: i18n.T(l, "check_in.missing"), Value: nameList(l, missing)})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "check_in.title"),
+		Description: description,
+		Fields:      fields,
+	}
+}
@@ -0,0 +1,11 @@
+package components
+
+import "github.com/bwmarrin/discordgo"
+
+// Paint gives the embed the color configured for the guild, a color of 0 keeps the discord default
+func Paint(e *discordgo.MessageEmbed, color int) *discordgo.MessageEmbed {
+	if color != 0 {
+		e.Color = color
+	}
+	return e
+}
@@ -0,0 +1,91 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+func ConfigurationEmbed(l i18n.Locale, t *models.Tournament) *discordgo.MessageEmbed {
+	fields := []*discordgo.MessageEmbedField{
+		{Name: i18n.T(l, "configuration.name"), Value: t.Name},
+	}
+
+	if len(t.Description.String) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.description"),
+			Value: t.Description.String,
+		})
+	}
+
+	if len(t.Rules.String) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.rules"),
+			Value: t.Rules.String,
+		})
+	}
+
+	registration := i18n.T(l, "configuration.registration_open")
+	if !t.Self_Register {
+		registration = i18n.T(l, "configuration.registration_managers")
+	}
+
+	capName := i18n.T(l, "configuration.player_cap")
+	if t.IsTeam() {
+		capName = i18n.T(l, "configuration.team_cap")
+	}
+
+	fields = append(fields,
+		&discordgo.MessageEmbedField{Name: i18n.T(l, "configuration.best_of"), Value: strconv.Itoa(t.Best_Of)},
+		&discordgo.MessageEmbedField{Name: capName, Value: t.TournamentType.Size},
+		&discordgo.MessageEmbedField{Name: i18n.T(l, "configuration.bracket_type"), Value: i18n.T(l, "configuration.single_elimination")},
+		&discordgo.MessageEmbedField{Name: i18n.T(l, "configuration.registration"), Value: registration},
+	)
+
+	if t.IsTeam() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.teams"),
+			Value: i18n.N(l, "configuration.team_size", t.Team_Size, t.RosterLimit()),
+		})
+	}
+
+	if t.Starting_At.Valid {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.starts"),
+			Value: fmt.Sprintf("<t:%d:F>", t.Starting_At.Int64),
+		})
+	}
+
+	if t.Owner_ID.Valid {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.owner"),
+			Value: fmt.Sprintf("<@%s>", t.Owner_ID.String),
+		})
+	}
+
+	if t.Check_In_Minutes.Valid {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.check_in"),
+			Value: i18n.N(l, "configuration.check_in_opens", int(t.Check_In_Minutes.Int64)),
+		})
+	}
+
+	if t.Match_Threads {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, "configuration.matches"),
+			Value: i18n.T(l, "configuration.match_threads"),
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "configuration.title"),
+		Description: i18n.T(l, "configuration.subtitle"),
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: string(t.ID),
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
 )
 
@@ -12,15 +13,66 @@ type MatchupPayload struct {
 	P2     models.AttendeeWithResult
 	Winner *models.AttendeeWithResult
 	Match  int
+	// discord id of the match referee, empty when the match has none
+	Referee string
+	Locale  i18n.Locale
+}
+
+const refereeField = "matchup.referee"
+
+// SetReferee shows the referee on a posted matchup embed, an empty referee id removes it. The field is
+// found in any language since the embed may have been posted before the language of the guild changed.
+func SetReferee(l i18n.Locale, e *discordgo.MessageEmbed, refereeID string) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(e.Fields)+1)
+	for _, f := range e.Fields {
+		if !i18n.Matches(refereeField, f.Name) {
+			fields = append(fields, f)
+		}
+	}
+	if refereeID != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(l, refereeField),
+			Value: fmt.Sprintf("<@%s>", refereeID),
+		})
+	}
+	e.Fields = fields
+	return e
+}
+
+const checkInField = "matchup.check_in"
+
+// SetCheckIn shows which sides of a match played in its own thread are ready to play
+func SetCheckIn(l i18n.Locale, e *discordgo.MessageEmbed, p1Ready, p2Ready bool) *discordgo.MessageEmbed {
+	status := func(ready bool) string {
+		if ready {
+			return i18n.T(l, "matchup.ready")
+		}
+		return i18n.T(l, "matchup.waiting")
+	}
+
+	value := i18n.T(l, "matchup.check_in_status", status(p1Ready), status(p2Ready))
+	for _, f := range e.Fields {
+		if i18n.Matches(checkInField, f.Name) {
+			f.Name = i18n.T(l, checkInField)
+			f.Value = value
+			return e
+		}
+	}
+	e.Fields = append(e.Fields, &discordgo.MessageEmbedField{Name: i18n.T(l, checkInField), Value: value})
+	return e
 }
 
 func MatchupEmbed(p MatchupPayload) *discordgo.MessageEmbed {
 	fields := []*discordgo.MessageEmbedField{}
 
-	formatOpponent := func(player models.Player, isWinner *models.AttendeeWithResult) string {
-		name := player.Name
+	formatOpponent := func(opponent models.AttendeeWithResult, isWinner *models.AttendeeWithResult) string {
+		player := opponent.Player
+		name := opponent.Name()
 
-		if player.DiscordID != "" {
+		// teams are reached through their captain
+		if player.DiscordID != "" && opponent.TeamID.Valid {
+			name = i18n.T(p.Locale, "matchup.captain", name, player.DiscordID)
+		} else if player.DiscordID != "" {
 			name = fmt.Sprintf("%s (<@%s>)", name, player.DiscordID)
 		}
 
@@ -35,15 +87,15 @@ func MatchupEmbed(p MatchupPayload) *discordgo.MessageEmbed {
 		return name
 	}
 
-	p1Name := formatOpponent(p.P1.Player, p.Winner)
-	p2Name := formatOpponent(p.P2.Player, p.Winner)
+	p1Name := formatOpponent(p.P1, p.Winner)
+	p2Name := formatOpponent(p.P2, p.Winner)
 
 	fields = append(fields, &discordgo.MessageEmbedField{
-		Name:  "Opponent 1",
+		Name:  i18n.T(p.Locale, "matchup.opponent", 1),
 		Value: p1Name, Inline: true},
 	)
 	fields = append(fields, &discordgo.MessageEmbedField{
-		Name:  "Opponent 2",
+		Name:  i18n.T(p.Locale, "matchup.opponent", 2),
 		Value: p2Name, Inline: true},
 	)
 
@@ -52,13 +104,13 @@ func MatchupEmbed(p MatchupPayload) *discordgo.MessageEmbed {
 		matchCount--
 	}
 
-	return &discordgo.MessageEmbed{
+	return SetReferee(p.Locale, &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    "spade",
 			URL:     "https://www.github.com/dimfu/spade",
 			IconURL: "https://cdn3.evostore.io/productimages/vow_api/l/sby23247_01.jpg",
 		},
-		Title:  fmt.Sprintf("Tournament Match #%d", matchCount),
+		Title:  i18n.T(p.Locale, "matchup.title", matchCount),
 		Fields: fields,
-	}
+	}, p.Referee)
 }
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// NotificationEmbed shows which direct messages the member receives
+func NotificationEmbed(l i18n.Locale, p *models.NotificationPreferences) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(models.NotificationKinds))
+	for _, kind := range models.NotificationKinds {
+		value := i18n.T(l, "notification.off")
+		if p.Wants(kind) {
+			value = i18n.T(l, "notification.on")
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(l, "notification."+string(kind)),
+			Value:  value,
+			Inline: true,
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "notification.title"),
+		Description: i18n.T(l, "notification.description"),
+		Fields:      fields,
+	}
+}
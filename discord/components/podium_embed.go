@@ -0,0 +1,70 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// podiumPlaces is how far down the placements are listed, the rest is only counted
+const podiumPlaces = 8
+
+var medals = map[int]string{1: "🥇", 2: "🥈", 3: "🥉"}
+
+// PodiumEmbed announces the final placements of a completed tournament, players that share a place
+// are listed together under the range of places they hold, such as 3rd-4th
+func PodiumEmbed(l i18n.Locale, t *models.Tournament, placements []models.Placement) *discordgo.MessageEmbed {
+	var (
+		fields []*discordgo.MessageEmbedField
+		rest   int
+	)
+	for idx := 0; idx < len(placements); {
+		place := placements[idx].Place
+		var mentions []string
+		for idx < len(placements) && placements[idx].Place == place {
+			mention := fmt.Sprintf("<@%s>", placements[idx].Player.DiscordID)
+			if placements[idx].TeamName != "" {
+				mention = fmt.Sprintf("**%s** (%s)", placements[idx].TeamName, mention)
+			}
+			mentions = append(mentions, mention)
+			idx++
+		}
+
+		if place > podiumPlaces {
+			rest += len(mentions)
+			continue
+		}
+
+		name := i18n.Ordinal(l, place)
+		if last := place + len(mentions) - 1; last > place {
+			name = fmt.Sprintf("%s-%s", i18n.Ordinal(l, place), i18n.Ordinal(l, last))
+		}
+		if medal, ok := medals[place]; ok {
+			name = medal + " " + name
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   name,
+			Value:  strings.Join(mentions, "\n"),
+			Inline: place <= 2,
+		})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "podium.title", t.Name),
+		Description: i18n.T(l, "podium.description"),
+		Fields:      fields,
+	}
+	if t.Completed_At.Valid {
+		embed.Timestamp = time.Unix(t.Completed_At.Int64, 0).UTC().Format(time.RFC3339)
+	}
+	if rest > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: i18n.N(l, "podium.more_players", rest),
+		}
+	}
+	return embed
+}
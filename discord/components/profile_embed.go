@@ -0,0 +1,100 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+const (
+	profileListSize = 5
+	h2hListSize     = 10
+)
+
+func matchLine(l i18n.Locale, m models.PlayerMatch) string {
+	result := i18n.T(l, "profile.loss")
+	if m.Won {
+		result = i18n.T(l, "profile.win")
+	}
+	line := i18n.T(l, "profile.match_line", result, m.Score, m.OpponentScore, m.Opponent.Name, m.TournamentName, m.Round)
+	if m.Status == models.MATCH_WALKOVER {
+		line += " " + i18n.T(l, "profile.walkover")
+	}
+	if m.CompletedAt > 0 {
+		line += fmt.Sprintf(" <t:%d:R>", m.CompletedAt)
+	}
+	return line
+}
+
+func listValue(lines []string, empty string) string {
+	if len(lines) == 0 {
+		return empty
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ProfileEmbed shows the profile of a player, rating is nil when the player has no rated match yet
+func ProfileEmbed(l i18n.Locale, p *models.Profile, rating *models.PlayerRating) *discordgo.MessageEmbed {
+	ratingValue := i18n.T(l, "profile.unrated")
+	if rating != nil {
+		ratingValue = fmt.Sprintf("%.0f ± %.0f", rating.Rating, 2*rating.Deviation)
+	}
+
+	var placements []string
+	for _, pl := range p.Placements[:min(profileListSize, len(p.Placements))] {
+		placements = append(placements, i18n.T(l, "profile.placement", i18n.Ordinal(l, pl.Place), pl.TournamentName))
+	}
+
+	var recent []string
+	for _, m := range p.Recent {
+		recent = append(recent, matchLine(l, m))
+	}
+
+	var opponents []string
+	for _, o := range p.Opponents[:min(profileListSize, len(p.Opponents))] {
+		opponents = append(opponents, i18n.N(l, "profile.opponent", o.Played(), o.Opponent.Name,
+			i18n.T(l, "profile.record", o.Wins, o.Losses)))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "profile.title", p.Player.Name),
+		Description: fmt.Sprintf("<@%s>", p.Player.DiscordID),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(l, "profile.tournaments"), Value: fmt.Sprintf("%d", p.Tournaments), Inline: true},
+			{Name: i18n.T(l, "profile.record_title"), Value: i18n.T(l, "profile.record", p.Wins, p.Losses), Inline: true},
+			{Name: i18n.T(l, "profile.rating"), Value: ratingValue, Inline: true},
+			{Name: i18n.T(l, "profile.best_placements"), Value: listValue(placements, i18n.T(l, "profile.no_placements"))},
+			{Name: i18n.T(l, "profile.recent_matches"), Value: listValue(recent, i18n.T(l, "profile.no_matches"))},
+			{Name: i18n.T(l, "profile.opponents"), Value: listValue(opponents, i18n.T(l, "profile.no_opponents"))},
+		},
+	}
+}
+
+// HeadToHeadEmbed shows every match played between two players, matches are seen from the first player
+func HeadToHeadEmbed(l i18n.Locale, a, b *models.Player, matches []models.PlayerMatch) *discordgo.MessageEmbed {
+	var wins int
+	for _, m := range matches {
+		if m.Won {
+			wins++
+		}
+	}
+
+	var lines []string
+	for _, m := range matches[:min(h2hListSize, len(matches))] {
+		lines = append(lines, matchLine(l, m))
+	}
+	if len(matches) > h2hListSize {
+		lines = append(lines, i18n.N(l, "profile.older_matches", len(matches)-h2hListSize))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s vs %s", a.Name, b.Name),
+		Description: fmt.Sprintf("<@%s> %d - %d <@%s>", a.DiscordID, wins, len(matches)-wins, b.DiscordID),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(l, "profile.matches"), Value: listValue(lines, i18n.T(l, "profile.never_met"))},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+func RatingEmbed(l i18n.Locale, r *models.PlayerRating) *discordgo.MessageEmbed {
+	pool := i18n.T(l, "rating.all_games")
+	if r.Game != "" {
+		pool = r.Game
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "rating.title", r.Player.Name),
+		Description: fmt.Sprintf("<@%s>", r.Player.DiscordID),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Glicko-2", Value: fmt.Sprintf("%.0f ± %.0f", r.Rating, 2*r.Deviation), Inline: true},
+			{Name: "Elo", Value: fmt.Sprintf("%.0f", r.Elo), Inline: true},
+			{Name: i18n.T(l, "profile.record_title"), Value: i18n.T(l, "profile.record", r.Wins, r.Losses), Inline: true},
+			{Name: i18n.T(l, "rating.game"), Value: pool},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(l, "rating.volatility", r.Volatility),
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+func settingValue(l i18n.Locale, v string) string {
+	if v == "" {
+		return i18n.T(l, "common.not_set")
+	}
+	return v
+}
+
+// SettingsEmbed shows the configuration of the guild, format is the name of the default tournament format
+func SettingsEmbed(l i18n.Locale, g *models.GuildSettings, format string, mappings []models.RoleMapping) *discordgo.MessageEmbed {
+	channel, role := "", "Tournament Manager"
+	if g.TournamentChannelID.Valid {
+		channel = fmt.Sprintf("<#%s>", g.TournamentChannelID.String)
+	}
+	if g.ManagerRoleID.Valid {
+		role = fmt.Sprintf("<@&%s>", g.ManagerRoleID.String)
+	}
+
+	// the language of the server is shown in its own language
+	locale, _ := i18n.Parse(g.Locale)
+
+	var permissions strings.Builder
+	for _, mp := range mappings {
+		fmt.Fprintf(&permissions, "<@&%s>: %s\n", mp.RoleID, i18n.T(l, "role."+mp.Role.String()))
+	}
+
+	return Paint(&discordgo.MessageEmbed{
+		Title:       i18n.T(l, "settings.title"),
+		Description: i18n.T(l, "settings.description"),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(l, "settings.channel"), Value: settingValue(l, channel), Inline: true},
+			{Name: i18n.T(l, "settings.manager_role"), Value: role, Inline: true},
+			{Name: i18n.T(l, "settings.format"), Value: settingValue(l, format), Inline: true},
+			{Name: i18n.T(l, "settings.timezone"), Value: g.Timezone, Inline: true},
+			{Name: i18n.T(l, "settings.locale"), Value: locale.Name(), Inline: true},
+			{Name: i18n.T(l, "settings.color"), Value: settingValue(l, models.FormatEmbedColor(g.EmbedColor)), Inline: true},
+			{Name: i18n.T(l, "settings.permissions"), Value: settingValue(l, permissions.String())},
+		},
+	}, g.Color())
+}
@@ -0,0 +1,54 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// signupListLength keeps the list of names within the limit of an embed field
+const signupListLength = 1000
+
+func nameList(l i18n.Locale, attendees []models.Attendee) string {
+	var b strings.Builder
+	for idx := range attendees {
+		name := attendees[idx].Name()
+		if idx > 0 {
+			name = ", " + name
+		}
+		if b.Len()+len(name) > signupListLength {
+			b.WriteString(i18n.N(l, "signup.more", len(attendees)-idx))
+			break
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+// SignupEmbed shows who registered for the tournament and who is waiting for a spot
+func SignupEmbed(l i18n.Locale, t *models.Tournament, attendees, waitlist []models.Attendee) *discordgo.MessageEmbed {
+	description := i18n.T(l, "signup.description", t.Name)
+	if !t.Registration_Open {
+		description = i18n.T(l, "signup.closed")
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: i18n.T(l, "signup.players"), Value: fmt.Sprintf("%d/%d", len(attendees), t.Cap()), Inline: true},
+		{Name: i18n.T(l, "signup.waitlist"), Value: fmt.Sprintf("%d", len(waitlist)), Inline: true},
+	}
+	if len(attendees) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: i18n.T(l, "signup.registered"), Value: nameList(l, attendees)})
+	}
+	if len(waitlist) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: i18n.T(l, "signup.waiting"), Value: nameList(l, waitlist)})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(l, "signup.title"),
+		Description: description,
+		Fields:      fields,
+	}
+}
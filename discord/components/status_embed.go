@@ -0,0 +1,98 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+const (
+	// statusListed is how many entries every list of the status embed shows
+	statusListed   = 8
+	maxFieldLength = 1024
+)
+
+// statusList joins the lines of a status field, lines past the listed ones or the field length are
+// summarized
+func statusList(l i18n.Locale, lines []string) string {
+	if len(lines) == 0 {
+		return i18n.T(l, "common.none")
+	}
+
+	var b strings.Builder
+	for idx, line := range lines {
+		more := i18n.T(l, "common.and_more", len(lines)-idx)
+		if idx == statusListed || b.Len()+len(line)+len(more)+2 > maxFieldLength {
+			b.WriteString(more)
+			break
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// StatusEmbed shows the progress of the tournament, names maps attendee ids to how they are shown
+func StatusEmbed(l i18n.Locale, t *models.Tournament, status *models.TournamentStatus, names map[int]string) *discordgo.MessageEmbed {
+	name := func(id int64) string {
+		if n, ok := names[int(id)]; ok {
+			return n
+		}
+		return i18n.T(l, "matchup.not_available")
+	}
+	matchup := func(r models.MatchRecord) string {
+		line := fmt.Sprintf("**%s** vs **%s**", name(r.P1AttendeeID.Int64), name(r.P2AttendeeID.Int64))
+		if r.MessageID.Valid && t.Guild_ID.Valid {
+			line = fmt.Sprintf("%s [↗](https://discord.com/channels/%s/%s/%s)", line, t.Guild_ID.String,
+				r.ChannelID.String, r.MessageID.String)
+		}
+		return line
+	}
+
+	description := i18n.T(l, "status.not_started")
+	switch {
+	case status.Completed:
+		description = i18n.T(l, "status.completed")
+	case t.Started_At.Valid && status.Rounds > 0:
+		description = i18n.T(l, "status.round", status.Round, status.Rounds)
+	}
+
+	live := make([]string, 0, len(status.Live))
+	for _, r := range status.Live {
+		live = append(live, "🔴 "+matchup(r))
+	}
+	upcoming := make([]string, 0, len(status.Upcoming))
+	for _, r := range status.Upcoming {
+		upcoming = append(upcoming, i18n.T(l, "status.upcoming", r.Round, matchup(r)))
+	}
+	recent := make([]string, 0, len(status.Recent))
+	for _, r := range status.Recent {
+		winner, loser := r.P1AttendeeID.Int64, r.P2AttendeeID.Int64
+		winnerScore, loserScore := r.P1Score, r.P2Score
+		if r.WinnerAttendeeID.Int64 == loser {
+			winner, loser, winnerScore, loserScore = loser, winner, loserScore, winnerScore
+		}
+		recent = append(recent, fmt.Sprintf("**%s** %d - %d %s", name(winner), winnerScore, loserScore, name(loser)))
+	}
+	remaining := make([]string, 0, len(status.Remaining))
+	for _, a := range status.Remaining {
+		remaining = append(remaining, a.Name())
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📊 %s", t.Name),
+		Description: description,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(l, "status.live"), Value: statusList(l, live)},
+			{Name: i18n.T(l, "status.up_next"), Value: statusList(l, upcoming)},
+			{Name: i18n.T(l, "status.recent"), Value: statusList(l, recent)},
+			{Name: i18n.T(l, "status.remaining", len(remaining)), Value: statusList(l, remaining)},
+		},
+		Footer:    &discordgo.MessageEmbedFooter{Text: i18n.T(l, "status.updated")},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
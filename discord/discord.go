@@ -2,33 +2,35 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dimfu/spade/config"
+	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/handlers"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/scheduler"
 )
 
+// ensureRole creates the manager role of the guild unless the configured one or a role named after it
+// already exists
 func ensureRole(dg *discordgo.Session, gid string) (*discordgo.Role, error) {
-	st, err := dg.GuildRoles(gid)
-
-	if err != nil {
-		return nil, err
+	role, err := base.ManagerRole(dg, gid)
+	if err == nil {
+		return role, nil
 	}
-
-	for _, role := range st {
-		if role.Name == "Tournament Manager" {
-			return role, nil
-		}
+	if !errors.Is(err, base.ERR_NO_MANAGER_ROLE) {
+		return nil, err
 	}
 
 	r, err := dg.GuildRoleCreate(
 		gid,
 		&discordgo.RoleParams{
-			Name: "Tournament Manager",
+			Name: base.MANAGER_ROLE,
 		},
 	)
 
@@ -58,7 +60,7 @@ func Init(ctx context.Context) {
 
 	// register commands
 	for _, handler := range handlers.CommandHandlers {
-		cmd := handler.Command()
+		cmd := i18n.LocalizeCommand(handler.Command())
 		_, err := dg.ApplicationCommandCreate(dg.State.User.ID, "", cmd)
 		if err != nil {
 			log.Printf("error creating command %s: %v", cmd.Name, err)
@@ -74,40 +76,28 @@ func Init(ctx context.Context) {
 		}
 	})
 
-	// listens to which command is being used, and do the handler
-	dg.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		switch i.Type {
-		case discordgo.InteractionApplicationCommand:
-			for _, handler := range handlers.CommandHandlers {
-				if handler.Command().Name == i.ApplicationCommandData().Name {
-					if hWithCtx, ok := handler.(base.CommandWithCtx); ok {
-						hWithCtx.WithCtx(ctx)
-					}
-					handler.Handler(dg, i)
-					return
-				}
-			}
-		case discordgo.InteractionMessageComponent:
-			for _, handler := range handlers.ComponentHandlers {
-				if strings.HasPrefix(i.MessageComponentData().CustomID, handler.Name()) {
-					handler.Handler(dg, i)
-					return
-				}
-			}
-		case discordgo.InteractionModalSubmit:
-			for _, handler := range handlers.ModalSubmitHandlers {
-				if strings.HasPrefix(i.ModalSubmitData().CustomID, handler.Name()) {
-					handler.Handler(dg, i)
-					return
-				}
-			}
-		}
-	})
+	// routes every interaction to the handler registered for it
+	dg.AddHandler(handlers.NewRouter(ctx).Dispatch)
 
 	if err != nil {
 		log.Fatalf("error creating slash commands: %v", err)
 	}
 
+	// tournaments played before matches were stored get their matches rebuilt from their history
+	if err := models.NewMatchModel(database.GetDB()).Backfill(); err != nil {
+		log.Printf("error backfilling matches: %v", err)
+	}
+
+	// run the persisted jobs such as recurring tournaments
+	sc := scheduler.GetScheduler()
+	for _, job := range handlers.JobHandlers {
+		if jWithCtx, ok := job.(base.CommandWithCtx); ok {
+			jWithCtx.WithCtx(ctx)
+		}
+		sc.Register(job)
+	}
+	go sc.Run(ctx, dg)
+
 	log.Println("bot is now running")
 	<-ctx.Done()
 }
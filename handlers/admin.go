@@ -1,11 +1,13 @@
 package handlers
 
 import (
-	"fmt"
+	"errors"
 	"log"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
 )
 
 type AdminHandler struct{}
@@ -65,26 +67,18 @@ func (p *AdminHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 		usrid := payload[2 : len(payload)-1]
 		u, err := s.User(usrid)
 		if err != nil {
-			base.Respond("Cannot add invalid user, use @user to properly target user", s, i, true)
+			base.Reply("admin.invalid_user", s, i, true)
 		}
 		st = u
 	}
 
-	roles, err := s.GuildRoles(i.GuildID)
-	if err != nil {
-		log.Println(err.Error())
+	tm, err := base.ManagerRole(s, i.GuildID)
+	if errors.Is(err, base.ERR_NO_MANAGER_ROLE) {
+		base.RespondError(err, s, i)
 		return
 	}
-
-	var tm *discordgo.Role
-	for _, role := range roles {
-		if role.Name == "Tournament Manager" {
-			tm = role
-		}
-	}
-
-	if tm == nil {
-		base.Respond("Can't find tournament role", s, i, false)
+	if err != nil {
+		log.Println(err.Error())
 		return
 	}
 
@@ -96,13 +90,15 @@ func (p *AdminHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 			ret = err.Error()
 			break
 		}
-		ret = fmt.Sprintf("<@%s> is now tournament manager", st.ID)
+		ret = i18n.T(base.GuildLocale(i.GuildID), "admin.added", st.ID)
+		base.Audit(i, models.AUDIT_ADMIN_ADD, "", nil, map[string]string{"target": st.ID, "role": tm.ID})
 	case "remove":
 		if err := s.GuildMemberRoleRemove(i.GuildID, st.ID, tm.ID); err != nil {
 			ret = err.Error()
 			break
 		}
-		ret = fmt.Sprintf("<@%s> is no longer tournament manager", st.ID)
+		ret = i18n.T(base.GuildLocale(i.GuildID), "admin.removed", st.ID)
+		base.Audit(i, models.AUDIT_ADMIN_REMOVE, "", map[string]string{"target": st.ID, "role": tm.ID}, nil)
 	default:
 	}
 
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+const auditPageSize = 10
+
+var auditMinPage = float64(1)
+
+type AuditHandler struct {
+	Base *base.BaseAdmin
+}
+
+type AuditComponentHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *AuditHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "audit",
+		Description: "Browse the log of administrative actions",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "list",
+				Description: "Page through the audit log",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "page",
+						Description: "Page to start from",
+						Required:    false,
+						MinValue:    &auditMinPage,
+					},
+				},
+			},
+			{
+				Name:        "export",
+				Description: "Export the whole audit log as csv",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *AuditHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	err := h.Base.HasPermit(s, i)
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	subcmd := data.Options[0]
+	switch subcmd.Name {
+	case "list":
+		page := 1
+		if len(subcmd.Options) > 0 {
+			page = int(subcmd.Options[0].IntValue())
+		}
+		res, err := auditPage(base.Locale(i), i.GuildID, page)
+		if err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		res.Flags = discordgo.MessageFlagsEphemeral
+		router.Respond(s, i, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: res,
+		})
+	case "export":
+		h.export(s, i)
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *AuditHandler) export(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	alm := models.NewAuditLogModel(database.GetDB())
+	logs, err := alm.List(i.GuildID, 0, 0)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if len(logs) == 0 {
+		base.Reply("audit.empty", s, i, true)
+		return
+	}
+
+	data := [][]string{{"id", "created_at", "actor_id", "action", "tournament_id", "before", "after"}}
+	for _, l := range logs {
+		data = append(data, []string{
+			strconv.Itoa(l.ID), strconv.FormatInt(l.CreatedAt, 10), l.ActorID, l.Action,
+			l.TournamentID.String, l.Before.String, l.After.String,
+		})
+	}
+
+	buf, err := base.ToCSV(data)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Files: []*discordgo.File{
+				{Name: fmt.Sprintf("audit-%s.csv", i.GuildID), ContentType: "text/csv", Reader: buf},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+}
+
+const ROUTE_AUDIT_PAGE = "audit.page"
+
+func (h *AuditComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_AUDIT_PAGE, Args: 1, Role: models.ROLE_MANAGER, Handler: h.page},
+	}
+}
+
+func (h *AuditComponentHandler) page(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	page, err := cid.Int(0)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	res, err := auditPage(base.Locale(i), i.GuildID, page)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: res,
+	})
+}
+
+func auditPage(locale i18n.Locale, guildID string, page int) (*discordgo.InteractionResponseData, error) {
+	alm := models.NewAuditLogModel(database.GetDB())
+
+	count, err := alm.Count(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := (count + auditPageSize - 1) / auditPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+	if page > pages {
+		page = pages
+	}
+
+	logs, err := alm.List(guildID, auditPageSize, (page-1)*auditPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(logs))
+	for _, l := range logs {
+		value := fmt.Sprintf("<@%s> <t:%d:R>", l.ActorID, l.CreatedAt)
+		if l.TournamentID.Valid {
+			value += "\n" + i18n.T(locale, "audit.tournament", l.TournamentID.String)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("#%d %s", l.ID, l.Action),
+			Value: value,
+		})
+	}
+
+	description := i18n.T(locale, "audit.description")
+	if len(logs) == 0 {
+		description = i18n.T(locale, "audit.none")
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.T(locale, "audit.title"),
+				Description: description,
+				Fields:      fields,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: i18n.T(locale, "common.page", page, pages),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(locale, "common.previous"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page <= 1,
+						CustomID: router.ID(ROUTE_AUDIT_PAGE, page-1),
+					},
+					discordgo.Button{
+						Label:    i18n.T(locale, "common.next"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page >= pages,
+						CustomID: router.ID(ROUTE_AUDIT_PAGE, page+1),
+					},
+				},
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}, nil
+}
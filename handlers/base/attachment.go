@@ -0,0 +1,40 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+)
+
+var attachmentClient = &http.Client{Timeout: 15 * time.Second}
+
+// ReadAttachment downloads the file uploaded along with the interaction, files bigger than maxSize are rejected
+func ReadAttachment(att *discordgo.MessageAttachment, maxSize int) ([]byte, error) {
+	if att.Size > maxSize {
+		return nil, i18n.NewError("error.file_too_big", maxSize/1024)
+	}
+
+	resp, err := attachmentClient.Get(att.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading attachment %s: %s", att.Filename, resp.Status)
+	}
+
+	// the reported size can't be trusted, read one byte more than allowed to notice oversized files
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSize {
+		return nil, i18n.NewError("error.file_too_big", maxSize/1024)
+	}
+	return data, nil
+}
@@ -0,0 +1,58 @@
+package base
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/models"
+)
+
+// Actor returns the discord user that triggered the interaction, both for guild and dm interactions
+func Actor(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+func auditData(v interface{}) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("error marshalling audit data: %v", err)
+		return sql.NullString{}
+	}
+	// typed nil pointers are marshalled as null
+	if string(b) == "null" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(b), Valid: true}
+}
+
+// Audit records the administrative action done by the interaction actor, failing to record
+// should never block the action itself so errors are only logged
+func Audit(i *discordgo.InteractionCreate, action, tournamentID string, before, after interface{}) {
+	actor := Actor(i)
+	if actor == nil {
+		log.Printf("cannot find actor for audit action %s", action)
+		return
+	}
+
+	l := &models.AuditLog{
+		GuildID:      sql.NullString{String: i.GuildID, Valid: i.GuildID != ""},
+		ActorID:      actor.ID,
+		Action:       action,
+		TournamentID: sql.NullString{String: tournamentID, Valid: tournamentID != ""},
+		Before:       auditData(before),
+		After:        auditData(after),
+	}
+
+	if err := models.NewAuditLogModel(database.GetDB()).Insert(l); err != nil {
+		log.Printf("error recording audit action %s: %v", action, err)
+	}
+}
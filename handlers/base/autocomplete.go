@@ -0,0 +1,38 @@
+package base
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/router"
+)
+
+// MAX_CHOICES is the most suggestions discord shows for an option
+const MAX_CHOICES = 25
+
+// Focused returns the option that is being typed, options of subcommands included
+func Focused(opts []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range opts {
+		if opt.Focused {
+			return opt
+		}
+		if focused := Focused(opt.Options); focused != nil {
+			return focused
+		}
+	}
+	return nil
+}
+
+// Suggest responds to an autocomplete interaction, choices past MAX_CHOICES are dropped
+func Suggest(s *discordgo.Session, i *discordgo.InteractionCreate, choices []*discordgo.ApplicationCommandOptionChoice) {
+	if len(choices) > MAX_CHOICES {
+		choices = choices[:MAX_CHOICES]
+	}
+	err := router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+	if err != nil {
+		log.Printf("error responding to autocomplete of %s: %v", i.ApplicationCommandData().Name, err)
+	}
+}
@@ -7,15 +7,18 @@ import (
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
 )
 
 var (
-	ERR_INTERNAL_ERROR            = errors.New("Something went wrong while executing this instruction")
-	ERR_CREATING_TOURNAMENT       = errors.New("Something went wrong while creating tournament")
-	ERR_GENERATE_BRACKET          = errors.New("Error occured when generating tournament bracket template")
-	ERR_GET_TOURNAMENT            = errors.New("Cannot find this tournament record")
-	ERR_GET_TOURNAMENT_IN_CHANNEL = errors.New("Cannot find tournament in this channel, make sure to run this command inside the tournament channel")
-	ERR_GET_TOURNAMENT_TYPES      = errors.New("Cannot get the list of tournament types")
+	ERR_INTERNAL_ERROR            = i18n.NewError("error.internal")
+	ERR_CREATING_TOURNAMENT       = i18n.NewError("error.creating_tournament")
+	ERR_GENERATE_BRACKET          = i18n.NewError("error.generate_bracket")
+	ERR_GET_TOURNAMENT            = i18n.NewError("error.get_tournament")
+	ERR_GET_TOURNAMENT_IN_CHANNEL = i18n.NewError("error.get_tournament_in_channel")
+	ERR_GET_TOURNAMENT_TYPES      = i18n.NewError("error.get_tournament_types")
 	ERR_FOUND_TOURNAMENT_WINNER   = errors.New("Tournament winner found")
 )
 
@@ -34,14 +37,20 @@ type CommandWithCtx interface {
 	WithCtx(ctx context.Context)
 }
 
+// Autocompleter is a command that suggests values for its options while they are being typed
+type Autocompleter interface {
+	Command
+	Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+// Component handles the message components of its routes
 type Component interface {
-	Name() string
-	Handler(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Routes() []router.Route
 }
 
+// Modal handles the modal submissions of its routes
 type Modal interface {
-	Name() string
-	Handler(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Routes() []router.Route
 }
 
 type BaseAdmin struct {
@@ -67,43 +76,26 @@ func Respond(r string, s *discordgo.Session, i *discordgo.InteractionCreate, eph
 		response.Data.Flags = discordgo.MessageFlagsEphemeral
 	}
 
-	s.InteractionRespond(i.Interaction, response)
+	router.Respond(s, i, response)
+}
+
+// Reply responds with the message of key, translated into the language of the member when only they
+// see it and into the language of the server otherwise
+func Reply(key string, s *discordgo.Session, i *discordgo.InteractionCreate, ephemeral bool, args ...interface{}) {
+	Respond(i18n.T(ReplyLocale(i, ephemeral), key, args...), s, i, ephemeral)
+}
+
+// RespondError shows the error to the member, in their language when it is translated
+func RespondError(err error, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	Respond(i18n.Text(Locale(i), err), s, i, true)
 }
 
 func SendError(err error, s *discordgo.Session, i *discordgo.InteractionCreate) {
 	log.Println(err)
-	Respond(ERR_INTERNAL_ERROR.Error(), s, i, true)
+	RespondError(ERR_INTERNAL_ERROR, s, i)
 }
 
+// HasPermit checks that the actor manages every tournament of the guild
 func (h *BaseAdmin) HasPermit(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	user := i.Member
-
-	// skips check if user has asdmin access
-	if user.Permissions&discordgo.PermissionAdministrator == discordgo.PermissionAdministrator {
-		return nil
-	}
-
-	roles, err := s.GuildRoles(i.GuildID)
-	if err != nil {
-		return err
-	}
-
-	var tm *discordgo.Role
-	for _, role := range roles {
-		if role.Name == "Tournament Manager" {
-			tm = role
-		}
-	}
-
-	if tm == nil {
-		return errors.New("Can't find tournament role")
-	}
-
-	for _, ur := range user.Roles {
-		if ur == tm.ID {
-			return nil
-		}
-	}
-
-	return errors.New("Insufficent permission to use this command")
+	return h.Authorize(s, i, nil, models.ROLE_MANAGER)
 }
@@ -0,0 +1,20 @@
+package base
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+func ToCSV(data [][]string) (*bytes.Buffer, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	defer writer.Flush()
+
+	for _, value := range data {
+		if err := writer.Write(value); err != nil {
+			return &buffer, err
+		}
+	}
+
+	return &buffer, nil
+}
@@ -0,0 +1,69 @@
+package base
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/models"
+)
+
+// Notify sends content to the members by direct message, members who turned kind off are skipped.
+// Members who can't be messaged, usually because their direct messages are closed, are mentioned
+// in the fallback channel instead. No fallback is posted when the channel is empty.
+func Notify(s *discordgo.Session, kind models.NotificationKind, userIDs []string, fallbackChannelID, content string) {
+	nm := models.NewNotificationModel(database.GetDB())
+
+	seen := make(map[string]bool, len(userIDs))
+	unreachable := []string{}
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		prefs, err := nm.Get(userID)
+		if err != nil {
+			log.Printf("error loading notification preferences of %s: %v", userID, err)
+			prefs = models.DefaultNotificationPreferences(userID)
+		}
+		if !prefs.Wants(kind) {
+			continue
+		}
+
+		if err := directMessage(s, userID, content); err != nil {
+			log.Printf("error sending %s notification to %s: %v", kind, userID, err)
+			unreachable = append(unreachable, userID)
+		}
+	}
+
+	if len(unreachable) == 0 || fallbackChannelID == "" {
+		return
+	}
+
+	mentions := make([]string, len(unreachable))
+	for idx, userID := range unreachable {
+		mentions[idx] = fmt.Sprintf("<@%s>", userID)
+	}
+	_, err := s.ChannelMessageSendComplex(fallbackChannelID, &discordgo.MessageSend{
+		Content:         fmt.Sprintf("%s %s", strings.Join(mentions, " "), content),
+		AllowedMentions: &discordgo.MessageAllowedMentions{Users: unreachable},
+	})
+	if err != nil {
+		log.Printf("error posting %s notification in %s: %v", kind, fallbackChannelID, err)
+	}
+}
+
+func directMessage(s *discordgo.Session, userID, content string) error {
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content:         content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	return err
+}
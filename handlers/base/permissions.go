@@ -0,0 +1,100 @@
+package base
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+var ERR_INSUFFICIENT_PERMISSION = i18n.NewError("error.insufficient_permission")
+
+// isManager reports whether the member is an administrator or holds the manager role of the guild
+func isManager(s *discordgo.Session, i *discordgo.InteractionCreate) (bool, error) {
+	user := i.Member
+
+	// skips check if user has asdmin access
+	if user.Permissions&discordgo.PermissionAdministrator == discordgo.PermissionAdministrator {
+		return true, nil
+	}
+
+	tm, err := ManagerRole(s, i.GuildID)
+	if errors.Is(err, ERR_NO_MANAGER_ROLE) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, ur := range user.Roles {
+		if ur == tm.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Role resolves the role of the actor in the tournament, a nil tournament only resolves the roles that
+// apply to every tournament of the guild
+func Role(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) (models.Role, error) {
+	if i.Member == nil || i.Member.User == nil {
+		return models.ROLE_NONE, nil
+	}
+
+	manager, err := isManager(s, i)
+	if err != nil {
+		return models.ROLE_NONE, err
+	}
+	member := models.Member{UserID: i.Member.User.ID, Roles: i.Member.Roles, Manager: manager}
+	if manager {
+		return models.ResolveRole(member, t, nil, nil), nil
+	}
+
+	pm := models.NewPermissionModel(database.GetDB())
+	mappings, err := pm.Mappings(i.GuildID)
+	if err != nil {
+		return models.ROLE_NONE, err
+	}
+
+	staff := []models.StaffMember{}
+	if t != nil {
+		if staff, err = pm.Staff(string(t.ID)); err != nil {
+			return models.ROLE_NONE, err
+		}
+	}
+
+	return models.ResolveRole(member, t, staff, mappings), nil
+}
+
+// Authorize checks that the actor holds at least the required role in the tournament, tournaments of
+// another guild are treated as not found since roles only apply to the guild they are held in
+func (h *BaseAdmin) Authorize(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament, required models.Role) error {
+	if t != nil && t.Guild_ID.String != i.GuildID {
+		return ERR_GET_TOURNAMENT
+	}
+
+	role, err := Role(s, i, t)
+	if err != nil {
+		return err
+	}
+	if role < required {
+		return ERR_INSUFFICIENT_PERMISSION
+	}
+	return nil
+}
+
+// Guard is the permission check of the router, routes of a tournament are checked against the
+// tournament whose id is the first argument of the custom id
+func (h *BaseAdmin) Guard(s *discordgo.Session, i *discordgo.InteractionCreate, r router.Route, id router.CustomID) error {
+	var t *models.Tournament
+	if r.Tournament {
+		var err error
+		if t, err = models.NewTournamentsModel(database.GetDB()).GetById(id.Arg(0)); err != nil {
+			return ERR_GET_TOURNAMENT
+		}
+	}
+	return h.Authorize(s, i, t, r.Role)
+}
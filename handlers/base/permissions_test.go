@@ -0,0 +1,41 @@
+package base
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/models"
+)
+
+func TestAuthorizeOtherGuild(t *testing.T) {
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		GuildID: "guild-a",
+		Member: &discordgo.Member{
+			User:        &discordgo.User{ID: "admin"},
+			Permissions: discordgo.PermissionAdministrator,
+		},
+	}}
+
+	tests := []struct {
+		name  string
+		guild sql.NullString
+	}{
+		{"other guild", sql.NullString{String: "guild-b", Valid: true}},
+		{"no guild", sql.NullString{}},
+	}
+	for _, tt := range tests {
+		tournament := &models.Tournament{Guild_ID: tt.guild}
+		err := GetBaseAdmin().Authorize(nil, i, tournament, models.ROLE_OWNER)
+		if !errors.Is(err, ERR_GET_TOURNAMENT) {
+			t.Errorf("%s: got %v, want ERR_GET_TOURNAMENT", tt.name, err)
+		}
+	}
+
+	// an administrator of the guild the tournament is held in passes without looking up any role
+	tournament := &models.Tournament{Guild_ID: sql.NullString{String: "guild-a", Valid: true}}
+	if err := GetBaseAdmin().Authorize(nil, i, tournament, models.ROLE_MANAGER); err != nil {
+		t.Errorf("same guild: got %v, want nil", err)
+	}
+}
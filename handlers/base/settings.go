@@ -0,0 +1,101 @@
+package base
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/config"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// MANAGER_ROLE is the role created in every guild the bot joins, used until another role is configured
+const MANAGER_ROLE = "Tournament Manager"
+
+var (
+	ERR_NO_TOURNAMENT_CHANNEL = i18n.NewError("error.no_tournament_channel")
+	ERR_NO_MANAGER_ROLE       = i18n.NewError("error.no_manager_role")
+)
+
+// Settings returns the settings of the guild, the defaults are used when they can't be loaded so a
+// broken settings row never blocks a command
+func Settings(guildID string) *models.GuildSettings {
+	g, err := models.NewGuildSettingsModel(database.GetDB()).Get(guildID)
+	if err != nil {
+		log.Printf("error loading settings of guild %s: %v", guildID, err)
+		return models.DefaultGuildSettings(guildID)
+	}
+	return g
+}
+
+// Locale is the language the member is answered in, their discord language when the bot speaks it and
+// the language of the server otherwise
+func Locale(i *discordgo.InteractionCreate) i18n.Locale {
+	if l, ok := i18n.Parse(string(i.Locale)); ok {
+		return l
+	}
+	return GuildLocale(i.GuildID)
+}
+
+// GuildLocale is the language of the messages everyone in the server reads
+func GuildLocale(guildID string) i18n.Locale {
+	if guildID == "" {
+		return i18n.DEFAULT
+	}
+	l, _ := i18n.Parse(Settings(guildID).Locale)
+	return l
+}
+
+// ReplyLocale is the language of a reply to the interaction, replies only the member sees are in
+// their language
+func ReplyLocale(i *discordgo.InteractionCreate, ephemeral bool) i18n.Locale {
+	if ephemeral {
+		return Locale(i)
+	}
+	return GuildLocale(i.GuildID)
+}
+
+// TournamentChannel is the channel tournament threads of the guild are opened in, the
+// TOURNAMENT_CHANNEL_ID environment variable is kept as a fallback for single guild setups
+func TournamentChannel(guildID string) (string, error) {
+	if g := Settings(guildID); g.TournamentChannelID.Valid {
+		return g.TournamentChannelID.String, nil
+	}
+	if id := config.GetEnv().TOURNAMENT_CHANNEL_ID; id != "" {
+		return id, nil
+	}
+	return "", ERR_NO_TOURNAMENT_CHANNEL
+}
+
+// ManagerRole finds the role that is allowed to manage tournaments in the guild
+func ManagerRole(s *discordgo.Session, guildID string) (*discordgo.Role, error) {
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role := managerRole(roles, Settings(guildID).ManagerRoleID); role != nil {
+		return role, nil
+	}
+	return nil, ERR_NO_MANAGER_ROLE
+}
+
+// managerRole picks the configured manager role, the role named after MANAGER_ROLE is used when none
+// is configured or the configured one has been deleted so a stale setting never hides it
+func managerRole(roles []*discordgo.Role, configured sql.NullString) *discordgo.Role {
+	if configured.Valid {
+		for _, role := range roles {
+			if role.ID == configured.String {
+				return role
+			}
+		}
+	}
+	for _, role := range roles {
+		if role.Name == MANAGER_ROLE {
+			return role
+		}
+	}
+	return nil
+}
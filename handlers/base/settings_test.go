@@ -0,0 +1,35 @@
+package base
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestManagerRole(t *testing.T) {
+	roles := []*discordgo.Role{
+		{ID: "1", Name: "Moderator"},
+		{ID: "2", Name: MANAGER_ROLE},
+	}
+
+	tests := []struct {
+		name       string
+		configured sql.NullString
+		want       string
+	}{
+		{"configured", sql.NullString{String: "1", Valid: true}, "1"},
+		{"not configured", sql.NullString{}, "2"},
+		{"configured role deleted", sql.NullString{String: "9", Valid: true}, "2"},
+	}
+	for _, tt := range tests {
+		role := managerRole(roles, tt.configured)
+		if role == nil || role.ID != tt.want {
+			t.Errorf("%s: got %v, want role %s", tt.name, role, tt.want)
+		}
+	}
+
+	if role := managerRole(roles[:1], sql.NullString{String: "9", Valid: true}); role != nil {
+		t.Errorf("got %v, want no role", role)
+	}
+}
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+type ConfigHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *ConfigHandler) Command() *discordgo.ApplicationCommand {
+	types, err := models.NewTournamentTypesModel(database.GetDB()).List()
+	if err != nil {
+		log.Printf("error querying tournament types, ERR: %v", err.Error())
+		return nil
+	}
+
+	// every locale is offered in its own language
+	locales := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(i18n.Locales))
+	for _, l := range i18n.Locales {
+		locales = append(locales, &discordgo.ApplicationCommandOptionChoice{Name: l.Name(), Value: string(l)})
+	}
+
+	formats := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(types))
+	for _, tt := range types {
+		if tt.Has_Third_Winner {
+			formats = append(formats, &discordgo.ApplicationCommandOptionChoice{
+				Name:              i18n.T(i18n.DEFAULT, "tournament.format", tt.Bracket_Type, tt.Size),
+				NameLocalizations: i18n.Localized("tournament.format", tt.Bracket_Type, tt.Size),
+				Value:             tt.ID,
+			})
+		}
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:                     "config",
+		Description:              "Configure spade for this server",
+		DefaultMemberPermissions: &defaultPermission,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "show",
+				Description: "Show the configuration of this server",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "channel",
+				Description: "Channel tournament threads are opened in",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "channel",
+						Description:  "Tournament channel",
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+						Required:     true,
+					},
+				},
+			},
+			{
+				Name:        "manager_role",
+				Description: "Role that is allowed to manage tournaments",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Manager role",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "permission",
+				Description: "Make the members of a role organizers or referees of every tournament",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Server role",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "permission",
+						Description: "Referees can only report results",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Co-organizer", Value: models.ROLE_ORGANIZER.String()},
+							{Name: "Referee", Value: models.ROLE_REFEREE.String()},
+							{Name: "None", Value: models.ROLE_NONE.String()},
+						},
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:        "format",
+				Description: "Format used by /create when no configuration is picked",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "configuration",
+						Description: "Default tournament format",
+						Choices:     formats,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "timezone",
+				Description: "Time zone start times and season dates are written in",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "zone",
+						Description: "IANA time zone such as Asia/Jakarta",
+						Required:    true,
+						MaxLength:   64,
+					},
+				},
+			},
+			{
+				Name:        "locale",
+				Description: "Language the bot replies in",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "locale",
+						Description: "Language",
+						Choices:     locales,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "color",
+				Description: "Color of the embeds posted by the bot",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "color",
+						Description: "Hex color such as #5865F2, leave empty to use the default",
+						Required:    false,
+						MaxLength:   7,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h *ConfigHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.GuildID == "" {
+		base.Reply("common.guild_only", s, i, true)
+		return
+	}
+
+	// the default member permissions of the command can be overridden by the guild, so they are not enough
+	if err := h.Base.HasPermit(s, i); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		base.Reply("common.unknown_action", s, i, true)
+		return
+	}
+	subcmd := data.Options[0]
+
+	db := database.GetDB()
+	gm := models.NewGuildSettingsModel(db)
+	g, err := gm.Get(i.GuildID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	switch subcmd.Name {
+	case "show":
+		h.show(s, i, g)
+		return
+	case "permission":
+		h.permission(s, i, g, subcmd.Options)
+		return
+	}
+
+	var before, after string
+	switch subcmd.Name {
+	case "channel":
+		channel := subcmd.Options[0].ChannelValue(nil)
+		before, after = g.TournamentChannelID.String, channel.ID
+		g.TournamentChannelID = sql.NullString{String: channel.ID, Valid: true}
+	case "manager_role":
+		role := subcmd.Options[0].RoleValue(nil, i.GuildID)
+		before, after = g.ManagerRoleID.String, role.ID
+		g.ManagerRoleID = sql.NullString{String: role.ID, Valid: true}
+	case "format":
+		id := subcmd.Options[0].IntValue()
+		before, after = fmt.Sprint(g.DefaultTypeID.Int64), fmt.Sprint(id)
+		g.DefaultTypeID = sql.NullInt64{Int64: id, Valid: true}
+	case "timezone":
+		zone := strings.TrimSpace(subcmd.Options[0].StringValue())
+		if _, err := time.LoadLocation(zone); err != nil || zone == "" || zone == "Local" {
+			base.Reply("config.unknown_timezone", s, i, true, zone)
+			return
+		}
+		before, after = g.Timezone, zone
+		g.Timezone = zone
+	case "locale":
+		before, after = g.Locale, subcmd.Options[0].StringValue()
+		g.Locale = after
+	case "color":
+		var v string
+		if len(subcmd.Options) > 0 {
+			v = subcmd.Options[0].StringValue()
+		}
+		color, err := models.ParseEmbedColor(v)
+		if err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+		before, after = models.FormatEmbedColor(g.EmbedColor), models.FormatEmbedColor(color)
+		g.EmbedColor = color
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+		return
+	}
+
+	g.UpdatedBy = base.Actor(i).ID
+	if err := gm.Save(g); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	base.Audit(i, models.AUDIT_CONFIG, "", map[string]string{subcmd.Name: before}, map[string]string{subcmd.Name: after})
+
+	h.show(s, i, g)
+}
+
+// permission maps a guild role to a role in every tournament of the guild
+func (h *ConfigHandler) permission(s *discordgo.Session, i *discordgo.InteractionCreate, g *models.GuildSettings,
+	opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	roleID := opts[0].RoleValue(nil, i.GuildID).ID
+	role, _ := models.ParseRole(opts[1].StringValue())
+
+	if err := models.NewPermissionModel(database.GetDB()).MapRole(i.GuildID, roleID, role); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	base.Audit(i, models.AUDIT_PERMISSION, "", nil, map[string]string{"role": roleID, "permission": role.String()})
+
+	h.show(s, i, g)
+}
+
+func (h *ConfigHandler) show(s *discordgo.Session, i *discordgo.InteractionCreate, g *models.GuildSettings) {
+	mappings, err := models.NewPermissionModel(database.GetDB()).Mappings(i.GuildID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	var format string
+	if g.DefaultTypeID.Valid {
+		types, err := models.NewTournamentTypesModel(database.GetDB()).List()
+		if err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		for _, tt := range types {
+			if tt.ID == int(g.DefaultTypeID.Int64) {
+				format = i18n.T(base.Locale(i), "tournament.format", tt.Bracket_Type, tt.Size)
+			}
+		}
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{components.SettingsEmbed(base.Locale(i), g, format, mappings)},
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
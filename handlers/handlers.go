@@ -4,16 +4,33 @@ import (
 	"github.com/dimfu/spade/handlers/base"
 	"github.com/dimfu/spade/handlers/queue"
 	"github.com/dimfu/spade/handlers/tournament"
+	"github.com/dimfu/spade/scheduler"
 )
 
 var CommandHandlers = []base.Command{
 	&PingHandler{},
 	&AdminHandler{},
+	&ConfigHandler{Base: base.GetBaseAdmin()},
+	&AuditHandler{Base: base.GetBaseAdmin()},
+	&RatingHandler{Base: base.GetBaseAdmin()},
+	&SeasonHandler{Base: base.GetBaseAdmin()},
+	&LeaderboardHandler{},
+	&TournamentsHandler{Base: base.GetBaseAdmin()},
+	&ProfileHandler{},
+	&HeadToHeadHandler{},
+	&NotifyHandler{},
 	&tournament.TournamentCreateHandler{Base: base.GetBaseAdmin()},
 	&tournament.TournamentDeleteHandler{Base: base.GetBaseAdmin()},
 	&tournament.TournamentRegisterHandler{Base: base.GetBaseAdmin()},
+	&tournament.TeamHandler{Base: base.GetBaseAdmin()},
+	&tournament.StaffHandler{Base: base.GetBaseAdmin()},
+	&tournament.StatusHandler{},
+	&tournament.RefereeHandler{Base: base.GetBaseAdmin()},
 	&tournament.ExportListHandler{Base: base.GetBaseAdmin()},
+	&tournament.ImportHandler{Base: base.GetBaseAdmin()},
 	&tournament.SeedHandler{Base: base.GetBaseAdmin()},
+	&tournament.TemplateHandler{Base: base.GetBaseAdmin()},
+	&tournament.RecurrenceHandler{Base: base.GetBaseAdmin()},
 	&tournament.StartHandler{
 		Base:       base.GetBaseAdmin(),
 		MatchQueue: queue.GetMatchQueue(),
@@ -26,8 +43,25 @@ var CommandHandlers = []base.Command{
 
 var ComponentHandlers = []base.Component{
 	&tournament.TournamentComponentHandler{Base: base.GetBaseAdmin(), MatchQueue: queue.GetMatchQueue()},
+	&AuditComponentHandler{Base: base.GetBaseAdmin()},
+	&tournament.ImportComponentHandler{Base: base.GetBaseAdmin()},
+	&tournament.SignupComponentHandler{},
+	&tournament.CheckInComponentHandler{},
+	&tournament.MatchComponentHandler{},
+	&LeaderboardComponentHandler{},
+	&TournamentsComponentHandler{Base: base.GetBaseAdmin()},
 }
 
 var ModalSubmitHandlers = []base.Modal{
-	&tournament.TournamentModalHandler{},
+	&tournament.TournamentModalHandler{Base: base.GetBaseAdmin()},
+}
+
+var JobHandlers = []scheduler.Job{
+	&tournament.RecurrenceJob{},
+	&tournament.ReminderJob{},
+	&tournament.CheckInJob{},
+	&tournament.StartHandler{
+		Base:       base.GetBaseAdmin(),
+		MatchQueue: queue.GetMatchQueue(),
+	},
 }
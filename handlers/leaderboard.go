@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+const leaderboardPageSize = 10
+
+const (
+	LEADERBOARD_RATING = "rating"
+	LEADERBOARD_WINS   = "wins"
+	LEADERBOARD_SEASON = "season"
+)
+
+var leaderboardMinPage = float64(1)
+
+type LeaderboardHandler struct{}
+
+type LeaderboardComponentHandler struct{}
+
+func (h *LeaderboardHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "leaderboard",
+		Description: "Show the best players of this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "by",
+				Description: "What players are ranked by (default rating)",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "rating", Value: LEADERBOARD_RATING},
+					{Name: "tournament wins", Value: LEADERBOARD_WINS},
+					{Name: "season points", Value: LEADERBOARD_SEASON},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "season",
+				Description: "Season to rank by points (default the current season)",
+				Required:    false,
+				MaxLength:   64,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "game",
+				Description: "Rank by the rating of a single game instead of every game",
+				Required:    false,
+				MaxLength:   64,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "page",
+				Description: "Page to start from",
+				Required:    false,
+				MinValue:    &leaderboardMinPage,
+			},
+		},
+	}
+}
+
+func (h *LeaderboardHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	by := LEADERBOARD_RATING
+	page := 1
+	var seasonName, game string
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "by":
+			by = opt.StringValue()
+		case "season":
+			seasonName = strings.TrimSpace(opt.StringValue())
+			by = LEADERBOARD_SEASON
+		case "game":
+			game = strings.TrimSpace(opt.StringValue())
+		case "page":
+			page = int(opt.IntValue())
+		}
+	}
+
+	// the argument is whatever the leaderboard needs to be computed again when paging
+	arg := game
+	if by == LEADERBOARD_SEASON {
+		sm := models.NewSeasonModel(database.GetDB())
+		var (
+			season *models.Season
+			err    error
+		)
+		if seasonName != "" {
+			season, err = sm.FindByName(i.GuildID, seasonName)
+		} else {
+			season, err = sm.Current(i.GuildID, time.Now().Unix())
+		}
+		if err == sql.ErrNoRows {
+			base.Reply("season.not_found", s, i, true)
+			return
+		}
+		if err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		arg = strconv.Itoa(season.ID)
+	}
+
+	res, err := leaderboardPage(base.GuildLocale(i.GuildID), i.GuildID, by, arg, page)
+	if errors.Is(err, router.ERR_CUSTOM_ID_TOO_LONG) {
+		base.Reply("leaderboard.game_too_long", s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: res,
+	})
+}
+
+// ROUTE_LEADERBOARD_PAGE custom id is built with the kind of leaderboard, the page and the game or
+// season of the leaderboard
+const ROUTE_LEADERBOARD_PAGE = "leaderboard"
+
+func (h *LeaderboardComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_LEADERBOARD_PAGE, Args: 3, Handler: h.page},
+	}
+}
+
+func (h *LeaderboardComponentHandler) page(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	page, err := cid.Int(1)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	res, err := leaderboardPage(base.GuildLocale(i.GuildID), i.GuildID, cid.Arg(0), cid.Arg(2), page)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: res,
+	})
+}
+
+func leaderboardPage(l i18n.Locale, guildID, by, arg string, page int) (*discordgo.InteractionResponseData, error) {
+	db := database.GetDB()
+	lm := models.NewLeaderboardModel(db)
+
+	var (
+		entries []models.LeaderboardEntry
+		title   string
+		value   func(e models.LeaderboardEntry) string
+		err     error
+	)
+	switch by {
+	case LEADERBOARD_RATING:
+		title = i18n.T(l, "leaderboard.rating")
+		if arg != "" {
+			title = i18n.T(l, "leaderboard.game_rating", arg)
+		}
+		entries, err = lm.ByRating(guildID, arg)
+		value = func(e models.LeaderboardEntry) string {
+			return i18n.T(l, "leaderboard.rating_entry", e.Value, e.Wins, e.Losses)
+		}
+	case LEADERBOARD_WINS:
+		title = i18n.T(l, "leaderboard.wins")
+		entries, err = lm.ByTournamentWins(guildID)
+		value = func(e models.LeaderboardEntry) string {
+			return i18n.N(l, "leaderboard.wins_entry", e.TournamentWins)
+		}
+	case LEADERBOARD_SEASON:
+		seasonID, convErr := strconv.Atoi(arg)
+		if convErr != nil {
+			return nil, convErr
+		}
+		season, seasonErr := models.NewSeasonModel(db).GetById(seasonID)
+		if seasonErr != nil {
+			return nil, seasonErr
+		}
+		if season.GuildID != guildID {
+			return nil, sql.ErrNoRows
+		}
+		title = i18n.T(l, "leaderboard.season", season.Name)
+		entries, err = lm.BySeason(season)
+		value = func(e models.LeaderboardEntry) string {
+			return i18n.N(l, "leaderboard.season_entry", int(e.Value), e.Tournaments, e.TournamentWins)
+		}
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %q", by)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pages := (len(entries) + leaderboardPageSize - 1) / leaderboardPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+	if page > pages {
+		page = pages
+	}
+
+	from := (page - 1) * leaderboardPageSize
+	to := min(from+leaderboardPageSize, len(entries))
+
+	var lines []string
+	for _, e := range entries[from:to] {
+		lines = append(lines, fmt.Sprintf("**%d.** <@%s> %s", e.Position, e.Player.DiscordID, value(e)))
+	}
+
+	description := strings.Join(lines, "\n")
+	if len(entries) == 0 {
+		description = i18n.T(l, "leaderboard.empty")
+	}
+
+	// the game is typed by the member and may not fit in the custom id of the buttons
+	prev, err := router.NewCustomID(ROUTE_LEADERBOARD_PAGE, by, page-1, arg).Encode()
+	if err != nil {
+		return nil, err
+	}
+	next, err := router.NewCustomID(ROUTE_LEADERBOARD_PAGE, by, page+1, arg).Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       title,
+				Description: description,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: i18n.T(l, "common.page", page, pages),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(l, "common.previous"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page <= 1,
+						CustomID: prev,
+					},
+					discordgo.Button{
+						Label:    i18n.T(l, "common.next"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page >= pages,
+						CustomID: next,
+					},
+				},
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}, nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+// NOTIFY_ALL picks every notification kind in /notify set
+const NOTIFY_ALL = "all"
+
+type NotifyHandler struct{}
+
+func (h *NotifyHandler) Command() *discordgo.ApplicationCommand {
+	kinds := []*discordgo.ApplicationCommandOptionChoice{
+		{Name: i18n.T(i18n.DEFAULT, "notification.all"), Value: NOTIFY_ALL},
+	}
+	for _, kind := range models.NotificationKinds {
+		kinds = append(kinds, &discordgo.ApplicationCommandOptionChoice{
+			Name:  i18n.T(i18n.DEFAULT, "notification."+string(kind)),
+			Value: string(kind),
+		})
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "notify",
+		Description: "Choose which direct messages you receive",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "show",
+				Description: "Show your notification preferences",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "set",
+				Description: "Turn a notification on or off",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "type",
+						Description: "Notification to change",
+						Choices:     kinds,
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Receive the notification by direct message",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h *NotifyHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		base.Reply("common.unknown_action", s, i, true)
+		return
+	}
+	subcmd := data.Options[0]
+
+	nm := models.NewNotificationModel(database.GetDB())
+	p, err := nm.Get(base.Actor(i).ID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	switch subcmd.Name {
+	case "show":
+	case "set":
+		options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+		for _, opt := range subcmd.Options {
+			options[opt.Name] = opt
+		}
+		kind, enabled := options["type"].StringValue(), options["enabled"].BoolValue()
+		for _, k := range models.NotificationKinds {
+			if kind == NOTIFY_ALL || kind == string(k) {
+				p.Enabled[k] = enabled
+			}
+		}
+		if err := nm.Save(p); err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				components.Paint(components.NotificationEmbed(base.Locale(i), p), base.Settings(i.GuildID).Color()),
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/router"
 )
 
 type PingHandler struct{}
@@ -16,10 +17,10 @@ func (p *PingHandler) Command() *discordgo.ApplicationCommand {
 }
 
 func (p *PingHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Pong! %vms", s.HeartbeatLatency().Milliseconds()),
+			Content: i18n.T(base.GuildLocale(i.GuildID), "ping.pong", s.HeartbeatLatency().Milliseconds()),
 		},
 	})
 }
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"database/sql"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+const profileRecentMatches = 5
+
+type ProfileHandler struct{}
+
+type HeadToHeadHandler struct{}
+
+func (h *ProfileHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "profile",
+		Description: "Show the tournament history of a player",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "Player to show the profile of (default yourself)",
+				Required:    false,
+			},
+		},
+	}
+}
+
+func (h *ProfileHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	user := base.Actor(i)
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "player" {
+			user = opt.UserValue(s)
+		}
+	}
+
+	p, ok := findPlayer(s, i, user)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	profile, err := models.NewProfileModel(db).Get(i.GuildID, p, profileRecentMatches)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	rating, err := models.NewPlayerRatingModel(db).Get(nil, i.GuildID, "", string(p.ID))
+	if err != nil && err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				components.Paint(components.ProfileEmbed(base.GuildLocale(i.GuildID), profile, rating), base.Settings(i.GuildID).Color()),
+			},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+func (h *HeadToHeadHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "h2h",
+		Description: "Show every match played between two players",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "a",
+				Description: "First player",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "b",
+				Description: "Second player (default yourself)",
+				Required:    false,
+			},
+		},
+	}
+}
+
+func (h *HeadToHeadHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userA, userB := base.Actor(i), base.Actor(i)
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "a":
+			userA = opt.UserValue(s)
+		case "b":
+			userB = opt.UserValue(s)
+		}
+	}
+
+	if userA.ID == userB.ID {
+		base.Reply("profile.same_players", s, i, true)
+		return
+	}
+
+	a, ok := findPlayer(s, i, userA)
+	if !ok {
+		return
+	}
+	b, ok := findPlayer(s, i, userB)
+	if !ok {
+		return
+	}
+
+	matches, err := models.NewProfileModel(database.GetDB()).Matches(i.GuildID, string(a.ID), string(b.ID))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				components.Paint(components.HeadToHeadEmbed(base.GuildLocale(i.GuildID), a, b, matches), base.Settings(i.GuildID).Color()),
+			},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+// findPlayer responds to the interaction by itself when the user never entered a tournament
+func findPlayer(s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User) (*models.Player, bool) {
+	p, err := models.NewPlayerModel(database.GetDB()).FindByDiscordId(user.ID)
+	if err == sql.ErrNoRows {
+		base.Reply("profile.not_played", s, i, true, user.Username)
+		return nil, false
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return nil, false
+	}
+	return p, true
+}
@@ -24,6 +24,7 @@ type MatchQueue struct {
 }
 
 type MatchResult struct {
+	MatchID    int
 	Winner     *models.AttendeeWithResult
 	Loser      *models.AttendeeWithResult
 	WinnerTo   *int
@@ -99,6 +100,7 @@ func (q *MatchQueue) Result(tournamentID string, winnerID int) (*MatchResult, er
 	defer wg.Done()
 
 	popped := items[0]
+	result.MatchID = popped.ID
 	match := make(map[int]*bracket.Node)
 	currSeats := []int{}
 
@@ -196,7 +198,7 @@ func (q *MatchQueue) Start(
 			default:
 			}
 
-			match := &models.Match{}
+			match := &models.Match{ID: currMatch.ID}
 			if p1, err := q.brackets[tournamentID].Search(currMatch.P1.Position); err == nil {
 				match.P1 = p1
 			}
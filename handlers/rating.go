@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/rating"
+	"github.com/dimfu/spade/router"
+)
+
+type RatingHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *RatingHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "rating",
+		Description: "Player ratings of this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "show",
+				Description: "Show the rating of a player",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "player",
+						Description: "Player to show the rating of (default yourself)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "game",
+						Description: "Show the rating of a single game instead of every game",
+						Required:    false,
+						MaxLength:   64,
+					},
+				},
+			},
+			{
+				Name:        "rebuild",
+				Description: "Recompute every rating of this server from the match history",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *RatingHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	subcmd := data.Options[0]
+	switch subcmd.Name {
+	case "show":
+		h.show(s, i, subcmd.Options)
+	case "rebuild":
+		if err := h.Base.HasPermit(s, i); err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+
+		// replaying the whole history can take longer than discord waits for a response
+		router.Respond(s, i, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		})
+
+		var content string
+		count, err := rating.Rebuild(i.GuildID)
+		if err != nil {
+			log.Printf("error rebuilding ratings of guild %s: %v", i.GuildID, err)
+			content = base.ERR_INTERNAL_ERROR.In(base.Locale(i))
+		} else {
+			base.Audit(i, models.AUDIT_RATING_REBUILD, "", nil, map[string]int{"matches": count})
+			content = i18n.N(base.Locale(i), "rating.rebuilt", count)
+		}
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{Content: content})
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *RatingHandler) show(s *discordgo.Session, i *discordgo.InteractionCreate,
+	opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	user := base.Actor(i)
+	var game string
+	for _, opt := range opts {
+		switch opt.Name {
+		case "player":
+			user = opt.UserValue(s)
+		case "game":
+			game = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	p, ok := findPlayer(s, i, user)
+	if !ok {
+		return
+	}
+
+	r, err := models.NewPlayerRatingModel(database.GetDB()).Get(nil, i.GuildID, game, string(p.ID))
+	if err == sql.ErrNoRows {
+		base.Reply("rating.unrated", s, i, true, p.Name)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{components.Paint(components.RatingEmbed(base.GuildLocale(i.GuildID), r), base.Settings(i.GuildID).Color())},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/router"
+)
+
+// NewRouter registers every command, component and modal handler on a router. Panics are recovered
+// inside the deferral so the error can still answer a deferred interaction.
+func NewRouter(ctx context.Context) *router.Router {
+	r := router.New(
+		router.Logging,
+		router.Timing,
+		router.Defer,
+		router.Recover,
+		router.Permission(base.GetBaseAdmin().Guard),
+	)
+
+	router.ErrorText = func(i *discordgo.InteractionCreate, err error) string {
+		return i18n.Text(base.Locale(i), err)
+	}
+
+	for _, handler := range CommandHandlers {
+		handler := handler
+		name := handler.Command().Name
+		r.Command(router.Route{
+			Name: name,
+			Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate, _ router.CustomID) {
+				if hWithCtx, ok := handler.(base.CommandWithCtx); ok {
+					hWithCtx.WithCtx(ctx)
+				}
+				handler.Handler(s, i)
+			},
+		})
+		if ac, ok := handler.(base.Autocompleter); ok {
+			r.Autocomplete(name, func(s *discordgo.Session, i *discordgo.InteractionCreate, _ router.CustomID) {
+				ac.Autocomplete(s, i)
+			})
+		}
+	}
+
+	for _, handler := range ComponentHandlers {
+		for _, route := range handler.Routes() {
+			r.Handle(route)
+		}
+	}
+	for _, handler := range ModalSubmitHandlers {
+		for _, route := range handler.Routes() {
+			r.Handle(route)
+		}
+	}
+
+	return r
+}
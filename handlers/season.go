@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+const seasonDateLayout = "2006-01-02"
+
+type SeasonHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *SeasonHandler) Command() *discordgo.ApplicationCommand {
+	seasonOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "name",
+		Description: "Name of the season",
+		Required:    true,
+		MaxLength:   64,
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "season",
+		Description: "Manage the seasons of this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "create",
+				Description: "Create a new season",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					seasonOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "start",
+						Description: "First day of the season in the server time zone (YYYY-MM-DD)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "end",
+						Description: "Last day of the season in the server time zone (YYYY-MM-DD)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "points",
+						Description: fmt.Sprintf("Points per placement as place=points (default %s)", models.DEFAULT_POINT_TIERS),
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "points",
+				Description: "Change the points awarded per placement",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					seasonOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "points",
+						Description: "Points per placement as place=points, such as 1=100,2=70,3=50",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "List the seasons of this server",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "archive",
+				Description: "Close the season and keep its final standings",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{seasonOption},
+			},
+		},
+	}
+}
+
+func (h *SeasonHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	subcmd := data.Options[0]
+	if subcmd.Name == "list" {
+		h.list(s, i)
+		return
+	}
+
+	if err := h.Base.HasPermit(s, i); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	opts := make(map[string]string, len(subcmd.Options))
+	for _, opt := range subcmd.Options {
+		opts[opt.Name] = strings.TrimSpace(opt.StringValue())
+	}
+
+	switch subcmd.Name {
+	case "create":
+		h.create(s, i, opts)
+	case "points":
+		h.points(s, i, opts)
+	case "archive":
+		h.archive(s, i, opts["name"])
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *SeasonHandler) create(s *discordgo.Session, i *discordgo.InteractionCreate, opts map[string]string) {
+	loc := base.Settings(i.GuildID).Location()
+	start, err := time.ParseInLocation(seasonDateLayout, opts["start"], loc)
+	if err != nil {
+		base.Reply("season.start_format", s, i, true)
+		return
+	}
+	end, err := time.ParseInLocation(seasonDateLayout, opts["end"], loc)
+	if err != nil {
+		base.Reply("season.end_format", s, i, true)
+		return
+	}
+	if end.Before(start) {
+		base.Reply("season.end_before_start", s, i, true)
+		return
+	}
+
+	points := opts["points"]
+	if points == "" {
+		points = models.DEFAULT_POINT_TIERS
+	}
+	tiers, err := models.ParsePointTiers(points)
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	sm := models.NewSeasonModel(database.GetDB())
+	_, err = sm.FindByName(i.GuildID, opts["name"])
+	if err == nil {
+		base.Reply("season.exists", s, i, true, opts["name"])
+		return
+	}
+	if err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	season := &models.Season{
+		GuildID:  i.GuildID,
+		Name:     opts["name"],
+		StartsAt: start.Unix(),
+		// the end day is part of the season
+		EndsAt:     end.AddDate(0, 0, 1).Unix() - 1,
+		PointTiers: models.FormatPointTiers(tiers),
+		CreatedBy:  base.Actor(i).ID,
+	}
+	if err := sm.Insert(season); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Audit(i, models.AUDIT_SEASON_CREATE, "", nil, season)
+	base.Reply("season.created", s, i, false, season.Name, season.StartsAt, season.EndsAt, season.PointTiers)
+}
+
+func (h *SeasonHandler) points(s *discordgo.Session, i *discordgo.InteractionCreate, opts map[string]string) {
+	season, ok := findSeason(s, i, opts["name"])
+	if !ok {
+		return
+	}
+	if season.Archived {
+		base.Reply("season.archived", s, i, true, season.Name)
+		return
+	}
+
+	tiers, err := models.ParsePointTiers(opts["points"])
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	points := models.FormatPointTiers(tiers)
+	if err := models.NewSeasonModel(database.GetDB()).UpdatePointTiers(season.ID, points); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Audit(i, models.AUDIT_SEASON_POINTS, "", map[string]string{"season": season.Name, "points": season.PointTiers},
+		map[string]string{"season": season.Name, "points": points})
+	base.Reply("season.points_updated", s, i, true, season.Name, points)
+}
+
+func (h *SeasonHandler) archive(s *discordgo.Session, i *discordgo.InteractionCreate, name string) {
+	season, ok := findSeason(s, i, name)
+	if !ok {
+		return
+	}
+	if season.Archived {
+		base.Reply("season.already_archived", s, i, true, season.Name)
+		return
+	}
+
+	db := database.GetDB()
+	standings, err := models.NewLeaderboardModel(db).BySeason(season)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := models.NewSeasonModel(db).Archive(season.ID, standings); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Audit(i, models.AUDIT_SEASON_ARCHIVE, "", nil, map[string]interface{}{
+		"season": season.Name, "players": len(standings),
+	})
+
+	if len(standings) > 0 {
+		content := i18n.N(base.GuildLocale(i.GuildID), "season.archived_winner", int(standings[0].Value), season.Name,
+			standings[0].Player.DiscordID)
+		base.Respond(content, s, i, false)
+		return
+	}
+	base.Reply("season.archived", s, i, false, season.Name)
+}
+
+func (h *SeasonHandler) list(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	seasons, err := models.NewSeasonModel(database.GetDB()).List(i.GuildID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if len(seasons) == 0 {
+		base.Reply("season.none", s, i, true)
+		return
+	}
+
+	// embeds can only hold 25 fields, the seasons past them are counted in the footer
+	l := base.Locale(i)
+	embed := &discordgo.MessageEmbed{Title: i18n.T(l, "season.title")}
+	for idx, season := range seasons {
+		if idx == 25 {
+			embed.Footer = &discordgo.MessageEmbedFooter{Text: i18n.T(l, "common.and_more", len(seasons)-idx)}
+			break
+		}
+		value := i18n.T(l, "season.period", season.StartsAt, season.EndsAt, season.PointTiers)
+		if season.Archived {
+			value += "\n" + i18n.T(l, "season.archived_label")
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  season.Name,
+			Value: value,
+		})
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// findSeason responds to the interaction by itself when the season can't be found
+func findSeason(s *discordgo.Session, i *discordgo.InteractionCreate, name string) (*models.Season, bool) {
+	season, err := models.NewSeasonModel(database.GetDB()).FindByName(i.GuildID, name)
+	if err == sql.ErrNoRows {
+		base.Reply("season.named_not_found", s, i, true, name)
+		return nil, false
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return nil, false
+	}
+	return season, true
+}
@@ -0,0 +1,319 @@
+package tournament
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+	"github.com/google/uuid"
+)
+
+const maxArchiveSize = 2 * 1024 * 1024
+
+type ImportHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *ImportHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "import",
+		Description: "Import tournament data from a file",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "tournament",
+				Description: "Rebuild a tournament from a JSON export",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Name:        "file",
+						Description: "JSON file created by /export",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "participants",
+				Description: "Register the players listed in a CSV file to the current tournament",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Name:        "file",
+						Description: "CSV file with the name,discord_id,current_seat columns",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "apply_seats",
+						Description: "Seat the players by the current_seat column (default true)",
+						Required:    false,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h *ImportHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	subcmd := data.Options[0]
+	switch subcmd.Name {
+	case "tournament":
+		// restoring a tournament creates a new one, participants are checked against their tournament
+		if err := h.Base.Authorize(s, i, nil, models.ROLE_ORGANIZER); err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+		h.tournament(s, i, data.Resolved.Attachments[subcmd.Options[0].Value.(string)])
+	case "participants":
+		h.participants(s, i, subcmd.Options)
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *ImportHandler) tournament(s *discordgo.Session, i *discordgo.InteractionCreate, att *discordgo.MessageAttachment) {
+	// downloading the file can take longer than discord waits for a response
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	l := base.GuildLocale(i.GuildID)
+	followup := func(content string) {
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	if att == nil {
+		followup(i18n.T(l, "import.attach_json"))
+		return
+	}
+
+	raw, err := base.ReadAttachment(att, maxArchiveSize)
+	if err != nil {
+		followup(i18n.Text(l, err))
+		return
+	}
+
+	a, err := models.DecodeArchive(bytes.NewReader(raw))
+	if err != nil {
+		followup(i18n.Text(l, err))
+		return
+	}
+
+	t, err := restoreArchive(a, i.GuildID, base.Actor(i).ID)
+	if err != nil {
+		log.Printf("error importing tournament %s: %v", a.Tournament.ID, err)
+		followup(i18n.Text(l, err))
+		return
+	}
+	base.Audit(i, models.AUDIT_IMPORT, string(t.ID), nil, models.SnapshotTournament(t))
+
+	if err := scheduleStart(t); err != nil {
+		log.Printf("error scheduling the start of tournament %s: %v", t.ID, err)
+	}
+
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:         i18n.N(l, "import.restored", len(a.Attendees), len(a.Matches)),
+		Embeds:          []*discordgo.MessageEmbed{components.Paint(components.ConfigurationEmbed(l, t), base.Settings(i.GuildID).Color())},
+		Components:      configurationComponents(l, string(t.ID)),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+// restoreArchive creates a new tournament in the guild out of the archive, attendees are matched to
+// the existing players by their discord id and registered as new players otherwise
+func restoreArchive(a *models.TournamentArchive, guildID, ownerID string) (*models.Tournament, error) {
+	db := database.GetDB()
+	pm := models.NewPlayerModel(db)
+	am := models.NewAttendeeModel(db)
+	mm := models.NewMatchModel(db)
+
+	tournamentTypes, err := models.NewTournamentTypesModel(db).List()
+	if err != nil {
+		return nil, err
+	}
+
+	var tt *models.TournamentType
+	for idx := range tournamentTypes {
+		candidate := tournamentTypes[idx]
+		if candidate.Size == strconv.Itoa(a.Tournament.Type.Size) &&
+			candidate.Bracket_Type == a.Tournament.Type.BracketType &&
+			candidate.Has_Third_Winner == a.Tournament.Type.HasThirdWinner {
+			tt = &candidate
+			break
+		}
+	}
+	if tt == nil {
+		return nil, i18n.NewError("import.unsupported_type", a.Tournament.Type.Size, a.Tournament.Type.BracketType)
+	}
+
+	createdAt := a.Tournament.CreatedAt
+	if createdAt == 0 {
+		createdAt = time.Now().Unix()
+	}
+
+	t := &models.Tournament{
+		ID:                  []uint8(uuid.New().String()),
+		Name:                a.Tournament.Name,
+		Description:         sql.NullString{String: a.Tournament.Description, Valid: a.Tournament.Description != ""},
+		Rules:               sql.NullString{String: a.Tournament.Rules, Valid: a.Tournament.Rules != ""},
+		Tournament_Types_ID: tt.ID,
+		TournamentType:      *tt,
+		Starting_At:         sql.NullInt64{Int64: a.Tournament.StartingAt, Valid: a.Tournament.StartingAt != 0},
+		Created_At:          strconv.FormatInt(createdAt, 10),
+		Guild_ID:            sql.NullString{String: guildID, Valid: guildID != ""},
+		Owner_ID:            sql.NullString{String: ownerID, Valid: ownerID != ""},
+		Best_Of:             a.Tournament.BestOf,
+		Self_Register:       a.Tournament.SelfRegister,
+		Started_At:          sql.NullInt64{Int64: a.Tournament.StartedAt, Valid: a.Tournament.StartedAt != 0},
+		Registration_Open:   a.Tournament.RegistrationOpen,
+		Game:                sql.NullString{String: a.Tournament.Game, Valid: a.Tournament.Game != ""},
+		Team_Size:           1,
+	}
+	if t.Best_Of < 1 {
+		t.Best_Of = 1
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := models.NewTournamentsModel(db).InsertTx(tx, t); err != nil {
+		return nil, err
+	}
+
+	attendees := make(map[string]int64, len(a.Attendees))
+	for _, at := range a.Attendees {
+		p, err := pm.FindByDiscordId(at.DiscordID)
+		if err == sql.ErrNoRows {
+			p = &models.Player{
+				ID:        []uint8(uuid.New().String()),
+				Name:      at.Name,
+				DiscordID: at.DiscordID,
+			}
+			err = pm.Insert(tx, p)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		attendee := &models.Attendee{
+			TournamentID: string(t.ID),
+			PlayerID:     string(p.ID),
+			StartingSeat: sql.NullInt64{Int64: at.StartingSeat, Valid: at.StartingSeat != 0},
+			CurrentSeat:  sql.NullInt64{Int64: at.CurrentSeat, Valid: at.CurrentSeat != 0},
+		}
+		if err := am.Insert(tx, attendee); err != nil {
+			return nil, err
+		}
+		attendees[at.DiscordID] = int64(attendee.Id)
+	}
+
+	attendeeID := func(discordID string) sql.NullInt64 {
+		id, ok := attendees[discordID]
+		return sql.NullInt64{Int64: id, Valid: ok}
+	}
+
+	for _, m := range a.Matches {
+		record := &models.MatchRecord{
+			TournamentID:     string(t.ID),
+			Round:            m.Round,
+			Number:           m.Number,
+			P1AttendeeID:     attendeeID(m.P1),
+			P2AttendeeID:     attendeeID(m.P2),
+			P1Seat:           m.P1Seat,
+			P2Seat:           m.P2Seat,
+			WinnerTo:         m.WinnerTo,
+			WinnerAttendeeID: attendeeID(m.Winner),
+			Status:           m.Status,
+			P1Score:          m.P1Score,
+			P2Score:          m.P2Score,
+			StartedAt:        sql.NullInt64{Int64: m.StartedAt, Valid: m.StartedAt != 0},
+			CompletedAt:      sql.NullInt64{Int64: m.CompletedAt, Valid: m.CompletedAt != 0},
+		}
+		// the match message stays behind in the old server, it gets posted again once the tournament resumes
+		if record.Status == models.MATCH_LIVE {
+			record.Status = models.MATCH_READY
+		}
+		if err := mm.Restore(tx, record); err != nil {
+			return nil, err
+		}
+	}
+
+	// archives of finished tournaments come back with their placements
+	if _, err := models.NewPlacementModel(db).Complete(tx, string(t.ID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// exportArchive responds with the complete tournament as a versioned JSON file
+func exportArchive(s *discordgo.Session, i *discordgo.InteractionCreate, db *sql.DB, tournamentId string) {
+	t, err := models.NewTournamentsModel(db).GetById(tournamentId)
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	// archives refer to single players, rosters would be lost on import
+	if t.IsTeam() {
+		base.Reply("export.team_archive", s, i, true)
+		return
+	}
+
+	attendees, err := models.NewAttendeeModel(db).List(tournamentId, false)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	records, err := models.NewMatchModel(db).List(tournamentId)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	buf, err := json.MarshalIndent(models.NewArchive(t, attendees, records), "", "  ")
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Files: []*discordgo.File{
+				{Name: fmt.Sprintf("%s.json", tournamentId), ContentType: "application/json", Reader: bytes.NewReader(buf)},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
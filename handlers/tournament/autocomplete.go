@@ -0,0 +1,99 @@
+package tournament
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// maxChoiceLength is the longest name or value discord accepts for a choice
+const maxChoiceLength = 100
+
+// tournamentChoices suggests the tournaments of the guild by name along with their state in the language
+// of the member, the value is the tournament id
+func tournamentChoices(l i18n.Locale, guildID, query string) []*discordgo.ApplicationCommandOptionChoice {
+	tournaments, err := models.NewTournamentsModel(database.GetDB()).Search(guildID, strings.TrimSpace(query), base.MAX_CHOICES)
+	if err != nil {
+		log.Printf("error searching tournaments of guild %s: %v", guildID, err)
+		return nil
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(tournaments))
+	for _, t := range tournaments {
+		status := i18n.T(l, fmt.Sprintf("tournaments.state.%s", t.State()))
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoice(fmt.Sprintf("%s (%s)", t.Name, status)),
+			Value: string(t.ID),
+		})
+	}
+	return choices
+}
+
+// splitPlayerList splits a typed list of players into the entries that are done and the one that
+// is still being typed
+func splitPlayerList(typed string) (done []string, partial string) {
+	idx := strings.LastIndexAny(typed, " ,\n>")
+	partial = typed[idx+1:]
+	// entries that don't parse are left for the handler to report
+	done, _ = models.ParseMentions(typed[:idx+1])
+	return done, partial
+}
+
+// playerChoices completes the entry that is being typed with the candidates whose name or id
+// contains it. The value of a choice is the whole list as plain ids so it can be parsed like a typed
+// one, its name lists the players by name. known names the players of the finished entries.
+func playerChoices(typed string, candidates []models.Player, known map[string]string) []*discordgo.ApplicationCommandOptionChoice {
+	done, partial := splitPlayerList(typed)
+	partial = strings.ToLower(strings.TrimSpace(partial))
+
+	listed := make(map[string]bool, len(done))
+	names := make([]string, 0, len(done)+1)
+	for _, id := range done {
+		listed[id] = true
+		if name, ok := known[id]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, "<@"+id+">")
+		}
+	}
+	prefix := strings.Join(append(done, ""), " ")
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, p := range candidates {
+		if listed[p.DiscordID] {
+			continue
+		}
+		if partial != "" && !strings.Contains(strings.ToLower(p.Name), partial) && !strings.HasPrefix(p.DiscordID, partial) {
+			continue
+		}
+		value := prefix + p.DiscordID
+		if len(value) > maxChoiceLength {
+			// the list is too long to be completed, what was typed is still accepted
+			break
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoice(strings.Join(append(names, p.Name), ", ")),
+			Value: value,
+		})
+		if len(choices) == base.MAX_CHOICES {
+			break
+		}
+	}
+	return choices
+}
+
+// truncateChoice keeps the end of a choice name so the suggested player is always visible
+func truncateChoice(name string) string {
+	if utf8.RuneCountInString(name) <= maxChoiceLength {
+		return name
+	}
+	runes := []rune(name)
+	return "…" + string(runes[len(runes)-maxChoiceLength+1:])
+}
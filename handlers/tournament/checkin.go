@@ -0,0 +1,235 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+// CheckInJob opens the check-in window of the tournament ahead of its planned start
+type CheckInJob struct{}
+
+func (j *CheckInJob) Kind() string {
+	return JOB_CHECK_IN
+}
+
+func (j *CheckInJob) Run(s *discordgo.Session, job *models.ScheduledJob) error {
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+
+	t, err := tm.GetById(job.TournamentID.String)
+	if err != nil {
+		log.Printf("skipping check-in, %v", err)
+		return nil
+	}
+
+	if t.Started_At.Valid || !t.Check_In_Minutes.Valid || !t.Thread_ID.Valid || t.Check_In_Opened_At.Valid {
+		return nil
+	}
+
+	data, err := checkInMessage(db, t)
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.ChannelMessageSendComplex(t.Thread_ID.String, data)
+	if err != nil {
+		return err
+	}
+	return tm.OpenCheckIn(string(t.ID), msg.ID)
+}
+
+type CheckInComponentHandler struct {
+	db *sql.DB
+}
+
+const ROUTE_CHECK_IN = "checkin"
+
+func (h *CheckInComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_CHECK_IN, Args: 1, Handler: h.checkIn},
+	}
+}
+
+func (h *CheckInComponentHandler) checkIn(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	h.db = database.GetDB()
+
+	t, err := models.NewTournamentsModel(h.db).GetById(cid.Arg(0))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if !t.Check_In_Opened_At.Valid || t.Started_At.Valid {
+		base.Reply("check_in.window_closed", s, i, true)
+		return
+	}
+
+	notRegistered := "signup.not_registered"
+	if t.IsTeam() {
+		notRegistered = "check_in.captains_only"
+	}
+
+	p, err := models.NewPlayerModel(h.db).FindByDiscordId(base.Actor(i).ID)
+	if err == sql.ErrNoRows {
+		base.Reply(notRegistered, s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	am := models.NewAttendeeModel(h.db)
+	a, err := am.FindById(string(t.ID), string(p.ID))
+	if err == sql.ErrNoRows {
+		base.Reply(notRegistered, s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := am.CheckIn(a.Id); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	data, err := checkInMessage(h.db, t)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     data.Embeds,
+			Components: data.Components,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	key := "check_in.done"
+	if a.WaitlistedAt.Valid {
+		key = "check_in.done_waitlist"
+	}
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: i18n.T(base.Locale(i), key),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func checkInMessage(db *sql.DB, t *models.Tournament) (*discordgo.MessageSend, error) {
+	am := models.NewAttendeeModel(db)
+	attendees, err := am.List(string(t.ID), false)
+	if err != nil {
+		return nil, err
+	}
+	waitlist, err := am.Waitlist(string(t.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	l := base.GuildLocale(t.Guild_ID.String)
+	return &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			components.Paint(components.CheckInEmbed(l, t, attendees, waitlist), base.Settings(t.Guild_ID.String).Color()),
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(l, "check_in.button"),
+						Style:    discordgo.SuccessButton,
+						Disabled: t.Started_At.Valid,
+						CustomID: router.ID(ROUTE_CHECK_IN, t.ID),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// closeCheckIn drops the attendees that did not check in and promotes checked-in waitlisted attendees
+// into their spots, it does nothing when the check-in window was never opened
+func closeCheckIn(s *discordgo.Session, db *sql.DB, t *models.Tournament) error {
+	if !t.Check_In_Opened_At.Valid {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	dropped, promoted, err := models.NewAttendeeModel(db).CloseCheckIn(tx, string(t.ID), t.Cap())
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for idx := range promoted {
+		notifyPromoted(s, t, &promoted[idx])
+	}
+
+	if len(dropped) > 0 && t.Thread_ID.Valid {
+		names := make([]string, 0, len(dropped))
+		for _, a := range dropped {
+			names = append(names, fmt.Sprintf("<@%s>", a.Player.DiscordID))
+		}
+		l := base.GuildLocale(t.Guild_ID.String)
+		msg := i18n.N(l, "check_in.dropped", len(dropped), strings.Join(names, ", "))
+		if len(promoted) > 0 {
+			msg += "\n" + i18n.N(l, "check_in.promoted", len(promoted))
+		}
+		if _, err := s.ChannelMessageSend(t.Thread_ID.String, msg); err != nil {
+			log.Println(err)
+		}
+	}
+	return nil
+}
+
+// refreshCheckIn updates the check-in message once the tournament started so its button is disabled
+func refreshCheckIn(s *discordgo.Session, db *sql.DB, tournamentID string) {
+	t, err := models.NewTournamentsModel(db).GetById(tournamentID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !t.Check_In_Message_ID.Valid || !t.Thread_ID.Valid {
+		return
+	}
+
+	data, err := checkInMessage(db, t)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	_, err = s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         t.Check_In_Message_ID.String,
+		Channel:    t.Thread_ID.String,
+		Embeds:     &data.Embeds,
+		Components: &data.Components,
+	})
+	if err != nil {
+		log.Printf("error refreshing check-in message of tournament %s: %v", tournamentID, err)
+	}
+}
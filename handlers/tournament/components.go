@@ -10,12 +10,14 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/dimfu/spade/config"
 	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/discord/components"
 	"github.com/dimfu/spade/handlers/base"
 	"github.com/dimfu/spade/handlers/queue"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/rating"
+	"github.com/dimfu/spade/router"
 )
 
 type TournamentComponentHandler struct {
@@ -24,86 +26,178 @@ type TournamentComponentHandler struct {
 	db         *sql.DB
 }
 
-func (h *TournamentComponentHandler) Name() string {
-	return "tournament"
+const (
+	ROUTE_TOURNAMENT_PUBLISH = "tournament.publish"
+	ROUTE_TOURNAMENT_EDIT    = "tournament.edit"
+	ROUTE_TOURNAMENT_DELETE  = "tournament.delete"
+	// custom id is built with the tournament id, the attendee id and the seat of the winner
+	ROUTE_MATCH_RESULT = "tournament.processresult"
+	// ROUTE_MATCH_SCORE is the modal opened by ROUTE_MATCH_RESULT for matches of more than one game
+	ROUTE_MATCH_SCORE = "tournament.score"
+)
+
+func (h *TournamentComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_TOURNAMENT_PUBLISH, Args: 1, Role: models.ROLE_ORGANIZER, Tournament: true, Handler: h.publish},
+		// the edit form is a modal which can't follow a deferred response
+		{Name: ROUTE_TOURNAMENT_EDIT, Args: 1, Role: models.ROLE_ORGANIZER, Tournament: true, NoDefer: true, Handler: h.edit},
+		// only the owner may delete the tournament
+		{Name: ROUTE_TOURNAMENT_DELETE, Args: 1, Role: models.ROLE_OWNER, Tournament: true, Handler: h.delete},
+		// matches of more than one game answer with the score modal, shorter ones defer by themselves
+		{Name: ROUTE_MATCH_RESULT, Args: 3, NoDefer: true, Handler: h.result},
+		{Name: ROUTE_MATCH_SCORE, Args: 3, Handler: h.score},
+	}
 }
 
-func (h *TournamentComponentHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	err := h.Base.HasPermit(s, i)
+func (h *TournamentComponentHandler) result(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	h.db = database.GetDB()
+
+	t, err := models.NewTournamentsModel(h.db).GetById(cid.Arg(0))
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if err := h.canReport(s, i, t, cid); err != nil {
+		base.RespondError(err, s, i)
 		return
 	}
 
+	// a single game can only end 1-0, longer matches ask for the score first
+	if t.Best_Of <= 1 {
+		report := func(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+			h.report(s, i, t, cid, 1, 0)
+		}
+		router.Defer(router.Route{Name: ROUTE_MATCH_RESULT}, report)(s, i, cid)
+		return
+	}
+
+	locale := base.Locale(i)
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: router.ID(ROUTE_MATCH_SCORE, cid.Arg(0), cid.Arg(1), cid.Arg(2)),
+			Title:    i18n.T(locale, "match.score_title"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "score",
+							Label:       i18n.T(locale, "match.score_label"),
+							Placeholder: fmt.Sprintf("%d-0", t.Best_Of/2+1),
+							Style:       discordgo.TextInputShort,
+							Required:    true,
+							MaxLength:   7,
+							MinLength:   3,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// score reports the result with the score entered in the modal of result
+func (h *TournamentComponentHandler) score(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
 	h.db = database.GetDB()
-	tm := models.NewTournamentsModel(h.db)
 
-	cid := i.MessageComponentData().CustomID
+	t, err := models.NewTournamentsModel(h.db).GetById(cid.Arg(0))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
 
-	splitcid := strings.Split(cid, "_")
-	action := splitcid[1]
-	id := splitcid[2]
+	data := i.ModalSubmitData()
+	text := data.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	winnerScore, loserScore, err := models.ParseScore(text, t.Best_Of)
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
 
-	switch action {
-	case "publish":
-		h.publish(s, i, tm, id)
-	case "edit":
-		t, err := tm.GetById(id)
-		if err != nil {
-			base.SendError(base.ERR_INTERNAL_ERROR, s, i)
-			return
-		}
-		h.edit(s, i, t)
-	case "delete":
-		h.delete(s, i, tm, id)
-	case "processresult":
-		tx, err := h.db.Begin()
-		if err != nil {
-			base.SendError(err, s, i)
-			return
-		}
-		defer tx.Rollback()
+	// the permission is checked again, the match may have been assigned to a referee meanwhile
+	if err := h.canReport(s, i, t, cid); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
 
-		attendeeID, _ := strconv.Atoi(splitcid[3])
+	h.report(s, i, t, cid, winnerScore, loserScore)
+}
 
-		// current winner seat, not the next seat for this attendee
-		winnerSeat, _ := strconv.Atoi(splitcid[4])
-		result, err := h.processResult(tx, id, attendeeID, winnerSeat)
-		if err != nil {
-			if errors.Is(err, base.ERR_FOUND_TOURNAMENT_WINNER) {
-				if result.Winner != nil {
-					if err := h.updateMatchEmbed(s, i, result); err != nil {
-						base.SendError(err, s, i)
-						return
-					}
-					if err := tx.Commit(); err != nil {
-						base.SendError(err, s, i)
-						return
-					}
-					base.Respond("Yay someone just won a tournament", s, i, false)
-				}
+// report stores the result of the match of the message and moves the winner forward
+func (h *TournamentComponentHandler) report(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament,
+	cid router.CustomID, winnerScore, loserScore int) {
+	tm := models.NewTournamentsModel(h.db)
+	id := cid.Arg(0)
+
+	attendeeID, err := cid.Int(1)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	// current winner seat, not the next seat for this attendee
+	winnerSeat, err := cid.Int(2)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := h.processResult(tx, id, attendeeID, winnerSeat, winnerScore, loserScore)
+	before := map[string]int{"attendee_id": attendeeID, "seat": winnerSeat}
+	if err != nil {
+		if errors.Is(err, base.ERR_FOUND_TOURNAMENT_WINNER) && result.Winner != nil {
+			// the final is stored before discord shows it, a failed commit leaves the match to be reported again
+			if _, err := models.NewPlacementModel(h.db).Complete(tx, id); err != nil {
+				base.SendError(err, s, i)
+				return
 			}
-			base.SendError(err, s, i)
-			return
-		}
-		if err := tx.Commit(); err != nil {
-			base.SendError(err, s, i)
-			return
-		}
+			if err := tx.Commit(); err != nil {
+				base.SendError(err, s, i)
+				return
+			}
+			base.Audit(i, models.AUDIT_RESULT, id, before, auditResult(result, winnerScore, loserScore))
 
-		if err := h.updateMatchEmbed(s, i, result); err != nil {
-			base.SendError(err, s, i)
+			if err := h.updateMatchEmbed(s, i, result); err != nil {
+				base.SendError(err, s, i)
+				return
+			}
+			closeMatchThread(s, t, i.ChannelID, result.Winner.Name(), result.Loser.Name(), result.MatchCount-1)
+			notifyResult(s, h.db, t, result, true)
+			refreshStatus(s, id)
+			h.announce(s, i, tm, id)
 			return
 		}
-	default:
-		base.Respond("Action not listed", s, i, true)
+		base.SendError(err, s, i)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
 		return
 	}
+	base.Audit(i, models.AUDIT_RESULT, id, before, auditResult(result, winnerScore, loserScore))
+
+	if err := h.updateMatchEmbed(s, i, result); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	notifyResult(s, h.db, t, result, false)
+	refreshStatus(s, id)
+	closeMatchThread(s, t, i.ChannelID, result.Winner.Name(), result.Loser.Name(), result.MatchCount-1)
 }
 
-func (h *TournamentComponentHandler) publish(
-	s *discordgo.Session, i *discordgo.InteractionCreate, tm *models.TournamentsModel, id string) {
-	cfg := config.GetEnv()
+func (h *TournamentComponentHandler) publish(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	tm := models.NewTournamentsModel(database.GetDB())
+	id := cid.Arg(0)
 	t, err := tm.GetById(id)
 	if err != nil {
 		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
@@ -111,18 +205,38 @@ func (h *TournamentComponentHandler) publish(
 	}
 
 	if t.Published {
-		base.Respond("Tournament has already been published", s, i, true)
+		base.Reply("tournament.already_published", s, i, true)
 		return
 	}
 
-	thread, err := s.ThreadStartComplex(cfg.TOURNAMENT_CHANNEL_ID, &discordgo.ThreadStart{
+	before := models.SnapshotTournament(t)
+	thread, err := publishTournament(s, tm, t)
+	if errors.Is(err, base.ERR_NO_TOURNAMENT_CHANNEL) {
+		base.RespondError(err, s, i)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	base.Audit(i, models.AUDIT_PUBLISH, id, before, models.SnapshotTournament(t))
+
+	base.Reply("tournament.published", s, i, true, thread.ID)
+}
+
+// publishTournament opens the tournament thread and pins the tournament configuration inside it
+func publishTournament(s *discordgo.Session, tm *models.TournamentsModel, t *models.Tournament) (*discordgo.Channel, error) {
+	channelID, err := base.TournamentChannel(t.Guild_ID.String)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, err := s.ThreadStartComplex(channelID, &discordgo.ThreadStart{
 		Name: t.Name,
 		Type: discordgo.ChannelTypeGuildPublicThread,
 	})
-
 	if err != nil {
-		base.SendError(err, s, i)
-		return
+		return nil, err
 	}
 
 	t.Published = true
@@ -132,70 +246,51 @@ func (h *TournamentComponentHandler) publish(
 	}
 
 	if err = tm.Update(t); err != nil {
-		base.SendError(err, s, i)
-		return
+		return nil, err
 	}
 
-	fields := []*discordgo.MessageEmbedField{
-		{Name: "Name", Value: t.Name},
+	color := base.Settings(t.Guild_ID.String).Color()
+	e, err := s.ChannelMessageSendEmbed(thread.ID, components.Paint(components.ConfigurationEmbed(base.GuildLocale(t.Guild_ID.String), t), color))
+	if err != nil {
+		return nil, err
 	}
 
-	if len(t.Description.String) > 0 {
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:  "Description",
-			Value: t.Description.String,
-		})
+	if err = s.ChannelMessagePin(thread.ID, e.ID); err != nil {
+		return nil, err
 	}
 
-	if len(t.Rules.String) > 0 {
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:  "Rules",
-			Value: t.Rules.String,
-		})
+	if err = postSignup(s, tm, t); err != nil {
+		return nil, err
 	}
 
-	fields = append(fields,
-		&discordgo.MessageEmbedField{Name: "Best Of", Value: "1"},
-		&discordgo.MessageEmbedField{Name: "Player Cap", Value: t.TournamentType.Size},
-		&discordgo.MessageEmbedField{Name: "Bracket Type", Value: "Single Elimination"},
-	)
-
-	e, err := s.ChannelMessageSendEmbed(thread.ID, &discordgo.MessageEmbed{
-		Title:       "Configuration",
-		Description: "Available configuration for your tournament",
-		Fields:      fields,
-		Footer: &discordgo.MessageEmbedFooter{
-			Text: string(t.ID),
-		},
-	})
-
-	if err != nil {
-		base.SendError(err, s, i)
-		return
+	// a check-in window that began before the thread existed could not be opened yet
+	if err = scheduleStart(t); err != nil {
+		log.Printf("error scheduling the start of tournament %s: %v", t.ID, err)
 	}
 
-	err = s.ChannelMessagePin(thread.ID, e.ID)
+	return thread, nil
+}
+
+func (h *TournamentComponentHandler) edit(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	t, err := models.NewTournamentsModel(database.GetDB()).GetById(cid.Arg(0))
 	if err != nil {
-		base.SendError(err, s, i)
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
 		return
 	}
 
-	base.Respond(fmt.Sprintf("Tournament <#%s> is published to the public", thread.ID), s, i, true)
-}
-
-func (h *TournamentComponentHandler) edit(
-	s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) {
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	settings := base.Settings(i.GuildID)
+	locale := base.Locale(i)
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
-			CustomID: "modals-tournament_edit_" + string(t.ID),
-			Title:    "Edit Tournament",
+			CustomID: router.ID(ROUTE_TOURNAMENT_EDIT_FORM, t.ID),
+			Title:    i18n.T(locale, "tournament.edit.title"),
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.TextInput{
 							CustomID:  "name",
-							Label:     "Tournament Name",
+							Label:     i18n.T(locale, "tournament.edit.name"),
 							Style:     discordgo.TextInputShort,
 							Required:  true,
 							MaxLength: 128,
@@ -208,8 +303,8 @@ func (h *TournamentComponentHandler) edit(
 					Components: []discordgo.MessageComponent{
 						discordgo.TextInput{
 							CustomID:    "description",
-							Placeholder: "Describe what's this tournament all about",
-							Label:       "Description",
+							Placeholder: i18n.T(locale, "tournament.edit.description_placeholder"),
+							Label:       i18n.T(locale, "tournament.edit.description"),
 							Style:       discordgo.TextInputParagraph,
 							Required:    false,
 							MaxLength:   2000,
@@ -218,12 +313,25 @@ func (h *TournamentComponentHandler) edit(
 						},
 					},
 				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "best_of",
+							Label:     i18n.T(locale, "tournament.edit.best_of"),
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MaxLength: 2,
+							MinLength: 1,
+							Value:     strconv.Itoa(t.Best_Of),
+						},
+					},
+				},
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.TextInput{
 							CustomID:    "rules",
-							Placeholder: "Rules if they are applicable",
-							Label:       "Rules",
+							Placeholder: i18n.T(locale, "tournament.edit.rules_placeholder"),
+							Label:       i18n.T(locale, "tournament.edit.rules"),
 							Style:       discordgo.TextInputParagraph,
 							Required:    false,
 							MaxLength:   2000,
@@ -232,6 +340,20 @@ func (h *TournamentComponentHandler) edit(
 						},
 					},
 				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "starting_at",
+							Placeholder: i18n.T(locale, "tournament.edit.starting_at_placeholder"),
+							Label:       i18n.T(locale, "tournament.edit.starting_at", settings.Timezone),
+							Style:       discordgo.TextInputShort,
+							Required:    false,
+							MaxLength:   16,
+							MinLength:   0,
+							Value:       formatStartingAt(t, settings.Location()),
+						},
+					},
+				},
 			},
 		},
 	})
@@ -241,32 +363,39 @@ func (h *TournamentComponentHandler) edit(
 	}
 }
 
-func (h *TournamentComponentHandler) delete(s *discordgo.Session, i *discordgo.InteractionCreate,
-	tm *models.TournamentsModel, id string) {
+func (h *TournamentComponentHandler) delete(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	tm := models.NewTournamentsModel(database.GetDB())
+	id := cid.Arg(0)
+
 	// delete the embed message of this tournament
 	err := s.ChannelMessageDelete(i.ChannelID, i.Message.ID)
 	if err != nil {
 		fmt.Println("Error deleting message:", err)
 	}
 
-	var threadID sql.NullString
 	t, err := tm.Delete(id)
+	if t == nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(err, s, i)
+		return
 	}
 
-	threadID = t.Thread_ID
-	if t != nil && threadID.Valid {
-		_, err = s.ChannelDelete(threadID.String)
+	base.Audit(i, models.AUDIT_DELETE, id, models.SnapshotTournament(t), nil)
+
+	if t.Thread_ID.Valid {
+		_, err = s.ChannelDelete(t.Thread_ID.String)
 		if err != nil {
-			base.Respond(fmt.Sprintf("Cannot delete tournament channel while deleting tournament: %v", err), s, i, true)
+			base.Reply("tournament.delete_channel_failed", s, i, true, err)
 		}
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "Tournament successfully deleted",
+			Content: i18n.T(base.Locale(i), "tournament.deleted"),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -282,28 +411,146 @@ func (h *TournamentComponentHandler) delete(s *discordgo.Session, i *discordgo.I
 }
 
 func (h *TournamentComponentHandler) updateMatchEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, result *queue.MatchResult) error {
+	// the referee stays on the embed as the one accountable for the result
+	var referee string
+	if record, err := models.NewMatchModel(h.db).FindByMessage(i.Message.ID); err == nil {
+		referee = record.RefereeID.String
+	}
+
 	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:      i.Message.ID,
 		Channel: i.ChannelID,
-		Embed: components.MatchupEmbed(components.MatchupPayload{
-			P1:     *result.Winner,
-			P2:     *result.Loser,
-			Winner: result.Winner,
-			Match:  result.MatchCount,
-		}),
+		Embed: components.Paint(components.MatchupEmbed(components.MatchupPayload{
+			P1:      *result.Winner,
+			P2:      *result.Loser,
+			Winner:  result.Winner,
+			Match:   result.MatchCount,
+			Referee: referee,
+			Locale:  base.GuildLocale(i.GuildID),
+		}), base.Settings(i.GuildID).Color()),
 		Components: &[]discordgo.MessageComponent{},
 	})
 
 	return err
 }
 
-func (h *TournamentComponentHandler) processResult(tx *sql.Tx, tournamentID string, attendeeID, winnerSeat int) (*queue.MatchResult, error) {
-	now := time.Now().Unix()
-	result, err := h.MatchQueue.Result(tournamentID, attendeeID)
+// canReport checks that the actor may report the result of the match of the message. Matches with an
+// assigned referee are reported by that referee or managers, other matches by the tournament referees
+// and by the captains playing them for their own side.
+func (h *TournamentComponentHandler) canReport(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament,
+	cid router.CustomID) error {
+	record, err := models.NewMatchModel(h.db).FindByMessage(i.Message.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if record != nil && record.RefereeID.Valid {
+		if record.RefereeID.String == base.Actor(i).ID {
+			return nil
+		}
+		if err := h.Base.Authorize(s, i, t, models.ROLE_MANAGER); err != nil {
+			return i18n.NewError("match.referee_only", record.RefereeID.String)
+		}
+		return nil
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_REFEREE); err != nil && !h.isCaptainOfMatch(i, cid) {
+		return err
+	}
+	return nil
+}
+
+// isCaptainOfMatch reports whether the actor captains the team whose result is reported, the team
+// has to play the match of the message. Captains only report the side of their own team.
+func (h *TournamentComponentHandler) isCaptainOfMatch(i *discordgo.InteractionCreate, cid router.CustomID) bool {
+	if i.Message == nil {
+		return false
+	}
+
+	attendeeID, err := cid.Int(1)
 	if err != nil {
-		if errors.Is(err, base.ERR_FOUND_TOURNAMENT_WINNER) {
-			return result, err
+		return false
+	}
+
+	record, err := models.NewMatchModel(h.db).FindByMessage(i.Message.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println(err)
 		}
+		return false
+	}
+
+	if record.P1AttendeeID.Int64 != int64(attendeeID) && record.P2AttendeeID.Int64 != int64(attendeeID) {
+		return false
+	}
+
+	team, err := models.NewTeamModel(h.db).FindByAttendee(attendeeID)
+	if err != nil {
+		return false
+	}
+	return team.Captain.DiscordID == base.Actor(i).ID
+}
+
+// announce posts the podium of the completed tournament and closes its thread, the thread is locked
+// so the results can't be buried under new messages
+func (h *TournamentComponentHandler) announce(s *discordgo.Session, i *discordgo.InteractionCreate,
+	tm *models.TournamentsModel, id string) {
+	t, err := tm.GetById(id)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	placements, err := models.NewPlacementModel(h.db).List(id)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{components.Paint(components.PodiumEmbed(base.GuildLocale(i.GuildID), t, placements), base.Settings(i.GuildID).Color())},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if !t.Thread_ID.Valid {
+		return
+	}
+	locked, archived := true, true
+	_, err = s.ChannelEdit(t.Thread_ID.String, &discordgo.ChannelEdit{
+		Locked:   &locked,
+		Archived: &archived,
+	})
+	if err != nil {
+		log.Printf("error closing thread of tournament %s: %v", id, err)
+	}
+}
+
+func auditResult(result *queue.MatchResult, winnerScore, loserScore int) map[string]interface{} {
+	data := map[string]interface{}{"match": result.MatchCount, "score": fmt.Sprintf("%d-%d", winnerScore, loserScore)}
+	if result.Winner != nil {
+		data["winner_attendee_id"] = result.Winner.Attendee.Id
+	}
+	if result.Loser != nil {
+		data["loser_attendee_id"] = result.Loser.Attendee.Id
+	}
+	if result.WinnerTo != nil {
+		data["winner_to"] = *result.WinnerTo
+	}
+	return data
+}
+
+func (h *TournamentComponentHandler) processResult(tx *sql.Tx, tournamentID string, attendeeID, winnerSeat,
+	winnerScore, loserScore int) (*queue.MatchResult, error) {
+	now := time.Now().Unix()
+	result, err := h.MatchQueue.Result(tournamentID, attendeeID)
+	// the final match result still needs to be stored before reporting the tournament winner
+	foundWinner := errors.Is(err, base.ERR_FOUND_TOURNAMENT_WINNER)
+	if err != nil && !foundWinner {
 		return nil, err
 	}
 
@@ -341,7 +588,33 @@ func (h *TournamentComponentHandler) processResult(tx *sql.Tx, tournamentID stri
 		if err != nil {
 			return nil, err
 		}
+
+		mm := models.NewMatchModel(h.db)
+		if err := mm.Complete(tx, result.MatchID, winner.Attendee.Id, winnerScore, loserScore); err != nil {
+			return nil, err
+		}
+		if err := mm.Advance(tx, tournamentID, *result.WinnerTo, winner.Attendee.Id); err != nil {
+			return nil, err
+		}
 	}
 
+	// only matches that were actually played between two players count towards the ratings
+	if result.Winner != nil && result.Loser != nil {
+		t, err := models.NewTournamentsModel(h.db).GetById(tournamentID)
+		if err != nil {
+			return nil, err
+		}
+		// ratings are personal, team results would be credited to the captains alone
+		if t.Guild_ID.Valid && !t.IsTeam() {
+			err := rating.Record(tx, t.Guild_ID.String, t.Game.String, result.Winner.PlayerID, result.Loser.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if foundWinner {
+		return result, base.ERR_FOUND_TOURNAMENT_WINNER
+	}
 	return result, nil
 }
@@ -1,24 +1,40 @@
 package tournament
 
 import (
-	"fmt"
+	"database/sql"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dimfu/spade/bracket"
 	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
 	"github.com/google/uuid"
 )
 
 type tournamentChoice struct {
 	name  string
+	names map[discordgo.Locale]string
 	value int
 }
 
+var (
+	minTeamSize = float64(1)
+	minCheckIn  = float64(5)
+)
+
+const (
+	maxTeamSize    = 10
+	maxRosterLimit = 15
+	maxCheckIn     = 24 * 60
+)
+
 type TournamentCreateHandler struct {
 	Base              *base.BaseAdmin
 	tournamentChoices []tournamentChoice
@@ -41,7 +57,9 @@ func (h *TournamentCreateHandler) Command() *discordgo.ApplicationCommand {
 		if tt.Has_Third_Winner {
 			h.tournamentChoices = append(h.tournamentChoices,
 				tournamentChoice{
-					name: fmt.Sprintf("%v (%v players)", tt.Bracket_Type, tt.Size), value: tt.ID,
+					name:  i18n.T(i18n.DEFAULT, "tournament.format", tt.Bracket_Type, tt.Size),
+					names: i18n.Localized("tournament.format", tt.Bracket_Type, tt.Size),
+					value: tt.ID,
 				},
 			)
 		}
@@ -52,8 +70,9 @@ func (h *TournamentCreateHandler) Command() *discordgo.ApplicationCommand {
 
 	for i, choice := range h.tournamentChoices {
 		discordChoice := &discordgo.ApplicationCommandOptionChoice{
-			Name:  choice.name,
-			Value: choice.value,
+			Name:              choice.name,
+			NameLocalizations: choice.names,
+			Value:             choice.value,
 		}
 		discordChoices[i] = discordChoice
 	}
@@ -67,7 +86,62 @@ func (h *TournamentCreateHandler) Command() *discordgo.ApplicationCommand {
 				Description: "Select pre configured tournament settings",
 				Type:        discordgo.ApplicationCommandOptionInteger,
 				Choices:     discordChoices,
-				Required:    true,
+				Required:    false,
+			},
+			{
+				Name:        "template",
+				Description: "Create the tournament from a saved template",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Required:    false,
+			},
+			{
+				Name:        "self_register",
+				Description: "Allow players to register themselves (default true)",
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Required:    false,
+			},
+			{
+				Name:        "game",
+				Description: "Game that is played, players get a separate rating per game",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Required:    false,
+				MaxLength:   64,
+			},
+			{
+				Name:        "team_size",
+				Description: "Players per team, leave empty for a solo tournament",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Required:    false,
+				MinValue:    &minTeamSize,
+				MaxValue:    maxTeamSize,
+			},
+			{
+				Name:        "roster_limit",
+				Description: "Most players a team can have, substitutes included (default the team size)",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Required:    false,
+				MinValue:    &minTeamSize,
+				MaxValue:    maxRosterLimit,
+			},
+			{
+				Name:        "starting_at",
+				Description: "Start the tournament automatically at YYYY-MM-DD HH:MM in the server time zone",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Required:    false,
+			},
+			{
+				Name:        "check_in",
+				Description: "Minutes before the start players have to check in, no-shows lose their spot",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Required:    false,
+				MinValue:    &minCheckIn,
+				MaxValue:    maxCheckIn,
+			},
+			{
+				Name:        "match_threads",
+				Description: "Play every match in a private thread with its players and referees",
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Required:    false,
 			},
 		},
 	}
@@ -75,109 +149,174 @@ func (h *TournamentCreateHandler) Command() *discordgo.ApplicationCommand {
 
 func (h *TournamentCreateHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	db := database.GetDB()
-	err := h.Base.HasPermit(s, i)
+	err := h.Base.Authorize(s, i, nil, models.ROLE_ORGANIZER)
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(err, s, i)
 		return
 	}
 
-	data := i.ApplicationCommandData()
-	payload := data.Options[0].IntValue()
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range i.ApplicationCommandData().Options {
+		options[opt.Name] = opt
+	}
+	settings := base.Settings(i.GuildID)
+
+	t := &models.Tournament{
+		ID:                []uint8(uuid.New().String()),
+		Name:              i18n.T(base.GuildLocale(i.GuildID), "create.default_name"),
+		Created_At:        strconv.FormatInt(time.Now().Unix(), 10),
+		Guild_ID:          sql.NullString{String: i.GuildID, Valid: i.GuildID != ""},
+		Best_Of:           1,
+		Self_Register:     true,
+		Registration_Open: true,
+		Team_Size:         1,
+		Owner_ID:          sql.NullString{String: base.Actor(i).ID, Valid: true},
+	}
 
-	var tt models.TournamentType
-	for _, t := range h.tournamentTypes {
-		if t.ID == int(payload) {
-			tt = t
+	if opt, ok := options["template"]; ok {
+		ttm := models.NewTournamentTemplatesModel(db)
+		tp, err := ttm.FindByName(i.GuildID, opt.StringValue())
+		if err != nil {
+			if err == sql.ErrNoRows {
+				base.Reply("template.not_found", s, i, true, opt.StringValue())
+				return
+			}
+			base.SendError(err, s, i)
+			return
+		}
+		fromTemplate(t, tp)
+		if err := ttm.IncrementUses(tp.ID); err != nil {
+			log.Println(err.Error())
+		}
+	} else {
+		// the default format of the guild is used when no configuration is picked
+		typeID := settings.DefaultTypeID.Int64
+		if opt, ok := options["configurations"]; ok {
+			typeID = opt.IntValue()
+		} else if !settings.DefaultTypeID.Valid {
+			base.Reply("create.no_configuration", s, i, true)
+			return
+		}
+		for _, tt := range h.tournamentTypes {
+			if tt.ID == int(typeID) {
+				t.Tournament_Types_ID = tt.ID
+				t.TournamentType = tt
+			}
 		}
 	}
 
-	sizeInt, err := strconv.Atoi(tt.Size)
-	if err != nil {
-		log.Println(err.Error())
-		base.SendError(base.ERR_CREATING_TOURNAMENT, s, i)
-		return
+	if opt, ok := options["self_register"]; ok {
+		t.Self_Register = opt.BoolValue()
 	}
 
-	t, err := bracket.GenerateFromTemplate(sizeInt)
-	if err != nil {
-		log.Println(err.Error())
-		base.SendError(base.ERR_GENERATE_BRACKET, s, i)
+	if opt, ok := options["game"]; ok {
+		game := strings.TrimSpace(opt.StringValue())
+		t.Game = sql.NullString{String: game, Valid: game != ""}
+	}
+
+	if opt, ok := options["team_size"]; ok {
+		t.Team_Size = int(opt.IntValue())
+	}
+
+	if opt, ok := options["roster_limit"]; ok {
+		t.Roster_Limit = sql.NullInt64{Int64: opt.IntValue(), Valid: true}
+	}
+
+	// the settings may come from a template, they are checked once the options are applied
+	if t.Roster_Limit.Valid && int(t.Roster_Limit.Int64) < t.Team_Size {
+		base.Reply("create.roster_limit", s, i, true)
 		return
 	}
 
-	query := `
-        INSERT INTO tournaments (id, name, tournament_types_id, starting_at, created_at) 
-        VALUES (?, ?, ?, ?, ?)`
+	if opt, ok := options["starting_at"]; ok {
+		at, err := parseStartingAt(opt.StringValue(), settings.Location())
+		if err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+		t.Starting_At = at
+	}
+
+	if opt, ok := options["check_in"]; ok {
+		t.Check_In_Minutes = sql.NullInt64{Int64: opt.IntValue(), Valid: true}
+	}
 
-	createdAt := time.Now().Unix()
+	if t.Check_In_Minutes.Valid && !t.Starting_At.Valid {
+		base.Reply("create.check_in_without_start", s, i, true)
+		return
+	}
 
-	stmt, err := db.Prepare(query)
+	if opt, ok := options["match_threads"]; ok {
+		t.Match_Threads = opt.BoolValue()
+	}
+
+	sizeInt, err := strconv.Atoi(t.TournamentType.Size)
 	if err != nil {
 		log.Println(err.Error())
 		base.SendError(base.ERR_CREATING_TOURNAMENT, s, i)
 		return
 	}
-	defer stmt.Close()
 
-	tName := "New Tournament"
+	if _, err := bracket.GenerateFromTemplate(sizeInt); err != nil {
+		log.Println(err.Error())
+		base.SendError(base.ERR_GENERATE_BRACKET, s, i)
+		return
+	}
 
-	tId := uuid.New().String()
-	_, err = stmt.Exec(tId, tName, tt.ID, nil, createdAt)
-	if err != nil {
+	tm := models.NewTournamentsModel(db)
+	if err := tm.Insert(t); err != nil {
 		log.Println(err.Error())
 		base.SendError(base.ERR_CREATING_TOURNAMENT, s, i)
 		return
 	}
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	if err := scheduleStart(t); err != nil {
+		log.Printf("error scheduling the start of tournament %s: %v", t.ID, err)
+	}
+
+	tId := string(t.ID)
+	locale := base.GuildLocale(i.GuildID)
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title:       "Configuration",
-					Description: "Available configuration for your tournament",
-					Fields: []*discordgo.MessageEmbedField{
-						{Name: "Name", Value: tName},
-						{Name: "Best Of", Value: "1"}, // TODO: Use dynamic value instead of hard coded value
-						{Name: "Player Cap", Value: strconv.Itoa(len(t.StartingSeats))},
-						{Name: "Bracket Type", Value: "Single Elimination"},
+			Embeds:          []*discordgo.MessageEmbed{components.Paint(components.ConfigurationEmbed(locale, t), settings.Color())},
+			Components:      configurationComponents(locale, tId),
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+// configurationComponents are the buttons attached to the configuration embed of an unpublished tournament
+func configurationComponents(l i18n.Locale, tId string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "📤",
 					},
-					Footer: &discordgo.MessageEmbedFooter{
-						Text: tId,
+					Label:    i18n.T(l, "tournament.publish"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: router.ID(ROUTE_TOURNAMENT_PUBLISH, tId),
+				},
+				discordgo.Button{
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "✍",
 					},
+					Label:    i18n.T(l, "tournament.edit"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: router.ID(ROUTE_TOURNAMENT_EDIT, tId),
 				},
-			},
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.Button{
-							Emoji: &discordgo.ComponentEmoji{
-								Name: "📤",
-							},
-							Label:    "Publish",
-							Style:    discordgo.SecondaryButton,
-							CustomID: fmt.Sprintf("tournament_publish_%s", tId),
-						},
-						discordgo.Button{
-							Emoji: &discordgo.ComponentEmoji{
-								Name: "✍",
-							},
-							Label:    "Edit",
-							Style:    discordgo.SecondaryButton,
-							CustomID: fmt.Sprintf("tournament_edit_%s", tId),
-						},
-						discordgo.Button{
-							Emoji: &discordgo.ComponentEmoji{
-								Name: "🗑️",
-							},
-							Label:    "Delete",
-							Style:    discordgo.DangerButton,
-							CustomID: fmt.Sprintf("tournament_delete_%s", tId),
-						},
+				discordgo.Button{
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "🗑️",
 					},
+					Label:    i18n.T(l, "tournament.delete"),
+					Style:    discordgo.DangerButton,
+					CustomID: router.ID(ROUTE_TOURNAMENT_DELETE, tId),
 				},
 			},
-			AllowedMentions: &discordgo.MessageAllowedMentions{},
 		},
-	})
+	}
 }
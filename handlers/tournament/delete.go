@@ -19,62 +19,79 @@ func (h *TournamentDeleteHandler) Command() *discordgo.ApplicationCommand {
 		Description: "Delete current tournament",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "id",
-				Description: "Tournament id (optional)",
-				Required:    false,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "id",
+				Description:  "Tournament to delete (default the tournament of this thread)",
+				Required:     false,
+				Autocomplete: true,
 			},
 		},
 	}
 }
 
-func (h *TournamentDeleteHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	err := h.Base.HasPermit(s, i)
-	if err != nil {
-		base.SendError(err, s, i)
-		return
+func (h *TournamentDeleteHandler) Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var query string
+	if opt := base.Focused(i.ApplicationCommandData().Options); opt != nil {
+		query = opt.StringValue()
 	}
+	base.Suggest(s, i, tournamentChoices(base.Locale(i), i.GuildID, query))
+}
 
+func (h *TournamentDeleteHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var err error
 	db := database.GetDB()
 	tm := models.NewTournamentsModel(db)
 
 	var tId []uint8
 	var targetChannel string
 
-	data := i.ApplicationCommandData()
-	providedTID := data.Options[0].StringValue()
+	var providedTID string
+	if data := i.ApplicationCommandData(); len(data.Options) > 0 {
+		providedTID = data.Options[0].StringValue()
+	}
 
 	if len(providedTID) == 0 {
 		tId, err = tm.GetTournamentIDInThread(i.ChannelID)
 		if err != nil {
-			base.Respond(base.ERR_GET_TOURNAMENT_IN_CHANNEL.Error(), s, i, true)
+			base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
 			return
 		}
 		targetChannel = i.ChannelID
 	} else {
 		tId = []uint8(providedTID)
-		t, err := tm.GetById(providedTID)
-		if err != nil {
-			log.Println(err.Error())
-			return
-		}
+	}
+
+	t, err := tm.GetById(string(tId))
+	if err != nil {
+		log.Println(err.Error())
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+	if len(providedTID) > 0 {
 		targetChannel = t.Thread_ID.String
 	}
 
-	_, err = tm.Delete(string(tId))
+	// only the owner may delete the tournament
+	if err := h.Base.Authorize(s, i, t, models.ROLE_OWNER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	deleted, err := tm.Delete(string(tId))
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(err, s, i)
 		return
 	}
+	base.Audit(i, models.AUDIT_DELETE, string(tId), models.SnapshotTournament(deleted), nil)
 
 	if len(targetChannel) > 0 {
 		_, err = s.ChannelDelete(targetChannel)
 		if err != nil {
-			base.Respond(err.Error(), s, i, true)
+			base.RespondError(err, s, i)
 			return
 		}
 	}
 
 	// TODO: need to delete all attendees
-	base.Respond("Tournament successfully deleted", s, i, true)
+	base.Reply("tournament.deleted", s, i, true)
 }
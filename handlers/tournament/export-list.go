@@ -1,9 +1,7 @@
 package tournament
 
 import (
-	"bytes"
 	"database/sql"
-	"encoding/csv"
 	"fmt"
 	"log"
 	"strconv"
@@ -12,6 +10,7 @@ import (
 	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/handlers/base"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
 )
 
 type ExportListHandler struct {
@@ -24,12 +23,15 @@ type extractType = int64
 const (
 	PARTICIANTS extractType = iota
 	SEEDS
+	ARCHIVE
+	RESULTS
+	MATCHES
 )
 
 func (h *ExportListHandler) Command() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
 		Name:        "export",
-		Description: "Create a tournament",
+		Description: "Export the tournament participants, results or the whole tournament",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Name:        "type",
@@ -44,6 +46,37 @@ func (h *ExportListHandler) Command() *discordgo.ApplicationCommand {
 						Name:  "seed",
 						Value: 1,
 					},
+					{
+						Name:  "tournament (json)",
+						Value: 2,
+					},
+					{
+						Name:  "results",
+						Value: 3,
+					},
+					{
+						Name:  "matches",
+						Value: 4,
+					},
+				},
+			},
+			{
+				Name:        "profile",
+				Description: "Column layout of the results and matches export",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "default",
+						Value: PROFILE_DEFAULT,
+					},
+					{
+						Name:  "challonge",
+						Value: PROFILE_CHALLONGE,
+					},
+					{
+						Name:  "start.gg",
+						Value: PROFILE_STARTGG,
+					},
 				},
 			},
 		},
@@ -52,25 +85,44 @@ func (h *ExportListHandler) Command() *discordgo.ApplicationCommand {
 
 func (h *ExportListHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	h.db = database.GetDB()
-	err := h.Base.HasPermit(s, i)
+	tm := models.NewTournamentsModel(h.db)
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
 		return
 	}
 
-	tm := models.NewTournamentsModel(h.db)
-	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	t, err := tm.GetById(string(tournamentId))
 	if err != nil {
-		base.Respond(base.ERR_GET_TOURNAMENT_IN_CHANNEL.Error(), s, i, true)
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
 		return
 	}
 
 	am := models.NewAttendeeModel(h.db)
 
-	cdata := i.ApplicationCommandData()
 	listType := PARTICIANTS
-	if len(cdata.Options) != 0 {
-		listType = cdata.Options[0].IntValue()
+	profile := PROFILE_DEFAULT
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "type":
+			listType = opt.IntValue()
+		case "profile":
+			profile = opt.StringValue()
+		}
+	}
+
+	switch listType {
+	case ARCHIVE:
+		exportArchive(s, i, h.db, string(tournamentId))
+		return
+	case RESULTS, MATCHES:
+		h.exportResults(s, i, string(tournamentId), listType, profile)
+		return
 	}
 
 	var (
@@ -88,12 +140,12 @@ func (h *ExportListHandler) Handler(s *discordgo.Session, i *discordgo.Interacti
 	list, err := am.List(string(tournamentId), seeded)
 	if err != nil {
 		log.Println(err.Error())
-		base.Respond("Cannot extract participants list", s, i, true)
+		base.Reply("export.participants_failed", s, i, true)
 		return
 	}
 
 	if len(list) == 0 {
-		base.Respond("No records to print", s, i, true)
+		base.Reply("export.empty", s, i, true)
 		return
 	}
 
@@ -102,12 +154,12 @@ func (h *ExportListHandler) Handler(s *discordgo.Session, i *discordgo.Interacti
 		data = append(data, []string{l.Player.Name, fmt.Sprintf("<@%s>", l.Player.DiscordID), currSeat})
 	}
 
-	buf, err := toCSV(data)
+	buf, err := base.ToCSV(data)
 	if err != nil {
 		panic(err)
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Files: []*discordgo.File{
@@ -120,17 +172,3 @@ func (h *ExportListHandler) Handler(s *discordgo.Session, i *discordgo.Interacti
 		panic(err)
 	}
 }
-
-func toCSV(data [][]string) (*bytes.Buffer, error) {
-	var buffer bytes.Buffer
-	writer := csv.NewWriter(&buffer)
-	defer writer.Flush()
-
-	for _, value := range data {
-		if err := writer.Write(value); err != nil {
-			return &buffer, err
-		}
-	}
-
-	return &buffer, nil
-}
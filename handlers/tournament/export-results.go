@@ -0,0 +1,152 @@
+package tournament
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+type exportProfile = string
+
+const (
+	PROFILE_DEFAULT   exportProfile = "default"
+	PROFILE_CHALLONGE exportProfile = "challonge"
+	PROFILE_STARTGG   exportProfile = "startgg"
+)
+
+// exportResults responds with the placements or the match results of the tournament as csv, laid out
+// by the profile so the file can be uploaded to other bracket platforms
+func (h *ExportListHandler) exportResults(s *discordgo.Session, i *discordgo.InteractionCreate,
+	tournamentId string, listType extractType, profile exportProfile) {
+	attendees, err := models.NewAttendeeModel(h.db).List(tournamentId, false)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	records, err := models.NewMatchModel(h.db).List(tournamentId)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	players := make(map[int64]models.Player, len(attendees))
+	for _, a := range attendees {
+		players[int64(a.Id)] = a.Player
+	}
+
+	var (
+		rows [][]string
+		name string
+	)
+	if listType == RESULTS {
+		standings := models.Standings(records)
+		if len(standings) == 0 {
+			base.Reply("export.no_results", s, i, true)
+			return
+		}
+		rows = resultRows(profile, standings, players)
+		name = "results"
+	} else {
+		if len(records) == 0 {
+			base.Reply("export.not_started", s, i, true)
+			return
+		}
+		rows = matchRows(profile, records, players)
+		name = "matches"
+	}
+
+	buf, err := base.ToCSV(rows)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Files: []*discordgo.File{
+				{Name: fmt.Sprintf("%s-%s-%s.csv", tournamentId, name, profile), ContentType: "text/csv", Reader: buf},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func resultRows(profile exportProfile, standings []models.Standing, players map[int64]models.Player) [][]string {
+	var rows [][]string
+	switch profile {
+	case PROFILE_CHALLONGE:
+		rows = append(rows, []string{"Final Rank", "Name", "Wins", "Losses"})
+	case PROFILE_STARTGG:
+		rows = append(rows, []string{"Placement", "GamerTag", "Discord ID"})
+	default:
+		rows = append(rows, []string{"place", "name", "discord_id", "wins", "losses"})
+	}
+
+	for _, st := range standings {
+		p := players[int64(st.AttendeeID)]
+		place := strconv.Itoa(st.Place)
+		switch profile {
+		case PROFILE_CHALLONGE:
+			rows = append(rows, []string{place, p.Name, strconv.Itoa(st.Wins), strconv.Itoa(st.Losses)})
+		case PROFILE_STARTGG:
+			rows = append(rows, []string{place, p.Name, p.DiscordID})
+		default:
+			rows = append(rows, []string{place, p.Name, p.DiscordID, strconv.Itoa(st.Wins), strconv.Itoa(st.Losses)})
+		}
+	}
+	return rows
+}
+
+func matchRows(profile exportProfile, records []models.MatchRecord, players map[int64]models.Player) [][]string {
+	var rows [][]string
+	switch profile {
+	case PROFILE_CHALLONGE:
+		// scores are written the way challonge expects them, player 1 score first
+		rows = append(rows, []string{"Round", "Match", "Player 1", "Player 2", "Scores", "Winner"})
+	case PROFILE_STARTGG:
+		rows = append(rows, []string{"Round", "Set", "Entrant 1", "Entrant 1 Score", "Entrant 2", "Entrant 2 Score", "Winner"})
+	default:
+		rows = append(rows, []string{"round", "match", "p1_name", "p1_discord_id", "p1_score", "p2_name",
+			"p2_discord_id", "p2_score", "winner", "status", "completed_at"})
+	}
+
+	for _, r := range records {
+		p1 := players[r.P1AttendeeID.Int64]
+		p2 := players[r.P2AttendeeID.Int64]
+		winner := players[r.WinnerAttendeeID.Int64]
+		round, number := strconv.Itoa(r.Round), strconv.Itoa(r.Number)
+
+		var p1Score, p2Score, completedAt string
+		if r.Done() {
+			p1Score, p2Score = strconv.Itoa(r.P1Score), strconv.Itoa(r.P2Score)
+		}
+		if r.CompletedAt.Valid {
+			completedAt = time.Unix(r.CompletedAt.Int64, 0).UTC().Format(time.RFC3339)
+		}
+
+		switch profile {
+		case PROFILE_CHALLONGE:
+			var scores string
+			if r.Done() {
+				scores = fmt.Sprintf("%d-%d", r.P1Score, r.P2Score)
+			}
+			rows = append(rows, []string{round, number, p1.Name, p2.Name, scores, winner.Name})
+		case PROFILE_STARTGG:
+			rows = append(rows, []string{round, number, p1.Name, p1Score, p2.Name, p2Score, winner.Name})
+		default:
+			rows = append(rows, []string{round, number, p1.Name, p1.DiscordID, p1Score, p2.Name, p2.DiscordID,
+				p2Score, winner.DiscordID, r.Status, completedAt})
+		}
+	}
+	return rows
+}
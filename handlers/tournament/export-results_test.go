@@ -0,0 +1,78 @@
+package tournament
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/dimfu/spade/models"
+)
+
+var exportPlayers = map[int64]models.Player{
+	1: {Name: "alice", DiscordID: "100"},
+	2: {Name: "bob", DiscordID: "200"},
+}
+
+func TestResultRows(t *testing.T) {
+	standings := []models.Standing{
+		{AttendeeID: 1, Place: 1, Wins: 2, Losses: 0},
+		{AttendeeID: 2, Place: 2, Wins: 1, Losses: 1},
+	}
+
+	cases := []struct {
+		profile  exportProfile
+		expected [][]string
+	}{
+		{PROFILE_CHALLONGE, [][]string{
+			{"Final Rank", "Name", "Wins", "Losses"},
+			{"1", "alice", "2", "0"},
+			{"2", "bob", "1", "1"},
+		}},
+		{PROFILE_STARTGG, [][]string{
+			{"Placement", "GamerTag", "Discord ID"},
+			{"1", "alice", "100"},
+			{"2", "bob", "200"},
+		}},
+	}
+	for _, c := range cases {
+		if got := resultRows(c.profile, standings, exportPlayers); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.profile, c.expected, got)
+		}
+	}
+}
+
+func TestMatchRows(t *testing.T) {
+	records := []models.MatchRecord{
+		{
+			Round: 1, Number: 1, Status: models.MATCH_COMPLETED, P1Score: 1, P2Score: 2,
+			P1AttendeeID:     sql.NullInt64{Int64: 1, Valid: true},
+			P2AttendeeID:     sql.NullInt64{Int64: 2, Valid: true},
+			WinnerAttendeeID: sql.NullInt64{Int64: 2, Valid: true},
+		},
+		{
+			Round: 2, Number: 2, Status: models.MATCH_PENDING,
+			P1AttendeeID: sql.NullInt64{Int64: 2, Valid: true},
+		},
+	}
+
+	cases := []struct {
+		profile  exportProfile
+		expected [][]string
+	}{
+		{PROFILE_CHALLONGE, [][]string{
+			{"Round", "Match", "Player 1", "Player 2", "Scores", "Winner"},
+			{"1", "1", "alice", "bob", "1-2", "bob"},
+			{"2", "2", "bob", "", "", ""},
+		}},
+		{PROFILE_STARTGG, [][]string{
+			{"Round", "Set", "Entrant 1", "Entrant 1 Score", "Entrant 2", "Entrant 2 Score", "Winner"},
+			{"1", "1", "alice", "1", "bob", "2", "bob"},
+			{"2", "2", "bob", "", "", "", ""},
+		}},
+	}
+	for _, c := range cases {
+		if got := matchRows(c.profile, records, exportPlayers); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.profile, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package tournament
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/bracket"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+	"github.com/google/uuid"
+)
+
+const (
+	maxParticipantsSize = 256 * 1024
+	// how long a dry run can be confirmed for
+	pendingImportTTL = 10 * time.Minute
+	// rows with errors listed in the dry run summary
+	maxListedRowErrors = 15
+)
+
+var errImportStarted = i18n.NewError("import.started")
+
+type ImportComponentHandler struct {
+	Base *base.BaseAdmin
+}
+
+// pendingImport is a dry run waiting to be confirmed, only rows without errors are kept. The dry run
+// is ephemeral so only whoever ran the import gets to confirm it.
+type pendingImport struct {
+	tournamentID string
+	applySeats   bool
+	rows         []models.ParticipantRow
+	expiresAt    time.Time
+}
+
+var pendingImports = struct {
+	sync.Mutex
+	imports map[string]*pendingImport
+}{imports: make(map[string]*pendingImport)}
+
+func storePendingImport(p *pendingImport) string {
+	pendingImports.Lock()
+	defer pendingImports.Unlock()
+
+	now := time.Now()
+	for token, pending := range pendingImports.imports {
+		if now.After(pending.expiresAt) {
+			delete(pendingImports.imports, token)
+		}
+	}
+
+	token := uuid.New().String()
+	pendingImports.imports[token] = p
+	return token
+}
+
+func takePendingImport(token string) (*pendingImport, bool) {
+	pendingImports.Lock()
+	defer pendingImports.Unlock()
+
+	p, ok := pendingImports.imports[token]
+	if !ok {
+		return nil, false
+	}
+	delete(pendingImports.imports, token)
+	return p, time.Now().Before(p.expiresAt)
+}
+
+// participants validates the uploaded participants CSV and responds with a dry run that has to be confirmed
+func (h *ImportHandler) participants(s *discordgo.Session, i *discordgo.InteractionCreate,
+	opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	if err != nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+		return
+	}
+
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	if t.Started_At.Valid {
+		base.RespondError(errImportStarted, s, i)
+		return
+	}
+
+	if t.IsTeam() {
+		base.Reply("import.team_tournament", s, i, true)
+		return
+	}
+
+	var att *discordgo.MessageAttachment
+	applySeats := true
+	for _, opt := range opts {
+		switch opt.Name {
+		case "file":
+			att = i.ApplicationCommandData().Resolved.Attachments[opt.Value.(string)]
+		case "apply_seats":
+			applySeats = opt.BoolValue()
+		}
+	}
+
+	// downloading the file can take longer than discord waits for a response
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+
+	l := base.Locale(i)
+	followup := func(content string) {
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	if att == nil {
+		followup(i18n.T(l, "import.attach_csv"))
+		return
+	}
+
+	raw, err := base.ReadAttachment(att, maxParticipantsSize)
+	if err != nil {
+		followup(i18n.Text(l, err))
+		return
+	}
+
+	var validSeats map[int]bool
+	if applySeats {
+		size, _ := strconv.Atoi(t.TournamentType.Size)
+		bt, err := bracket.GenerateFromTemplate(size)
+		if err != nil {
+			followup(base.ERR_GENERATE_BRACKET.In(l))
+			return
+		}
+		validSeats = make(map[int]bool, len(bt.StartingSeats))
+		for _, seat := range bt.StartingSeats {
+			validSeats[seat] = true
+		}
+	}
+
+	rows, err := models.ParseParticipants(bytes.NewReader(raw), validSeats)
+	if err != nil {
+		followup(i18n.Text(l, err))
+		return
+	}
+
+	attendees, err := models.NewAttendeeModel(db).List(string(t.ID), false)
+	if err != nil {
+		log.Println(err)
+		followup(base.ERR_INTERNAL_ERROR.In(l))
+		return
+	}
+
+	registered := make(map[string]bool, len(attendees))
+	seatedBy := make(map[int]models.Attendee)
+	for _, a := range attendees {
+		registered[a.Player.DiscordID] = true
+		if a.CurrentSeat.Valid {
+			seatedBy[int(a.CurrentSeat.Int64)] = a
+		}
+	}
+
+	var (
+		valid                          []models.ParticipantRow
+		rowErrors                      []string
+		newCount, existingCount, seats int
+	)
+	for _, row := range rows {
+		if row.Err == nil && row.Seat != 0 {
+			if a, ok := seatedBy[row.Seat]; ok && a.Player.DiscordID != row.DiscordID {
+				row.Err = i18n.NewError("import.seat_taken", row.Seat, a.Player.Name)
+			}
+		}
+		if row.Err != nil {
+			rowErrors = append(rowErrors, i18n.T(l, "import.row_error", row.Line, row.Err.In(l)))
+			continue
+		}
+
+		valid = append(valid, row)
+		if registered[row.DiscordID] {
+			existingCount++
+		} else {
+			newCount++
+		}
+		if row.Seat != 0 {
+			seats++
+		}
+	}
+
+	var summary strings.Builder
+	fmt.Fprintln(&summary, i18n.T(l, "import.dry_run", att.Filename))
+	fmt.Fprintln(&summary, strings.Join([]string{
+		i18n.N(l, "import.new_attendees", newCount),
+		i18n.N(l, "import.existing_attendees", existingCount),
+		i18n.N(l, "import.seats_to_apply", seats),
+		i18n.N(l, "import.rows_with_errors", len(rowErrors)),
+	}, ", "))
+	for idx, e := range rowErrors {
+		if idx == maxListedRowErrors {
+			fmt.Fprintln(&summary, i18n.T(l, "common.and_more", len(rowErrors)-idx))
+			break
+		}
+		fmt.Fprintf(&summary, "- %s\n", e)
+	}
+
+	if len(valid) == 0 {
+		summary.WriteString(i18n.T(l, "import.nothing"))
+		followup(summary.String())
+		return
+	}
+	if len(attendees)+newCount > t.Cap() {
+		summary.WriteString(i18n.N(l, "import.over_cap", t.Cap(), len(attendees)))
+		followup(summary.String())
+		return
+	}
+	if len(rowErrors) > 0 {
+		summary.WriteString(i18n.T(l, "import.errors_skipped"))
+	}
+
+	token := storePendingImport(&pendingImport{
+		tournamentID: string(t.ID),
+		applySeats:   applySeats,
+		rows:         valid,
+		expiresAt:    time.Now().Add(pendingImportTTL),
+	})
+
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: summary.String(),
+		Flags:   discordgo.MessageFlagsEphemeral,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(l, "common.confirm"),
+						Style:    discordgo.SuccessButton,
+						CustomID: router.ID(ROUTE_IMPORT_CONFIRM, token),
+					},
+					discordgo.Button{
+						Label:    i18n.T(l, "common.cancel"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: router.ID(ROUTE_IMPORT_CANCEL, token),
+					},
+				},
+			},
+		},
+	})
+}
+
+const (
+	ROUTE_IMPORT_CONFIRM = "import.confirm"
+	ROUTE_IMPORT_CANCEL  = "import.cancel"
+)
+
+func (h *ImportComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_IMPORT_CONFIRM, Args: 1, Handler: h.Handler},
+		{Name: ROUTE_IMPORT_CANCEL, Args: 1, Handler: h.Handler},
+	}
+}
+
+func (h *ImportComponentHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	// the dry run token is the only argument of both routes
+	token := cid.Arg(0)
+	l := base.Locale(i)
+
+	update := func(content string) {
+		router.Respond(s, i, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	}
+
+	p, ok := takePendingImport(token)
+	if !ok {
+		update(i18n.T(l, "import.expired"))
+		return
+	}
+
+	switch cid.Route {
+	case ROUTE_IMPORT_CANCEL:
+		update(i18n.T(l, "import.cancelled"))
+	case ROUTE_IMPORT_CONFIRM:
+		t, err := models.NewTournamentsModel(database.GetDB()).GetById(p.tournamentID)
+		if err != nil {
+			base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+			return
+		}
+		if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+
+		am := models.NewAttendeeModel(database.GetDB())
+		before, err := am.List(p.tournamentID, true)
+		if err != nil {
+			log.Println(err)
+		}
+		added, seated, err := importParticipants(p)
+		if err != nil {
+			log.Printf("error importing participants of %s: %v", p.tournamentID, err)
+			update(i18n.Text(l, err))
+			return
+		}
+		after, err := am.List(p.tournamentID, true)
+		if err != nil {
+			log.Println(err)
+		}
+		base.Audit(i, models.AUDIT_IMPORT, p.tournamentID, models.SnapshotSeats(before), models.SnapshotSeats(after))
+		refreshSignup(s, database.GetDB(), p.tournamentID)
+		update(i18n.N(l, "import.imported", added, seated))
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+// importParticipants registers the rows of a confirmed dry run, it returns how many attendees were
+// added and how many seats were applied
+func importParticipants(p *pendingImport) (int, int, error) {
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+	pm := models.NewPlayerModel(db)
+	am := models.NewAttendeeModel(db)
+
+	t, err := tm.GetById(p.tournamentID)
+	if err != nil {
+		return 0, 0, base.ERR_GET_TOURNAMENT
+	}
+	if t.Started_At.Valid {
+		return 0, 0, errImportStarted
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	// the cap is checked again, players may have registered since the dry run
+	taken, err := am.Taken(tx, p.tournamentID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var added, seated int
+	for _, row := range p.rows {
+		player, err := pm.FindByDiscordId(row.DiscordID)
+		if err == sql.ErrNoRows {
+			player = &models.Player{
+				ID:        []uint8(uuid.New().String()),
+				Name:      row.Name,
+				DiscordID: row.DiscordID,
+			}
+			err = pm.Insert(tx, player)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		attendee, err := am.FindById(p.tournamentID, string(player.ID))
+		if err == sql.ErrNoRows {
+			attendee = &models.Attendee{TournamentID: p.tournamentID, PlayerID: string(player.ID)}
+			if err = am.Insert(tx, attendee); err == nil {
+				added++
+			}
+		} else if err == nil && attendee.WaitlistedAt.Valid {
+			// the import is counted against the cap as if the waitlisted player was new
+			if err = am.Promote(tx, attendee.Id); err == nil {
+				added++
+			}
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if p.applySeats && row.Seat != 0 {
+			if err := am.SeatTx(tx, attendee.Id, row.Seat); err != nil {
+				return 0, 0, err
+			}
+			seated++
+		}
+	}
+
+	if cap := t.Cap(); taken+added > cap {
+		return 0, 0, i18n.NewCountError("import.over_cap", cap, cap, taken)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return added, seated, nil
+}
@@ -0,0 +1,258 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/bracket"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+// ROUTE_MATCH_CHECK_IN custom id is built with the id of the match record
+const ROUTE_MATCH_CHECK_IN = "match.checkin"
+
+const (
+	// matchThreadArchiveMinutes is how long an idle match thread stays open, results archive it sooner
+	matchThreadArchiveMinutes = 1440
+	maxThreadName             = 100
+)
+
+type MatchComponentHandler struct {
+	db *sql.DB
+}
+
+func (h *MatchComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_MATCH_CHECK_IN, Args: 1, Handler: h.checkIn},
+	}
+}
+
+// matchPlayers returns the discord ids of everyone playing the match, every roster member in team
+// tournaments
+func matchPlayers(db *sql.DB, m models.Match) []string {
+	var players []string
+	for _, node := range []*bracket.Node{m.P1, m.P2} {
+		if a, ok := nodeAttendee(node); ok {
+			players = append(players, sidePlayers(db, a.Id)...)
+		}
+	}
+	return players
+}
+
+// openMatchThread creates the private thread the match is played in and adds its players and
+// referees, managers are pulled in by mentioning their role. An empty id is returned when the thread
+// can't be created so the match is posted in the tournament thread instead.
+func openMatchThread(s *discordgo.Session, db *sql.DB, t *models.Tournament, m models.Match, matchCount int,
+	referee string) string {
+	// threads can't be nested, the match thread is opened next to the tournament thread
+	parent, err := s.Channel(t.Thread_ID.String)
+	if err != nil {
+		log.Printf("error getting thread of tournament %s: %v", t.ID, err)
+		return ""
+	}
+
+	locale := base.GuildLocale(t.Guild_ID.String)
+	p1, p2 := matchupNames(locale, m)
+	name := []rune(i18n.T(locale, "match.thread_name", matchCount, p1, p2))
+	if len(name) > maxThreadName {
+		name = name[:maxThreadName]
+	}
+	thread, err := s.ThreadStartComplex(parent.ParentID, &discordgo.ThreadStart{
+		Name:                string(name),
+		Type:                discordgo.ChannelTypeGuildPrivateThread,
+		AutoArchiveDuration: matchThreadArchiveMinutes,
+		Invitable:           false,
+	})
+	if err != nil {
+		log.Printf("error opening thread of match %d: %v", m.ID, err)
+		return ""
+	}
+
+	members := matchPlayers(db, m)
+	referees, err := tournamentReferees(db, string(t.ID))
+	if err != nil {
+		log.Printf("error listing referees of tournament %s: %v", t.ID, err)
+	}
+	members = append(members, referees...)
+	if referee != "" {
+		members = append(members, referee)
+	}
+
+	added := make(map[string]bool, len(members))
+	mentions := make([]string, 0, len(members)+1)
+	for _, userID := range members {
+		if added[userID] {
+			continue
+		}
+		added[userID] = true
+		if err := s.ThreadMemberAdd(thread.ID, userID); err != nil {
+			log.Printf("error adding %s to the thread of match %d: %v", userID, m.ID, err)
+			continue
+		}
+		mentions = append(mentions, fmt.Sprintf("<@%s>", userID))
+	}
+
+	allowed := &discordgo.MessageAllowedMentions{}
+	if role, err := base.ManagerRole(s, t.Guild_ID.String); err == nil {
+		mentions = append(mentions, role.Mention())
+		allowed.Roles = []string{role.ID}
+	}
+
+	_, err = s.ChannelMessageSendComplex(thread.ID, &discordgo.MessageSend{
+		Content:         i18n.T(locale, "match.thread_greeting", strings.Join(mentions, " ")),
+		AllowedMentions: allowed,
+	})
+	if err != nil {
+		log.Printf("error greeting the thread of match %d: %v", m.ID, err)
+	}
+
+	_, err = s.ChannelMessageSend(t.Thread_ID.String, i18n.T(locale, "match.thread_opened", matchCount, p1, p2, thread.ID))
+	if err != nil {
+		log.Printf("error announcing the thread of match %d: %v", m.ID, err)
+	}
+
+	return thread.ID
+}
+
+// closeMatchThread posts the result in the tournament thread and archives the thread of the match,
+// matches posted in the tournament thread are left alone
+func closeMatchThread(s *discordgo.Session, t *models.Tournament, channelID, winner, loser string, matchCount int) {
+	if channelID == "" || channelID == t.Thread_ID.String {
+		return
+	}
+
+	_, err := s.ChannelMessageSend(t.Thread_ID.String, i18n.T(base.GuildLocale(t.Guild_ID.String),
+		"match.thread_result", matchCount, winner, loser, channelID))
+	if err != nil {
+		log.Printf("error posting the result of match thread %s: %v", channelID, err)
+	}
+
+	archived, locked := true, true
+	if _, err := s.ChannelEditComplex(channelID, &discordgo.ChannelEdit{Archived: &archived, Locked: &locked}); err != nil {
+		log.Printf("error archiving match thread %s: %v", channelID, err)
+	}
+}
+
+// matchupNames returns how both participants of the match are shown
+func matchupNames(l i18n.Locale, m models.Match) (string, string) {
+	na := i18n.T(l, "matchup.not_available")
+	names := [2]string{na, na}
+	for idx, node := range []*bracket.Node{m.P1, m.P2} {
+		if node == nil {
+			continue
+		}
+		if a, ok := node.Payload.(models.AttendeeWithResult); ok {
+			names[idx] = a.Name()
+		}
+	}
+	return names[0], names[1]
+}
+
+// matchSide returns which side of the match the member plays, 0 when the member does not play it.
+// Any roster member checks their team in.
+func matchSide(db *sql.DB, record *models.MatchRecord, discordID string) (int, error) {
+	player, err := models.NewPlayerModel(db).FindByDiscordId(discordID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	teamModel := models.NewTeamModel(db)
+	if team, err := teamModel.FindByPlayer(record.TournamentID, string(player.ID)); err == nil {
+		for side, attendeeID := range []sql.NullInt64{record.P1AttendeeID, record.P2AttendeeID} {
+			if !attendeeID.Valid {
+				continue
+			}
+			if opponent, err := teamModel.FindByAttendee(int(attendeeID.Int64)); err == nil && opponent.ID == team.ID {
+				return side + 1, nil
+			}
+		}
+		return 0, nil
+	}
+
+	attendee, err := models.NewAttendeeModel(db).FindById(record.TournamentID, string(player.ID))
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return record.Side(attendee.Id), nil
+}
+
+func (h *MatchComponentHandler) checkIn(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	h.db = database.GetDB()
+	mm := models.NewMatchModel(h.db)
+
+	matchID, err := cid.Int(0)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	record, err := mm.GetById(matchID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if record.Done() {
+		base.Reply("match.already_over", s, i, true)
+		return
+	}
+
+	side, err := matchSide(h.db, record, base.Actor(i).ID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if side == 0 {
+		base.Reply("match.check_in_players_only", s, i, true)
+		return
+	}
+	if (side == 1 && record.P1CheckedInAt.Valid) || (side == 2 && record.P2CheckedInAt.Valid) {
+		base.Reply("match.already_checked_in", s, i, true)
+		return
+	}
+
+	if err := mm.CheckIn(record.ID, side); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if record, err = mm.GetById(matchID); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	p1Ready, p2Ready := record.P1CheckedInAt.Valid, record.P2CheckedInAt.Valid
+	embeds := i.Message.Embeds
+	if len(embeds) > 0 {
+		components.SetCheckIn(base.GuildLocale(i.GuildID), embeds[0], p1Ready, p2Ready)
+	}
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{Embeds: embeds},
+	})
+
+	locale := base.GuildLocale(i.GuildID)
+	notifyOpponentCheckedIn(s, h.db, locale, record, side, i.ChannelID)
+
+	if p1Ready && p2Ready {
+		msg := i18n.T(locale, "match.both_ready")
+		if record.RefereeID.Valid {
+			msg = i18n.T(locale, "match.both_ready_referee", record.RefereeID.String)
+		}
+		if _, err := s.ChannelMessageSend(i.ChannelID, msg); err != nil {
+			log.Println(err)
+		}
+	}
+}
@@ -3,114 +3,126 @@ package tournament
 import (
 	"database/sql"
 	"log"
-	"strings"
+	"strconv"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
 )
 
 type TournamentModalHandler struct {
+	Base *base.BaseAdmin
 }
 
-func (h *TournamentModalHandler) Name() string {
-	return "modals-tournament"
+// ROUTE_TOURNAMENT_EDIT_FORM is the modal opened by ROUTE_TOURNAMENT_EDIT
+const ROUTE_TOURNAMENT_EDIT_FORM = "tournament.editform"
+
+func (h *TournamentModalHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_TOURNAMENT_EDIT_FORM, Args: 1, Handler: h.edit},
+	}
 }
 
-func (h *TournamentModalHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *TournamentModalHandler) edit(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
 	// acknowledge modal is submitted so it wont hang
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	router.Respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	})
 
 	db := database.GetDB()
 	tm := models.NewTournamentsModel(db)
 	data := i.ModalSubmitData()
+	id := cid.Arg(0)
 
-	// first index is the main component name, second is type of action, third is the unique id
-	splitcid := strings.Split(data.CustomID, "_")
-	action := splitcid[1]
-	id := splitcid[2]
+	t, err := tm.GetById(id)
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
 
-	// TODO: add some kind of gate that prevent the non tournament owner to edit/delete
-	switch action {
-	case "edit":
-		t, err := tm.GetById(id)
-		if err != nil {
-			base.Respond(err.Error(), s, i, true)
-			return
-		}
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: i18n.Text(base.Locale(i), err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
 
-		t.Name = data.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-		description := data.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-		rules := data.Components[2].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	before := models.SnapshotTournament(t)
+	t.Name = data.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	description := data.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	bestOf := data.Components[2].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	rules := data.Components[3].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	startingAt := data.Components[4].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
 
-		fields := []*discordgo.MessageEmbedField{
-			{Name: "Name", Value: t.Name},
-		}
+	t.Description = sql.NullString{
+		String: description,
+		Valid:  len(description) != 0,
+	}
 
-		t.Description = sql.NullString{
-			String: description,
-			Valid:  len(description) != 0,
-		}
-		if len(description) > 0 {
-			fields = append(fields, &discordgo.MessageEmbedField{
-				Name:  "Description",
-				Value: t.Description.String,
-			})
-		}
+	t.Rules = sql.NullString{
+		String: rules,
+		Valid:  len(rules) != 0,
+	}
 
-		t.Rules = sql.NullString{
-			String: rules,
-			Valid:  len(rules) != 0,
-		}
-		if len(rules) > 0 {
-			fields = append(fields, &discordgo.MessageEmbedField{
-				Name:  "Rules",
-				Value: t.Rules.String,
+	// best of has to be an odd number so a match can't end in a tie
+	bo, err := strconv.Atoi(bestOf)
+	if err != nil || bo < 1 || bo%2 == 0 {
+		s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: i18n.T(base.Locale(i), "tournament.best_of_odd"),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+	t.Best_Of = bo
+
+	// the start time can no longer be planned once the tournament is started
+	loc := base.Settings(i.GuildID).Location()
+	if !t.Started_At.Valid && startingAt != formatStartingAt(t, loc) {
+		at, err := parseStartingAt(startingAt, loc)
+		if err != nil {
+			s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+				Content: i18n.Text(base.Locale(i), err),
+				Flags:   discordgo.MessageFlagsEphemeral,
 			})
+			return
 		}
+		t.Starting_At = at
+	}
 
-		fields = append(fields,
-			&discordgo.MessageEmbedField{Name: "Best Of", Value: "1"},
-			&discordgo.MessageEmbedField{Name: "Player Cap", Value: t.TournamentType.Size},
-			&discordgo.MessageEmbedField{Name: "Bracket Type", Value: "Single Elimination"},
-		)
+	if err := tm.Update(t); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+	base.Audit(i, models.AUDIT_EDIT, id, before, models.SnapshotTournament(t))
+	if err := scheduleStart(t); err != nil {
+		log.Printf("error scheduling the start of tournament %s: %v", id, err)
+	}
 
-		if err := tm.Update(t); err != nil {
-			base.Respond(err.Error(), s, i, true)
-			return
-		}
+	editEmbed := func(chId, msgId string) {
+		s.ChannelMessageEditEmbed(chId, msgId, components.Paint(components.ConfigurationEmbed(base.GuildLocale(i.GuildID), t), base.Settings(i.GuildID).Color()))
+	}
 
-		editEmbed := func(chId, msgId string) {
-			s.ChannelMessageEditEmbed(chId, msgId, &discordgo.MessageEmbed{
-				Title:       "Configuration",
-				Description: "Available configuration for your tournament",
-				Fields:      fields,
-				Footer: &discordgo.MessageEmbedFooter{
-					Text: string(t.ID),
-				},
-			})
+	// update tournament embed inside the published channel
+	if t.Published {
+		msgs, err := s.ChannelMessagesPinned(t.Thread_ID.String)
+		if err != nil {
+			log.Println(err.Error())
+			return
 		}
-
-		// update tournament embed inside the published channel
-		if t.Published {
-			msgs, err := s.ChannelMessagesPinned(t.Thread_ID.String)
-			if err != nil {
-				log.Println(err.Error())
-				return
+		var embedID string
+		for _, msg := range msgs {
+			if msg.Author.ID == s.State.User.ID && len(msg.Embeds) > 0 {
+				embedID = msg.ID
+				break
 			}
-			var embedID string
-			for _, msg := range msgs {
-				if msg.Author.ID == s.State.User.ID && len(msg.Embeds) > 0 {
-					embedID = msg.ID
-					break
-				}
-			}
-			editEmbed(t.Thread_ID.String, embedID)
 		}
-
-		editEmbed(i.ChannelID, i.Message.ID)
+		editEmbed(t.Thread_ID.String, embedID)
 	}
+
+	editEmbed(i.ChannelID, i.Message.ID)
 }
@@ -0,0 +1,109 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/bracket"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/handlers/queue"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+// sidePlayers returns the discord ids of everyone playing for the attendee
+func sidePlayers(db *sql.DB, attendeeID int) []string {
+	players, err := models.NewAttendeeModel(db).DiscordIDs(attendeeID)
+	if err != nil {
+		log.Printf("error listing players of attendee %d: %v", attendeeID, err)
+	}
+	return players
+}
+
+// nodeAttendee returns the attendee sitting on the bracket node, if any
+func nodeAttendee(node *bracket.Node) (models.AttendeeWithResult, bool) {
+	if node == nil {
+		return models.AttendeeWithResult{}, false
+	}
+	a, ok := node.Payload.(models.AttendeeWithResult)
+	return a, ok
+}
+
+func messageLink(guildID, channelID, messageID string) string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, channelID, messageID)
+}
+
+// notifyMatchReady tells both sides of the match that it has been posted, walkovers are not announced
+func notifyMatchReady(s *discordgo.Session, db *sql.DB, t *models.Tournament, m models.Match, matchCount int,
+	msg *discordgo.Message) {
+	p1, ok1 := nodeAttendee(m.P1)
+	p2, ok2 := nodeAttendee(m.P2)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	link := messageLink(t.Guild_ID.String, msg.ChannelID, msg.ID)
+	locale := base.GuildLocale(t.Guild_ID.String)
+	for _, side := range [][2]models.AttendeeWithResult{{p1, p2}, {p2, p1}} {
+		content := i18n.T(locale, "notification.match_ready_message", matchCount, side[1].Name(), t.Name, link)
+		base.Notify(s, models.NOTIFY_MATCH_READY, sidePlayers(db, side[0].Id), msg.ChannelID, content)
+	}
+}
+
+// notifyOpponentCheckedIn tells the other side of the match that the given side is ready
+func notifyOpponentCheckedIn(s *discordgo.Session, db *sql.DB, locale i18n.Locale, record *models.MatchRecord, side int,
+	channelID string) {
+	opponent := record.P2AttendeeID
+	if side == 2 {
+		opponent = record.P1AttendeeID
+	}
+	if !opponent.Valid {
+		return
+	}
+
+	content := i18n.T(locale, "notification.opponent_checked_in_message", channelID)
+	base.Notify(s, models.NOTIFY_OPPONENT_CHECKED_IN, sidePlayers(db, int(opponent.Int64)), channelID, content)
+}
+
+// notifyResult tells the winner they advanced, or won the tournament after the final, and the loser
+// they were eliminated. Match threads are archived once the result is in so the fallback mention is
+// posted in the tournament thread.
+func notifyResult(s *discordgo.Session, db *sql.DB, t *models.Tournament, result *queue.MatchResult, final bool) {
+	if result.Winner == nil || result.Loser == nil {
+		return
+	}
+
+	locale := base.GuildLocale(t.Guild_ID.String)
+	won := i18n.T(locale, "notification.advanced_message", result.Loser.Name(), t.Name)
+	if final {
+		won = i18n.T(locale, "notification.won_message", result.Loser.Name(), t.Name)
+	}
+	base.Notify(s, models.NOTIFY_RESULTS, sidePlayers(db, result.Winner.Id), t.Thread_ID.String, won)
+
+	lost := i18n.T(locale, "notification.eliminated_message", result.Winner.Name(), t.Name)
+	base.Notify(s, models.NOTIFY_RESULTS, sidePlayers(db, result.Loser.Id), t.Thread_ID.String, lost)
+}
+
+// notifyStartingSoon tells every attendee the tournament is about to start
+func notifyStartingSoon(s *discordgo.Session, db *sql.DB, t *models.Tournament) error {
+	attendees, err := models.NewAttendeeModel(db).List(string(t.ID), false)
+	if err != nil {
+		return err
+	}
+
+	players := []string{}
+	for _, a := range attendees {
+		if a.TeamID.Valid {
+			players = append(players, sidePlayers(db, a.Id)...)
+			continue
+		}
+		players = append(players, a.Player.DiscordID)
+	}
+
+	content := i18n.T(base.GuildLocale(t.Guild_ID.String), "notification.starting_soon_message", t.Name,
+		t.Starting_At.Int64, t.Thread_ID.String)
+	base.Notify(s, models.NOTIFY_STARTING_SOON, players, t.Thread_ID.String, content)
+	return nil
+}
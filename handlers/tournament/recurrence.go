@@ -0,0 +1,365 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/scheduler"
+	"github.com/google/uuid"
+)
+
+const JOB_RECURRENCE = "recurrence"
+
+var (
+	minOpenBeforeHours = float64(1)
+	// an edition has to be created before the previous occurrence of the same weekday passes
+	maxOpenBeforeHours = float64(144)
+)
+
+type RecurrenceHandler struct {
+	Base *base.BaseAdmin
+}
+
+// RecurrenceJob creates, publishes and opens the registration of the next edition of a recurring tournament
+type RecurrenceJob struct{}
+
+func (h *RecurrenceHandler) Command() *discordgo.ApplicationCommand {
+	weekdays := make([]*discordgo.ApplicationCommandOptionChoice, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays = append(weekdays, &discordgo.ApplicationCommandOptionChoice{
+			Name:              d.String(),
+			NameLocalizations: i18n.Localized(weekdayKey(d)),
+			Value:             int(d),
+		})
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "recurrence",
+		Description: "Repeat the current tournament on a weekly schedule",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "set",
+				Description: "Host a new edition of this tournament every week",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "weekday",
+						Description: "Day of the week the tournament is held",
+						Choices:     weekdays,
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "time",
+						Description: "Time the tournament is held in 24 hour HH:MM format",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "IANA time zone such as Asia/Jakarta (default the server time zone)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "open_before_hours",
+						Description: "Hours before the tournament the next edition is published (default 24)",
+						Required:    false,
+						MinValue:    &minOpenBeforeHours,
+						MaxValue:    maxOpenBeforeHours,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "carry_seeding",
+						Description: "Seed the next edition by the results of the previous one",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name_pattern",
+						Description: "Name of the editions, {n} is the edition and {date} the tournament date",
+						Required:    false,
+						MaxLength:   128,
+					},
+				},
+			},
+			{
+				Name:        "show",
+				Description: "Show the schedule of this tournament",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "stop",
+				Description: "Stop creating new editions of this tournament",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *RecurrenceHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+	rm := models.NewRecurrenceModel(db)
+
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	if err != nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+		return
+	}
+
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	subcmd := data.Options[0]
+	switch subcmd.Name {
+	case "set":
+		h.set(s, i, t, subcmd.Options)
+	case "show":
+		r, err := rm.FindByTournament(string(t.ID))
+		if err != nil {
+			base.Reply("recurrence.not_recurring", s, i, true)
+			return
+		}
+		l := base.Locale(i)
+		carry := i18n.T(l, "common.no")
+		if r.CarrySeeding {
+			carry = i18n.T(l, "common.yes")
+		}
+		base.Respond(i18n.N(l, "recurrence.show", r.OpenBeforeHours, i18n.T(l, weekdayKey(r.Weekday)), r.TimeOfDay,
+			r.Timezone, r.Edition, carry), s, i, true)
+	case "stop":
+		r, err := rm.FindByTournament(string(t.ID))
+		if err != nil {
+			base.Reply("recurrence.not_recurring", s, i, true)
+			return
+		}
+		if err := stopRecurrence(r); err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		base.Reply("recurrence.stopped", s, i, false)
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *RecurrenceHandler) set(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament,
+	opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range opts {
+		options[opt.Name] = opt
+	}
+
+	r := &models.Recurrence{
+		GuildID:          i.GuildID,
+		TournamentID:     string(t.ID),
+		LastTournamentID: string(t.ID),
+		NamePattern:      t.Name + " #{n}",
+		Edition:          1,
+		Weekday:          time.Weekday(options["weekday"].IntValue()),
+		TimeOfDay:        options["time"].StringValue(),
+		Timezone:         base.Settings(i.GuildID).Timezone,
+		OpenBeforeHours:  24,
+		Enabled:          true,
+		CreatedBy:        base.Actor(i).ID,
+	}
+
+	if opt, ok := options["timezone"]; ok {
+		r.Timezone = opt.StringValue()
+	}
+	if opt, ok := options["open_before_hours"]; ok {
+		r.OpenBeforeHours = int(opt.IntValue())
+	}
+	if opt, ok := options["carry_seeding"]; ok {
+		r.CarrySeeding = opt.BoolValue()
+	}
+	if opt, ok := options["name_pattern"]; ok {
+		r.NamePattern = opt.StringValue()
+	}
+
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		base.Reply("config.unknown_timezone", s, i, true, r.Timezone)
+		return
+	}
+
+	hour, minute, err := scheduler.ParseTimeOfDay(r.TimeOfDay)
+	if err != nil {
+		base.Reply("recurrence.invalid_time", s, i, true, r.TimeOfDay)
+		return
+	}
+
+	rm := models.NewRecurrenceModel(database.GetDB())
+
+	// only one schedule can be active for the same tournament
+	if prev, err := rm.FindByTournament(string(t.ID)); err == nil {
+		r.Edition = prev.Edition
+		if err := stopRecurrence(prev); err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+	}
+
+	if err := rm.Insert(r); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	// a tournament that has not been started yet is the edition of the upcoming occurrence
+	now := time.Now()
+	next := scheduler.NextOccurrence(now, r.Weekday, hour, minute, loc)
+	if !t.Started_At.Valid {
+		next = scheduler.NextOccurrence(next, r.Weekday, hour, minute, loc)
+	}
+
+	runAt := next.Add(-time.Duration(r.OpenBeforeHours) * time.Hour)
+	if runAt.Before(now) {
+		runAt = now
+	}
+
+	if err := scheduler.Schedule(JOB_RECURRENCE, "", r.ID, runAt); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Reply("recurrence.set", s, i, false, i18n.T(base.GuildLocale(i.GuildID), weekdayKey(r.Weekday)), r.TimeOfDay,
+		r.Timezone, next.Unix(), runAt.Unix())
+}
+
+// weekdayKey is the message key of the name of the day
+func weekdayKey(d time.Weekday) string {
+	return fmt.Sprintf("weekday.%d", d)
+}
+
+func stopRecurrence(r *models.Recurrence) error {
+	db := database.GetDB()
+	if err := models.NewRecurrenceModel(db).Disable(r.ID); err != nil {
+		return err
+	}
+	return models.NewScheduledJobModel(db).CancelByReference(JOB_RECURRENCE, r.ID)
+}
+
+func (j *RecurrenceJob) Kind() string {
+	return JOB_RECURRENCE
+}
+
+func (j *RecurrenceJob) Run(s *discordgo.Session, job *models.ScheduledJob) error {
+	db := database.GetDB()
+	rm := models.NewRecurrenceModel(db)
+	tm := models.NewTournamentsModel(db)
+
+	r, err := rm.GetById(int(job.ReferenceID.Int64))
+	if err != nil {
+		return err
+	}
+	if !r.Enabled {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return err
+	}
+	hour, minute, err := scheduler.ParseTimeOfDay(r.TimeOfDay)
+	if err != nil {
+		return err
+	}
+	occurrence := scheduler.NextOccurrence(time.Now(), r.Weekday, hour, minute, loc)
+
+	prev, err := tm.GetById(r.LastTournamentID)
+	if err != nil {
+		// there is nothing left to copy the configuration from
+		log.Printf("stopping recurrence %d, previous edition is gone: %v", r.ID, err)
+		return rm.Disable(r.ID)
+	}
+
+	// the edition of this occurrence exists already when a previous attempt of this job stopped halfway
+	next := prev
+	if !r.LastOccurrenceAt.Valid || r.LastOccurrenceAt.Int64 != occurrence.Unix() {
+		next = &models.Tournament{
+			ID:                  []uint8(uuid.New().String()),
+			Name:                r.TournamentName(r.Edition+1, occurrence),
+			Description:         prev.Description,
+			Rules:               prev.Rules,
+			Tournament_Types_ID: prev.Tournament_Types_ID,
+			TournamentType:      prev.TournamentType,
+			Created_At:          strconv.FormatInt(time.Now().Unix(), 10),
+			Guild_ID:            prev.Guild_ID,
+			Best_Of:             prev.Best_Of,
+			Self_Register:       prev.Self_Register,
+			Template_ID:         prev.Template_ID,
+			Game:                prev.Game,
+			Team_Size:           prev.Team_Size,
+			Roster_Limit:        prev.Roster_Limit,
+			Check_In_Minutes:    prev.Check_In_Minutes,
+			Owner_ID:            prev.Owner_ID,
+			Auto_Referee:        prev.Auto_Referee,
+			Match_Threads:       prev.Match_Threads,
+			Starting_At:         sql.NullInt64{Int64: occurrence.Unix(), Valid: true},
+			Registration_Open:   true,
+		}
+		if r.CarrySeeding {
+			next.Seed_From = sql.NullString{String: string(prev.ID), Valid: true}
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := tm.InsertTx(tx, next); err != nil {
+			return err
+		}
+		if err := rm.NextEdition(tx, r.ID, string(next.ID), occurrence.Unix()); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	if !next.Published {
+		thread, err := publishTournament(s, tm, next)
+		if err != nil {
+			return err
+		}
+
+		if err := scheduleStart(next); err != nil {
+			log.Printf("error scheduling the start of tournament %s: %v", next.ID, err)
+		}
+
+		_, err = s.ChannelMessageSend(thread.ID, i18n.T(base.GuildLocale(next.Guild_ID.String), "recurrence.opened",
+			occurrence.Unix()))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	following := scheduler.NextOccurrence(occurrence, r.Weekday, hour, minute, loc)
+	return scheduler.Schedule(JOB_RECURRENCE, "", r.ID, following.Add(-time.Duration(r.OpenBeforeHours)*time.Hour))
+}
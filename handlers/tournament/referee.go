@@ -0,0 +1,235 @@
+package tournament
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+)
+
+var minMatchNumber = float64(1)
+
+type RefereeHandler struct {
+	Base *base.BaseAdmin
+	db   *sql.DB
+}
+
+func (h *RefereeHandler) Command() *discordgo.ApplicationCommand {
+	matchOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        "match",
+		Description: "Match number as shown on the match embed",
+		Required:    true,
+		MinValue:    &minMatchNumber,
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "referee",
+		Description: "Assign referees to the matches of the current tournament",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "assign",
+				Description: "Make a member the referee of a match, only they and managers can report its result",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					matchOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "member",
+						Description: "Referee of the match",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "clear",
+				Description: "Remove the referee of a match",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{matchOption},
+			},
+			{
+				Name:        "rotate",
+				Description: "Rotate the tournament referees across live matches as they are posted",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether new matches get a referee automatically",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h *RefereeHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.db = database.GetDB()
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	tm := models.NewTournamentsModel(h.db)
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	if err != nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+		return
+	}
+
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	subcmd := data.Options[0]
+	if subcmd.Name == "rotate" {
+		enabled := subcmd.Options[0].BoolValue()
+		if err := tm.SetAutoReferee(string(t.ID), enabled); err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		base.Audit(i, models.AUDIT_REFEREE, string(t.ID), map[string]bool{"rotate": t.Auto_Referee},
+			map[string]bool{"rotate": enabled})
+
+		key := "referee.rotate_off"
+		if enabled {
+			key = "referee.rotate_on"
+		}
+		base.Reply(key, s, i, true)
+		return
+	}
+
+	mm := models.NewMatchModel(h.db)
+	record, err := mm.FindByNumber(string(t.ID), int(subcmd.Options[0].IntValue()))
+	if err == sql.ErrNoRows {
+		base.Reply("referee.match_not_found", s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if record.Done() {
+		base.Reply("referee.match_played", s, i, true)
+		return
+	}
+
+	var referee sql.NullString
+	switch subcmd.Name {
+	case "assign":
+		referee = sql.NullString{String: subcmd.Options[1].UserValue(nil).ID, Valid: true}
+	case "clear":
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+		return
+	}
+
+	if err := mm.AssignReferee(record.ID, referee); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	base.Audit(i, models.AUDIT_REFEREE, string(t.ID),
+		map[string]interface{}{"match": record.Number, "referee": record.RefereeID.String},
+		map[string]interface{}{"match": record.Number, "referee": referee.String})
+	showReferee(s, base.GuildLocale(i.GuildID), record, referee.String)
+
+	// a match played in its own thread is only visible to the members of the thread
+	if referee.Valid && record.ChannelID.Valid && record.ChannelID.String != t.Thread_ID.String {
+		if err := s.ThreadMemberAdd(record.ChannelID.String, referee.String); err != nil {
+			log.Printf("error adding referee %s to the thread of match %d: %v", referee.String, record.ID, err)
+		}
+	}
+
+	if referee.Valid {
+		base.Reply("referee.assigned", s, i, true, referee.String, record.Number)
+		return
+	}
+	base.Reply("referee.cleared", s, i, true, record.Number)
+}
+
+// tournamentReferees are the members that were made referee of the tournament, in the order they were added
+func tournamentReferees(db *sql.DB, tournamentID string) ([]string, error) {
+	staff, err := models.NewPermissionModel(db).Staff(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	referees := make([]string, 0, len(staff))
+	for _, st := range staff {
+		if st.Role == models.ROLE_REFEREE {
+			referees = append(referees, st.UserID)
+		}
+	}
+	return referees, nil
+}
+
+// matchReferee returns the referee of the match about to be posted, matches without one get the least
+// busy tournament referee when rotation is enabled. Errors are only logged so the match is still posted.
+func matchReferee(db *sql.DB, t *models.Tournament, matchID int) string {
+	mm := models.NewMatchModel(db)
+	record, err := mm.GetById(matchID)
+	if err != nil {
+		log.Printf("error getting referee of match %d: %v", matchID, err)
+		return ""
+	}
+	if record.RefereeID.Valid || !t.Auto_Referee {
+		return record.RefereeID.String
+	}
+
+	referees, err := tournamentReferees(db, string(t.ID))
+	if err != nil {
+		log.Printf("error listing referees of tournament %s: %v", t.ID, err)
+		return ""
+	}
+	load, err := mm.RefereeLoad(string(t.ID))
+	if err != nil {
+		log.Printf("error counting referee matches of tournament %s: %v", t.ID, err)
+		return ""
+	}
+
+	referee := models.PickReferee(referees, load)
+	if referee == "" {
+		return ""
+	}
+	if err := mm.AssignReferee(matchID, sql.NullString{String: referee, Valid: true}); err != nil {
+		log.Printf("error assigning referee of match %d: %v", matchID, err)
+		return ""
+	}
+	return referee
+}
+
+// showReferee updates the referee on the match embed when the match has already been posted
+func showReferee(s *discordgo.Session, locale i18n.Locale, record *models.MatchRecord, refereeID string) {
+	if !record.ChannelID.Valid || !record.MessageID.Valid {
+		return
+	}
+
+	msg, err := s.ChannelMessage(record.ChannelID.String, record.MessageID.String)
+	if err != nil {
+		log.Printf("error getting the message of match %d: %v", record.ID, err)
+		return
+	}
+	if len(msg.Embeds) == 0 {
+		return
+	}
+
+	embed := components.SetReferee(locale, msg.Embeds[0], refereeID)
+	if _, err := s.ChannelMessageEditEmbed(record.ChannelID.String, record.MessageID.String, embed); err != nil {
+		log.Printf("error showing the referee of match %d: %v", record.ID, err)
+	}
+}
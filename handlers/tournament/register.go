@@ -2,7 +2,6 @@ package tournament
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -11,6 +10,7 @@ import (
 	"github.com/dimfu/spade/config"
 	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
 	"github.com/google/uuid"
 )
@@ -29,10 +29,11 @@ func (h *TournamentRegisterHandler) Command() *discordgo.ApplicationCommand {
 		Description: "Register a user to current tournament",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "players",
-				Description: "Player(s) to be registered",
-				Required:    false,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "players",
+				Description:  "Mentions or user ids of the players to register (default yourself)",
+				Required:     false,
+				Autocomplete: true,
 			},
 		},
 	}
@@ -41,12 +42,8 @@ func (h *TournamentRegisterHandler) Command() *discordgo.ApplicationCommand {
 func (h *TournamentRegisterHandler) players(inputs []string, s *discordgo.Session, tx *sql.Tx) []*models.Player {
 	mode := config.GetEnv().ENV_MODE
 	validPlayers := make([]*models.Player, 0, len(inputs))
-	for _, player := range inputs {
+	for _, discordId := range inputs {
 		var validPl *models.Player
-		discordId := player
-		if strings.HasPrefix(player, "<@") {
-			discordId = player[2 : len(player)-1]
-		}
 
 		p, err := h.playerModel.FindByDiscordId(discordId)
 		if err != nil {
@@ -94,30 +91,56 @@ func (h *TournamentRegisterHandler) players(inputs []string, s *discordgo.Sessio
 	return validPlayers
 }
 
-func (h *TournamentRegisterHandler) register(t *models.Tournament, p []*models.Player, sr bool, tx *sql.Tx) (int64, error) {
-	var count int64
+func (h *TournamentRegisterHandler) register(t *models.Tournament, p []*models.Player, sr bool, tx *sql.Tx) (int64, int64, error) {
+	var count, waitlisted int64
 	if sr && len(p) == 1 {
 		self, _ := h.attendeeModel.FindById(string(t.ID), string(p[0].ID))
 		// ignoring the error cause that's what sigma does
 		if self != nil {
-			return 0, errors.New("You are already registered to this tournament.")
+			return 0, 0, i18n.NewError("register.already_registered")
 		}
 	}
 
 	for _, player := range p {
-		q := `INSERT INTO attendees (tournament_id, player_id, current_seat) SELECT ?, ?, NULL
-			  	WHERE NOT EXISTS (SELECT 1 FROM attendees WHERE tournament_id = ? AND player_id = ?)`
-
-		result, err := tx.Exec(q, t.ID, player.ID, t.ID, player.ID)
+		registered, onWaitlist, err := h.attendeeModel.Register(tx, string(t.ID), string(player.ID), t.Cap())
 		if err != nil {
 			tx.Rollback()
-			return 0, fmt.Errorf("Error inserting player %s as attendee: %v", player.ID, err)
+			return 0, 0, fmt.Errorf("Error inserting player %s as attendee: %v", player.ID, err)
+		}
+		if registered {
+			count++
+		}
+		if onWaitlist {
+			waitlisted++
+		}
+	}
+
+	return count, waitlisted, nil
+}
+
+// Autocomplete suggests the players who took part in the tournaments of this server before
+func (h *TournamentRegisterHandler) Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opt := base.Focused(i.ApplicationCommandData().Options)
+	if opt == nil {
+		return
+	}
+
+	pm := models.NewPlayerModel(database.GetDB())
+	done, partial := splitPlayerList(opt.StringValue())
+
+	known := make(map[string]string, len(done))
+	for _, id := range done {
+		if p, err := pm.FindByDiscordId(id); err == nil {
+			known[id] = p.Name
 		}
-		rows, _ := result.RowsAffected()
-		count += rows
 	}
 
-	return count, nil
+	// players that are listed already are skipped, fetch enough to still fill the suggestions
+	candidates, err := pm.SearchInGuild(i.GuildID, strings.TrimSpace(partial), base.MAX_CHOICES+len(done))
+	if err != nil {
+		log.Printf("error searching players of guild %s: %v", i.GuildID, err)
+	}
+	base.Suggest(s, i, playerChoices(opt.StringValue(), candidates, known))
 }
 
 func (h *TournamentRegisterHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -132,29 +155,49 @@ func (h *TournamentRegisterHandler) Handler(s *discordgo.Session, i *discordgo.I
 	data := i.ApplicationCommandData()
 
 	if len(data.Options) > 0 {
-		inputs = strings.Fields(data.Options[0].StringValue())
-	}
-
-	if len(inputs) > 0 {
-		err := h.Base.HasPermit(s, i)
-		if err != nil {
-			base.Respond("Insufficent permission to add other players to this tournament.", s, i, true)
+		var err error
+		if inputs, err = models.ParseMentions(data.Options[0].StringValue()); err != nil {
+			base.RespondError(err, s, i)
 			return
 		}
-	} else {
+	}
+
+	if len(inputs) == 0 {
 		inputs = append(inputs, i.Member.User.ID)
 		selfRegister = true
 	}
 
 	tournamentId, err := h.tournamentsModel.GetTournamentIDInThread(i.ChannelID)
 	if err != nil {
-		base.Respond(base.ERR_GET_TOURNAMENT_IN_CHANNEL.Error(), s, i, true)
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
 		return
 	}
 
 	t, err := h.tournamentsModel.GetById(string(tournamentId))
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(err, s, i)
+		return
+	}
+
+	if !selfRegister {
+		if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+			base.Reply("register.insufficient_permission", s, i, true)
+			return
+		}
+	}
+
+	if !t.Registration_Open {
+		base.Reply("register.closed", s, i, true)
+		return
+	}
+
+	if t.IsTeam() {
+		base.Reply("register.team_tournament", s, i, true)
+		return
+	}
+
+	if selfRegister && !t.Self_Register {
+		base.Reply("register.self_register_disabled", s, i, true)
 		return
 	}
 
@@ -165,10 +208,10 @@ func (h *TournamentRegisterHandler) Handler(s *discordgo.Session, i *discordgo.I
 	}
 
 	players := h.players(inputs, s, tx)
-	regCount, err := h.register(t, players, selfRegister, tx)
+	regCount, waitlisted, err := h.register(t, players, selfRegister, tx)
 
 	if err != nil {
-		base.Respond(err.Error(), s, i, true)
+		base.RespondError(err, s, i)
 		return
 	}
 
@@ -177,5 +220,12 @@ func (h *TournamentRegisterHandler) Handler(s *discordgo.Session, i *discordgo.I
 		log.Fatalf("error committing transaction: %v", err)
 	}
 
-	base.Respond(fmt.Sprintf("Added %d players to the tournament.", regCount), s, i, true)
+	refreshSignup(s, h.db, string(t.ID))
+
+	l := base.Locale(i)
+	msg := i18n.N(l, "register.added", int(regCount-waitlisted))
+	if waitlisted > 0 {
+		msg += " " + i18n.N(l, "register.waitlisted", int(waitlisted))
+	}
+	base.Respond(msg, s, i, true)
 }
@@ -2,12 +2,14 @@ package tournament
 
 import (
 	"database/sql"
+	"log"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/handlers/base"
 	"github.com/dimfu/spade/handlers/queue"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/rating"
 )
 
 type RestartTournamentHandler struct {
@@ -24,12 +26,6 @@ func (h *RestartTournamentHandler) Command() *discordgo.ApplicationCommand {
 }
 
 func (h *RestartTournamentHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	err := h.Base.HasPermit(s, i)
-	if err != nil {
-		base.Respond(err.Error(), s, i, true)
-		return
-	}
-
 	h.db = database.GetDB()
 	tm := models.NewTournamentsModel(h.db)
 	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
@@ -37,7 +33,19 @@ func (h *RestartTournamentHandler) Handler(s *discordgo.Session, i *discordgo.In
 		base.SendError(err, s, i)
 		return
 	}
-	_, err = tm.GetById(string(tournamentId))
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	am := models.NewAttendeeModel(h.db)
+	before, err := am.List(string(tournamentId), true)
 	if err != nil {
 		base.SendError(err, s, i)
 		return
@@ -51,11 +59,11 @@ func (h *RestartTournamentHandler) Handler(s *discordgo.Session, i *discordgo.In
 	defer func() {
 		if err != nil {
 			tx.Rollback()
-			base.Respond("Something went wrong while restarting the tournament.", s, i, true)
+			base.Reply("restart.failed", s, i, true)
 		}
 	}()
 
-	err = h.restart(tx, string(tournamentId))
+	err = h.restart(tx, t)
 	if err != nil {
 		base.SendError(err, s, i)
 		return
@@ -65,14 +73,25 @@ func (h *RestartTournamentHandler) Handler(s *discordgo.Session, i *discordgo.In
 		return
 	}
 
-	base.Respond("Tournament has been restarted, use /start to start again.", s, i, false)
+	// not reusing err here since it would trigger the rollback above
+	after, listErr := am.List(string(tournamentId), true)
+	if listErr != nil {
+		log.Println(listErr)
+	}
+	base.Audit(i, models.AUDIT_RESTART, string(tournamentId), models.SnapshotSeats(before), models.SnapshotSeats(after))
+	refreshStatus(s, string(tournamentId))
+
+	base.Reply("restart.restarted", s, i, false)
 }
 
-func (h *RestartTournamentHandler) restart(tx *sql.Tx, tournamentID string) error {
+func (h *RestartTournamentHandler) restart(tx *sql.Tx, t *models.Tournament) error {
+	tournamentID := string(t.ID)
 	s := make([]string, 0)
 	s = append(s, "UPDATE attendees SET current_seat = starting_seat WHERE tournament_id = ?")
-	s = append(s, "UPDATE tournaments SET starting_at = NULL WHERE id = ?")
+	s = append(s, "UPDATE tournaments SET started_at = NULL, completed = false, completed_at = NULL WHERE id = ?")
+	s = append(s, "DELETE FROM placements WHERE tournament_id = ?")
 	s = append(s, "DELETE FROM match_histories WHERE attendee_id IN (SELECT id FROM attendees WHERE tournament_id = ?)")
+	s = append(s, "DELETE FROM matches WHERE tournament_id = ?")
 
 	for _, q := range s {
 		_, err := tx.Exec(q, tournamentID)
@@ -81,5 +100,12 @@ func (h *RestartTournamentHandler) restart(tx *sql.Tx, tournamentID string) erro
 		}
 	}
 
+	// the ratings are replayed without the removed matches so the leaderboards don't keep them
+	if t.Guild_ID.Valid {
+		if _, err := rating.RebuildTx(tx, t.Guild_ID.String); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit()
 }
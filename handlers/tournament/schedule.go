@@ -0,0 +1,128 @@
+package tournament
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/scheduler"
+)
+
+const (
+	JOB_AUTO_START = "autostart"
+	JOB_REMINDER   = "reminder"
+	JOB_CHECK_IN   = "checkin"
+)
+
+const startingAtLayout = "2006-01-02 15:04"
+
+// minutes before the planned start a reminder is posted to the tournament thread
+var reminders = []int{24 * 60, startingSoonMinutes, 10}
+
+// startingSoonMinutes is the reminder attendees are also notified of by direct message
+const startingSoonMinutes = 60
+
+// ReminderJob reminds the tournament thread that the tournament is about to start
+type ReminderJob struct{}
+
+// parseStartingAt parses the planned start time, an empty value clears it
+func parseStartingAt(v string, loc *time.Location) (sql.NullInt64, error) {
+	if v == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	at, err := time.ParseInLocation(startingAtLayout, v, loc)
+	if err != nil {
+		return sql.NullInt64{}, i18n.NewError("tournament.invalid_starting_at", v)
+	}
+
+	if at.Before(time.Now()) {
+		return sql.NullInt64{}, i18n.NewError("tournament.starting_at_past")
+	}
+
+	return sql.NullInt64{Int64: at.Unix(), Valid: true}, nil
+}
+
+func formatStartingAt(t *models.Tournament, loc *time.Location) string {
+	if !t.Starting_At.Valid {
+		return ""
+	}
+	return time.Unix(t.Starting_At.Int64, 0).In(loc).Format(startingAtLayout)
+}
+
+// scheduleStart replaces the pending reminders, check-in and auto start of the tournament with the
+// ones of its current planned start time
+func scheduleStart(t *models.Tournament) error {
+	jm := models.NewScheduledJobModel(database.GetDB())
+	for _, kind := range []string{JOB_AUTO_START, JOB_REMINDER, JOB_CHECK_IN} {
+		if err := jm.Cancel(string(t.ID), kind); err != nil {
+			return err
+		}
+	}
+
+	if !t.Starting_At.Valid || t.Started_At.Valid {
+		return nil
+	}
+
+	now := time.Now()
+	startAt := time.Unix(t.Starting_At.Int64, 0)
+	if startAt.Before(now) {
+		return nil
+	}
+
+	for _, minutes := range reminders {
+		remindAt := startAt.Add(-time.Duration(minutes) * time.Minute)
+		if remindAt.Before(now) {
+			continue
+		}
+		if err := scheduler.Schedule(JOB_REMINDER, string(t.ID), minutes, remindAt); err != nil {
+			return err
+		}
+	}
+
+	// the check-in opens right away when the window already began
+	if t.Check_In_Minutes.Valid && !t.Check_In_Opened_At.Valid {
+		openAt := startAt.Add(-time.Duration(t.Check_In_Minutes.Int64) * time.Minute)
+		if openAt.Before(now) {
+			openAt = now
+		}
+		if err := scheduler.Schedule(JOB_CHECK_IN, string(t.ID), int(t.Check_In_Minutes.Int64), openAt); err != nil {
+			return err
+		}
+	}
+
+	return scheduler.Schedule(JOB_AUTO_START, string(t.ID), 0, startAt)
+}
+
+func (j *ReminderJob) Kind() string {
+	return JOB_REMINDER
+}
+
+func (j *ReminderJob) Run(s *discordgo.Session, job *models.ScheduledJob) error {
+	t, err := models.NewTournamentsModel(database.GetDB()).GetById(job.TournamentID.String)
+	if err != nil {
+		log.Printf("skipping reminder, %v", err)
+		return nil
+	}
+
+	if t.Started_At.Valid || !t.Starting_At.Valid || !t.Thread_ID.Valid {
+		return nil
+	}
+
+	_, err = s.ChannelMessageSend(t.Thread_ID.String, i18n.T(base.GuildLocale(t.Guild_ID.String),
+		"tournament.reminder", t.Name, t.Starting_At.Int64))
+	if err != nil {
+		return err
+	}
+
+	// players are messaged once, with the last reminder that leaves time to get ready
+	if job.ReferenceID.Int64 != startingSoonMinutes {
+		return nil
+	}
+	return notifyStartingSoon(s, database.GetDB(), t)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/dimfu/spade/bracket/seeds"
 	"github.com/dimfu/spade/database"
 	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
 )
 
@@ -27,38 +28,60 @@ func (h *SeedHandler) Command() *discordgo.ApplicationCommand {
 		Description: "Define tournament seeds by order ascending order",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "players",
-				Description: "Player(s) to be registered",
-				Required:    true,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "players",
+				Description:  "Mentions or user ids of the attendees from the best seed to the worst",
+				Required:     true,
+				Autocomplete: true,
 			},
 		},
 	}
 }
 
-func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	err := h.Base.HasPermit(s, i)
+// Autocomplete suggests the attendees of the tournament in this thread
+func (h *SeedHandler) Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opt := base.Focused(i.ApplicationCommandData().Options)
+	if opt == nil {
+		return
+	}
+
+	db := database.GetDB()
+	tournamentId, err := models.NewTournamentsModel(db).GetTournamentIDInThread(i.ChannelID)
 	if err != nil {
-		base.Respond("Insufficent permission to seed other players to this tournament.", s, i, true)
+		base.Suggest(s, i, nil)
 		return
 	}
 
+	attendees, err := models.NewAttendeeModel(db).List(string(tournamentId), false)
+	if err != nil {
+		log.Printf("error listing attendees of %s: %v", tournamentId, err)
+	}
+
+	candidates := make([]models.Player, 0, len(attendees))
+	known := make(map[string]string, len(attendees))
+	for _, a := range attendees {
+		candidates = append(candidates, models.Player{ID: a.Player.ID, Name: a.Name(), DiscordID: a.Player.DiscordID})
+		known[a.Player.DiscordID] = a.Name()
+	}
+	base.Suggest(s, i, playerChoices(opt.StringValue(), candidates, known))
+}
+
+func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	h.db = database.GetDB()
 	tm := models.NewTournamentsModel(h.db)
-	pm := models.NewPlayerModel(h.db)
 	am := models.NewAttendeeModel(h.db)
 
 	data := i.ApplicationCommandData()
 
 	if len(data.Options) == 0 {
-		base.Respond("You need atleast seed 1 player to this tournament", s, i, true)
+		base.Reply("seed.empty", s, i, true)
 		return
 	}
 
 	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
 	if err != nil {
 		log.Println(err)
-		base.Respond(base.ERR_GET_TOURNAMENT_IN_CHANNEL.Error(), s, i, true)
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
 		return
 	}
 
@@ -68,13 +91,43 @@ func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCrea
 		return
 	}
 
+	if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+		base.Reply("seed.insufficient_permission", s, i, true)
+		return
+	}
+
 	tSize, err := strconv.Atoi(t.TournamentType.Size)
 	if err != nil {
 		base.SendError(err, s, i)
 		return
 	}
 
-	fields := strings.Fields(data.Options[0].StringValue())
+	fields, err := models.ParseMentions(data.Options[0].StringValue())
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	attendees, err := am.List(string(tournamentId), false)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	byDiscordId := make(map[string]models.Attendee, len(attendees))
+	for _, a := range attendees {
+		byDiscordId[a.Player.DiscordID] = a
+	}
+
+	var unregistered []string
+	for _, discordId := range fields {
+		if _, ok := byDiscordId[discordId]; !ok {
+			unregistered = append(unregistered, fmt.Sprintf("<@%s>", discordId))
+		}
+	}
+	if len(unregistered) > 0 {
+		base.Reply("seed.unregistered", s, i, true, strings.Join(unregistered, ", "))
+		return
+	}
 
 	maxlen := len(fields)
 	if len(fields) > tSize {
@@ -103,6 +156,12 @@ func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCrea
 		}
 	}()
 
+	before, err := am.List(string(tournamentId), true)
+	if err != nil {
+		errMsg = fmt.Sprintf("error while listing seated attendees %v", err)
+		return
+	}
+
 	if err := am.ResetSeatPos(string(tournamentId)); err != nil {
 		errMsg = fmt.Sprintf("error while resetting seat pos %v", err)
 		tx.Rollback()
@@ -120,23 +179,7 @@ func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCrea
 		if seed == nil {
 			continue
 		}
-		player := seed.(string)
-		discordId := player
-		if strings.HasPrefix(player, "<@") {
-			discordId = player[2 : len(player)-1]
-		}
-		p, err := pm.FindByDiscordId(discordId)
-		if err != nil {
-			errMsg = fmt.Sprintf("error finding discord id of %v", discordId)
-			return
-		}
-
-		a, err := am.FindById(string(tournamentId), string(p.ID))
-		if err != nil {
-			errMsg = fmt.Sprintf("error finding attendee id of this tournament %v", err)
-			return
-		}
-
+		a := byDiscordId[seed.(string)]
 		err = am.StartingSeat(a.Id, bracket.StartingSeats[i])
 		if err != nil {
 			errMsg = fmt.Sprintf("error updating seat position %v", err)
@@ -150,5 +193,11 @@ func (h *SeedHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCrea
 		return
 	}
 
-	base.Respond(fmt.Sprintf("Successfully seeded %d players", countSuccess), s, i, true)
+	after, err := am.List(string(tournamentId), true)
+	if err != nil {
+		log.Println(err)
+	}
+	base.Audit(i, models.AUDIT_SEED, string(tournamentId), models.SnapshotSeats(before), models.SnapshotSeats(after))
+
+	base.Respond(i18n.N(base.Locale(i), "seed.seeded", countSuccess), s, i, true)
 }
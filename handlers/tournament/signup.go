@@ -0,0 +1,263 @@
+package tournament
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+type SignupComponentHandler struct {
+	db *sql.DB
+}
+
+const (
+	ROUTE_SIGNUP_JOIN  = "signup.join"
+	ROUTE_SIGNUP_LEAVE = "signup.leave"
+)
+
+func (h *SignupComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_SIGNUP_JOIN, Args: 1, Handler: h.open(h.join)},
+		{Name: ROUTE_SIGNUP_LEAVE, Args: 1, Handler: h.open(h.leave)},
+	}
+}
+
+// open runs action for the tournament of the custom id while its registration is open
+func (h *SignupComponentHandler) open(action func(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament)) router.HandlerFunc {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+		h.db = database.GetDB()
+
+		t, err := models.NewTournamentsModel(h.db).GetById(cid.Arg(0))
+		if err != nil {
+			base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+			return
+		}
+
+		if !t.Registration_Open || t.Started_At.Valid {
+			base.Reply("signup.registration_closed", s, i, true)
+			return
+		}
+
+		action(s, i, t)
+	}
+}
+
+func (h *SignupComponentHandler) join(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) {
+	if !t.Self_Register {
+		base.Reply("signup.self_register_disabled", s, i, true)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	p, err := playerForUser(h.db, tx, base.Actor(i))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	am := models.NewAttendeeModel(h.db)
+	registered, waitlisted, err := am.Register(tx, string(t.ID), string(p.ID), t.Cap())
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if !registered {
+		base.Reply("signup.already_registered", s, i, true)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	key := "signup.joined"
+	if waitlisted {
+		key = "signup.waitlisted"
+	}
+	h.respond(s, i, t, key)
+}
+
+func (h *SignupComponentHandler) leave(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) {
+	p, err := models.NewPlayerModel(h.db).FindByDiscordId(base.Actor(i).ID)
+	if err == sql.ErrNoRows {
+		base.Reply("signup.not_registered", s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	promoted, err := models.NewAttendeeModel(h.db).Leave(tx, string(t.ID), string(p.ID), t.Cap())
+	if err == sql.ErrNoRows {
+		base.Reply("signup.not_registered", s, i, true)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	h.respond(s, i, t, "signup.left")
+	if promoted != nil {
+		notifyPromoted(s, t, promoted)
+	}
+}
+
+// respond refreshes the signup message the button belongs to and tells the player what happened
+// with the message of key
+func (h *SignupComponentHandler) respond(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament, key string) {
+	data, err := signupMessage(h.db, t)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	err = router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     data.Embeds,
+			Components: data.Components,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: i18n.T(base.Locale(i), key),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func signupMessage(db *sql.DB, t *models.Tournament) (*discordgo.MessageSend, error) {
+	am := models.NewAttendeeModel(db)
+	attendees, err := am.List(string(t.ID), false)
+	if err != nil {
+		return nil, err
+	}
+	waitlist, err := am.Waitlist(string(t.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	l := base.GuildLocale(t.Guild_ID.String)
+	closed := !t.Registration_Open || t.Started_At.Valid
+	return &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			components.Paint(components.SignupEmbed(l, t, attendees, waitlist), base.Settings(t.Guild_ID.String).Color()),
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(l, "signup.join"),
+						Style:    discordgo.SuccessButton,
+						Disabled: closed,
+						CustomID: router.ID(ROUTE_SIGNUP_JOIN, t.ID),
+					},
+					discordgo.Button{
+						Label:    i18n.T(l, "signup.leave"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: closed,
+						CustomID: router.ID(ROUTE_SIGNUP_LEAVE, t.ID),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// postSignup sends the signup message of a solo tournament to its thread, teams register through
+// /team instead
+func postSignup(s *discordgo.Session, tm *models.TournamentsModel, t *models.Tournament) error {
+	if t.IsTeam() || !t.Thread_ID.Valid {
+		return nil
+	}
+
+	data, err := signupMessage(tm.DB, t)
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.ChannelMessageSendComplex(t.Thread_ID.String, data)
+	if err != nil {
+		return err
+	}
+
+	t.Signup_Message_ID = sql.NullString{String: msg.ID, Valid: true}
+	return tm.SetSignupMessage(string(t.ID), msg.ID)
+}
+
+// refreshSignup updates the signup message after the attendees changed outside of its buttons, errors
+// are only logged since the registration itself already went through
+func refreshSignup(s *discordgo.Session, db *sql.DB, tournamentID string) {
+	t, err := models.NewTournamentsModel(db).GetById(tournamentID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !t.Signup_Message_ID.Valid || !t.Thread_ID.Valid {
+		return
+	}
+
+	data, err := signupMessage(db, t)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	_, err = s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         t.Signup_Message_ID.String,
+		Channel:    t.Thread_ID.String,
+		Embeds:     &data.Embeds,
+		Components: &data.Components,
+	})
+	if err != nil {
+		log.Printf("error refreshing signup message of tournament %s: %v", tournamentID, err)
+	}
+}
+
+// notifyPromoted lets the waitlisted player know a spot opened up for them
+func notifyPromoted(s *discordgo.Session, t *models.Tournament, a *models.Attendee) {
+	l := base.GuildLocale(t.Guild_ID.String)
+	msg := i18n.T(l, "signup.promoted", t.Name)
+	if t.Thread_ID.Valid {
+		msg += " " + i18n.T(l, "signup.promoted_details", t.Thread_ID.String)
+	}
+
+	channel, err := s.UserChannelCreate(a.Player.DiscordID)
+	if err == nil {
+		_, err = s.ChannelMessageSend(channel.ID, msg)
+	}
+	if err != nil {
+		log.Printf("error notifying %s of their promotion: %v", a.Player.DiscordID, err)
+	}
+}
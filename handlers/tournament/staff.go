@@ -0,0 +1,211 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+// staffLabels are the message keys of the roles shown to members
+var staffLabels = map[models.Role]string{
+	models.ROLE_ORGANIZER: "staff.organizer",
+	models.ROLE_REFEREE:   "staff.referee",
+}
+
+// staffRoles are the roles the owner can hand out in a tournament
+var staffRoles = []*discordgo.ApplicationCommandOptionChoice{
+	staffChoice(models.ROLE_ORGANIZER),
+	staffChoice(models.ROLE_REFEREE),
+}
+
+func staffChoice(role models.Role) *discordgo.ApplicationCommandOptionChoice {
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name:              i18n.T(i18n.DEFAULT, staffLabels[role]),
+		NameLocalizations: i18n.Localized(staffLabels[role]),
+		Value:             role.String(),
+	}
+}
+
+type StaffHandler struct {
+	Base *base.BaseAdmin
+	db   *sql.DB
+}
+
+func (h *StaffHandler) Command() *discordgo.ApplicationCommand {
+	memberOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionUser,
+		Name:        "member",
+		Description: "Server member",
+		Required:    true,
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "staff",
+		Description: "Manage the co-organizers and referees of the current tournament",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "add",
+				Description: "Make a member co-organizer or referee, co-organizers are picked by the owner",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					memberOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "role",
+						Description: "Referees can only report results",
+						Choices:     staffRoles,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "remove",
+				Description: "Take the role of a member away",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{memberOption},
+			},
+			{
+				Name:        "list",
+				Description: "List the owner and staff of the tournament",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *StaffHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.db = database.GetDB()
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	tm := models.NewTournamentsModel(h.db)
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	if err != nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+		return
+	}
+
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	subcmd := data.Options[0]
+	if subcmd.Name == "list" {
+		h.list(s, i, t)
+		return
+	}
+
+	member := subcmd.Options[0].UserValue(nil)
+	pm := models.NewPermissionModel(h.db)
+
+	// organizers pick the referees, co-organizers are picked by the owner
+	role := models.ROLE_REFEREE
+	if subcmd.Name == "add" {
+		var ok bool
+		if role, ok = models.ParseRole(subcmd.Options[1].StringValue()); !ok {
+			base.Reply("common.unknown_action", s, i, true)
+			return
+		}
+	} else if current, err := staffRole(pm, string(t.ID), member.ID); err != nil {
+		base.SendError(err, s, i)
+		return
+	} else if current > role {
+		role = current
+	}
+
+	required := models.ROLE_OWNER
+	if role == models.ROLE_REFEREE {
+		required = models.ROLE_ORGANIZER
+	}
+	if err := h.Base.Authorize(s, i, t, required); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	switch subcmd.Name {
+	case "add":
+		st := &models.StaffMember{
+			TournamentID: string(t.ID),
+			UserID:       member.ID,
+			Role:         role,
+			AddedBy:      base.Actor(i).ID,
+		}
+		if err := pm.AddStaff(st); err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		base.Audit(i, models.AUDIT_STAFF_ADD, string(t.ID), nil, map[string]string{"member": member.ID, "role": role.String()})
+		base.Reply("staff.added", s, i, true, member.ID, strings.ToLower(i18n.T(base.Locale(i), staffLabels[role])))
+	case "remove":
+		err := pm.RemoveStaff(string(t.ID), member.ID)
+		if err == sql.ErrNoRows {
+			base.Reply("staff.not_staff", s, i, true, member.ID)
+			return
+		}
+		if err != nil {
+			base.SendError(err, s, i)
+			return
+		}
+		base.Audit(i, models.AUDIT_STAFF_REMOVE, string(t.ID), map[string]string{"member": member.ID}, nil)
+		base.Reply("staff.removed", s, i, true, member.ID)
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+// staffRole returns the role the member was given in the tournament
+func staffRole(pm *models.PermissionModel, tournamentID, userID string) (models.Role, error) {
+	staff, err := pm.Staff(tournamentID)
+	if err != nil {
+		return models.ROLE_NONE, err
+	}
+	for _, st := range staff {
+		if st.UserID == userID {
+			return st.Role, nil
+		}
+	}
+	return models.ROLE_NONE, nil
+}
+
+func (h *StaffHandler) list(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) {
+	staff, err := models.NewPermissionModel(h.db).Staff(string(t.ID))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	l := base.Locale(i)
+	var b strings.Builder
+	if t.Owner_ID.Valid {
+		fmt.Fprintf(&b, "%s: <@%s>\n", i18n.T(l, "staff.owner"), t.Owner_ID.String)
+	}
+	for _, st := range staff {
+		fmt.Fprintf(&b, "%s: <@%s>\n", i18n.T(l, staffLabels[st.Role]), st.UserID)
+	}
+	if b.Len() == 0 {
+		b.WriteString(i18n.T(l, "staff.none"))
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         b.String(),
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -19,7 +20,9 @@ import (
 	"github.com/dimfu/spade/discord/components"
 	"github.com/dimfu/spade/handlers/base"
 	"github.com/dimfu/spade/handlers/queue"
+	"github.com/dimfu/spade/i18n"
 	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
 )
 
 type StartHandler struct {
@@ -42,21 +45,14 @@ func (h *StartHandler) Command() *discordgo.ApplicationCommand {
 }
 
 func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	err := h.Base.HasPermit(s, i)
-	if err != nil {
-		base.Respond(err.Error(), s, i, true)
-		return
-	}
-
 	h.db = database.GetDB()
 	tm := models.NewTournamentsModel(h.db)
-	ttm := models.NewTournamentTypesModel(h.db)
 	h.attendeeModel = models.NewAttendeeModel(h.db)
 
 	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
 	if err != nil {
 		log.Println(err)
-		base.Respond(base.ERR_GET_TOURNAMENT_IN_CHANNEL.Error(), s, i, true)
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
 		return
 	}
 
@@ -66,38 +62,148 @@ func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
 		return
 	}
+
+	if err := h.Base.Authorize(s, i, tournament, models.ROLE_ORGANIZER); err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	resumed, err := h.begin(s, tournament, i.ChannelID)
+	if err != nil {
+		var se *i18n.Error
+		if errors.As(err, &se) {
+			base.RespondError(se, s, i)
+			return
+		}
+		base.SendError(err, s, i)
+		return
+	}
+
+	if resumed {
+		base.Audit(i, models.AUDIT_START, string(tournamentId), models.SnapshotTournament(tournament), nil)
+		base.Reply("start.resumed", s, i, true)
+		return
+	}
+
+	seated, err := h.attendeeModel.List(string(tournamentId), true)
+	if err != nil {
+		log.Println(err)
+	}
+	base.Audit(i, models.AUDIT_START, string(tournamentId), models.SnapshotTournament(tournament), models.SnapshotSeats(seated))
+
+	base.Reply("start.started", s, i, false)
+}
+
+func (h *StartHandler) Kind() string {
+	return JOB_AUTO_START
+}
+
+// Run closes the registration and starts the tournament at its planned start time
+func (h *StartHandler) Run(s *discordgo.Session, job *models.ScheduledJob) error {
+	h.db = database.GetDB()
+	h.attendeeModel = models.NewAttendeeModel(h.db)
+	tm := models.NewTournamentsModel(h.db)
+
+	t, err := tm.GetById(job.TournamentID.String)
+	if err != nil {
+		log.Printf("skipping auto start, %v", err)
+		return nil
+	}
+
+	// the tournament was started by hand or the start time has been changed in the meantime
+	if t.Started_At.Valid || !t.Starting_At.Valid || t.Starting_At.Int64 > time.Now().Unix()+int64(time.Minute.Seconds()) {
+		return nil
+	}
+
+	if !t.Thread_ID.Valid {
+		log.Printf("skipping auto start, tournament %s is not published", t.ID)
+		return nil
+	}
+
+	t.Registration_Open = false
+	if err := tm.Update(t); err != nil {
+		return err
+	}
+
+	locale := base.GuildLocale(t.Guild_ID.String)
+	msg := i18n.T(locale, "start.auto_started")
+	resumed, err := h.begin(s, t, t.Thread_ID.String)
+	if err != nil {
+		var se *i18n.Error
+		if !errors.As(err, &se) {
+			return err
+		}
+		msg = i18n.T(locale, "start.auto_start_failed", se.In(locale))
+	} else if resumed {
+		return nil
+	}
+
+	_, err = s.ChannelMessageSend(t.Thread_ID.String, msg)
+	return err
+}
+
+// begin starts the tournament and posts its matches to the channel, it reports whether the
+// tournament had been started before and is resumed with the previous results instead. Errors that
+// can be shown to whoever starts the tournament are i18n errors.
+func (h *StartHandler) begin(s *discordgo.Session, tournament *models.Tournament, channelID string) (bool, error) {
+	tm := models.NewTournamentsModel(h.db)
+	ttm := models.NewTournamentTypesModel(h.db)
+	tournamentId := tournament.ID
 	tSize, _ := strconv.Atoi(tournament.TournamentType.Size)
 
+	color := base.Settings(tournament.Guild_ID.String).Color()
+	locale := base.GuildLocale(tournament.Guild_ID.String)
+	post := func(match models.Match, matchCount int) {
+		referee := matchReferee(h.db, tournament, match.ID)
+		matchChannel, threaded := channelID, false
+		if tournament.Match_Threads {
+			if thread := openMatchThread(s, h.db, tournament, match, matchCount, referee); thread != "" {
+				matchChannel, threaded = thread, true
+			}
+		}
+		if msg := h.buildEmbed(s, matchChannel, match, matchCount, referee, locale, color, threaded); msg != nil {
+			notifyMatchReady(s, h.db, tournament, match, matchCount, msg)
+		}
+		refreshStatus(s, string(tournamentId))
+	}
+
+	if tournament.Completed {
+		return false, i18n.NewError("start.already_completed")
+	}
+
 	// if tournament has been already started before, it should skip all checks below.
-	if tournament.Starting_At.Valid {
+	if tournament.Started_At.Valid {
 		bracket, err := bracket.GenerateFromTemplate(tSize)
 		if err != nil {
-			base.SendError(err, s, i)
-			return
+			return false, err
 		}
-		err = h.start(tournamentId, bracket, func(match models.Match, matchCount int) {
-			h.buildEmbed(s, i, match, matchCount)
-		})
-		if err != nil {
-			base.SendError(err, s, i)
-			return
+		if err := h.start(tournamentId, bracket, post); err != nil {
+			return false, err
 		}
-		base.Respond("Tournament has already been started, resuming with previous result.", s, i, true)
-		return
+		return true, nil
+	}
+
+	// only the attendees that checked in are seeded
+	if err := closeCheckIn(s, h.db, tournament); err != nil {
+		return false, err
 	}
 
 	attendees, err := h.attendeeModel.List(string(tournamentId), false)
 	if err != nil {
-		base.SendError(err, s, i)
-		return
+		return false, err
 	}
 
 	if len(attendees) == 0 {
-		base.Respond("Not enough seed to start the tournament", s, i, true)
-		return
+		return false, i18n.NewError("start.not_enough_seeds")
 	}
 
-	var randomize bool
+	if tournament.IsTeam() {
+		if err := h.checkRosters(tournament); err != nil {
+			return false, err
+		}
+	}
+
+	var randomize, carried bool
 	seatedAttendees := make([]models.Attendee, 0, len(attendees))
 	for _, a := range attendees {
 		if a.CurrentSeat.Valid {
@@ -105,8 +211,16 @@ func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 		}
 	}
 
-	// if no seeds provided it should seed with random strategy
-	if len(seatedAttendees) == 0 {
+	// if no seeds provided it should seed by the previous edition results when carried over,
+	// otherwise seed with random strategy
+	if len(seatedAttendees) == 0 && tournament.Seed_From.Valid {
+		attendees, err = h.rankByPreviousEdition(tournament.Seed_From.String, attendees)
+		if err != nil {
+			return false, err
+		}
+		carried = true
+		seatedAttendees = attendees
+	} else if len(seatedAttendees) == 0 {
 		randomize = true
 		seatedAttendees = attendees
 	}
@@ -139,7 +253,7 @@ func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 
 	tx, err := h.db.Begin()
 	if err != nil {
-		log.Fatal(err)
+		return false, err
 	}
 	defer tx.Rollback()
 
@@ -160,14 +274,12 @@ func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 			minSize = int(math.Min(float64(minSize), float64(size)))
 		}
 		if minSize > len(seatedAttendees) {
-			base.Respond(fmt.Sprintf("Can't start tournament, you need at least %d seeded players to start", bracketSize), s, i, true)
-			return
+			return false, i18n.NewCountError("start.minimum_seeds", bracketSize)
 		}
 
 		tournamentTypes, err := ttm.List()
 		if err != nil {
-			base.SendError(err, s, i)
-			return
+			return false, err
 		}
 
 		var newTType int
@@ -181,39 +293,79 @@ func (h *StartHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCre
 
 		tournament.Tournament_Types_ID = newTType
 		if err = tm.Update(tournament); err != nil {
-			base.SendError(err, s, i)
-			return
+			return false, err
 		}
 	}
 
 	bracket, err := bracket.GenerateFromTemplate(bracketSize)
 	if err != nil {
-		base.SendError(err, s, i)
-		return
+		return false, err
 	}
 
 	// re-adjust the seat positions according to new bracket size if needed
-	if shouldReseed || randomize {
+	if shouldReseed || randomize || carried {
 		if err = h.reseed(bracket, attendees, strategy); err != nil {
-			base.SendError(err, s, i)
-			return
+			return false, err
 		}
 	}
 
 	if err = tx.Commit(); err != nil {
-		base.SendError(err, s, i)
-		return
+		return false, err
 	}
 
-	err = h.start(tournamentId, bracket, func(match models.Match, matchCount int) {
-		h.buildEmbed(s, i, match, matchCount)
-	})
+	if err = h.start(tournamentId, bracket, post); err != nil {
+		return false, err
+	}
+	refreshSignup(s, h.db, string(tournamentId))
+	refreshCheckIn(s, h.db, string(tournamentId))
+
+	return false, nil
+}
+
+// checkRosters makes sure every team has enough players to play before the bracket is drawn
+func (h *StartHandler) checkRosters(t *models.Tournament) error {
+	teams, err := models.NewTeamModel(h.db).List(string(t.ID))
 	if err != nil {
-		base.SendError(err, s, i)
-		return
+		return err
+	}
+
+	var short []string
+	for _, team := range teams {
+		if len(team.Members) < t.Team_Size {
+			short = append(short, fmt.Sprintf("%s (%d/%d)", team.Name, len(team.Members), t.Team_Size))
+		}
+	}
+	if len(short) > 0 {
+		return i18n.NewError("start.short_rosters", strings.Join(short, ", "))
+	}
+	return nil
+}
+
+// rankByPreviousEdition orders the attendees by their results in the previous edition, attendees
+// that did not play the previous edition are put last
+func (h *StartHandler) rankByPreviousEdition(previousID string, attendees []models.Attendee) ([]models.Attendee, error) {
+	ranking, err := models.NewMatchModel(h.db).PlayerRanking(previousID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := make(map[string]int, len(ranking))
+	for idx, playerID := range ranking {
+		pos[playerID] = idx
 	}
 
-	base.Respond("Tournament is now started", s, i, false)
+	ranked := make([]models.Attendee, len(attendees))
+	copy(ranked, attendees)
+	sort.SliceStable(ranked, func(a, b int) bool {
+		pa, okA := pos[ranked[a].PlayerID]
+		pb, okB := pos[ranked[b].PlayerID]
+		if okA != okB {
+			return okA
+		}
+		return pa < pb
+	})
+
+	return ranked, nil
 }
 
 func (h *StartHandler) reseed(bracket *bracket.BracketTree, attendees []models.Attendee, strategy seeds.Stragies) error {
@@ -247,50 +399,6 @@ func (h *StartHandler) reseed(bracket *bracket.BracketTree, attendees []models.A
 	return nil
 }
 
-func (h *StartHandler) assertPayload(node *bracket.Node) (*models.AttendeeWithResult, error) {
-	payload, ok := node.Payload.(models.AttendeeWithResult)
-	if !ok {
-		return nil, errors.New("payload must be AttendeeWithStatus")
-	}
-	return &payload, nil
-}
-
-func (h *StartHandler) generateMatches(b *bracket.BracketTree, r int) ([]*models.Match, error) {
-	nodes, err := b.NodesInRound(r)
-	if err != nil {
-		return nil, err
-	}
-	matches := make([]*models.Match, 0, len(nodes)/2)
-	for i := 0; i < len(nodes)-1; i += 2 {
-		var completed bool
-		match := models.Match{P1: &bracket.Node{}, P2: &bracket.Node{}}
-
-		if p1, err := b.Search(nodes[i].Position); err == nil {
-			if payload, err := h.assertPayload(p1); err == nil {
-				if payload.Completed {
-					completed = true
-				}
-			}
-			match.P1 = p1
-		}
-
-		if p2, err := b.Search(nodes[i+1].Position); err == nil {
-			if payload, err := h.assertPayload(p2); err == nil {
-				if payload.Completed {
-					completed = true
-				}
-			}
-			match.P2 = p2
-		}
-
-		if completed {
-			continue
-		}
-		matches = append(matches, &match)
-	}
-	return matches, nil
-}
-
 func (h *StartHandler) InsertPayload(bt *bracket.BracketTree, s int, a models.Attendee, result int, completed bool) (*models.AttendeeWithResult, error) {
 	node, err := bt.Search(s)
 	if err != nil {
@@ -301,13 +409,39 @@ func (h *StartHandler) InsertPayload(bt *bracket.BracketTree, s int, a models.At
 	return &attendee, nil
 }
 
-func (h *StartHandler) start(tournamentId []uint8, bracket *bracket.BracketTree, callback func(match models.Match, matchCount int)) error {
+func (h *StartHandler) start(tournamentId []uint8, bt *bracket.BracketTree, callback func(match models.Match, matchCount int)) error {
 	now := time.Now().Unix()
-	_, err := h.db.Exec("UPDATE tournaments SET starting_at = IFNULL(starting_at, ?) WHERE id = ?", now, tournamentId)
+	q := `UPDATE tournaments SET starting_at = IFNULL(starting_at, ?), started_at = IFNULL(started_at, ?),
+			registration_open = false
+		  WHERE id = ?`
+	_, err := h.db.Exec(q, now, now, tournamentId)
 	if err != nil {
 		return err
 	}
 
+	// a planned start is no longer needed once the tournament has been started
+	jm := models.NewScheduledJobModel(h.db)
+	for _, kind := range []string{JOB_AUTO_START, JOB_REMINDER} {
+		if err := jm.Cancel(string(tournamentId), kind); err != nil {
+			return err
+		}
+	}
+
+	mm := models.NewMatchModel(h.db)
+	records, err := mm.List(string(tournamentId))
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		if err := mm.Create(string(tournamentId), bt); err != nil {
+			return err
+		}
+		if records, err = mm.List(string(tournamentId)); err != nil {
+			return err
+		}
+	}
+
 	mhm := models.NewMatchHistoryModel(h.db)
 	currentTournament, err := mhm.CurrentTournamentHistory(tournamentId)
 	if err != nil {
@@ -317,7 +451,7 @@ func (h *StartHandler) start(tournamentId []uint8, bracket *bracket.BracketTree,
 	attendeeWithStatus := make([]models.AttendeeWithResult, 0, len(currentTournament))
 	for _, c := range currentTournament {
 		// insert current position
-		attendee, err := h.InsertPayload(bracket, int(c.Attendee.CurrentSeat.Int64), c.Attendee, 0, false)
+		attendee, err := h.InsertPayload(bt, int(c.Attendee.CurrentSeat.Int64), c.Attendee, 0, false)
 		if err != nil {
 			return err
 		}
@@ -325,7 +459,7 @@ func (h *StartHandler) start(tournamentId []uint8, bracket *bracket.BracketTree,
 
 		// insert previous positions to the bracket if exists
 		for _, history := range c.Histories {
-			attendee, err := h.InsertPayload(bracket, int(history.Seat.Int64), c.Attendee, history.Result, true)
+			attendee, err := h.InsertPayload(bt, int(history.Seat.Int64), c.Attendee, history.Result, true)
 			if err != nil {
 				return err
 			}
@@ -333,29 +467,29 @@ func (h *StartHandler) start(tournamentId []uint8, bracket *bracket.BracketTree,
 		}
 	}
 
-	r := 1
-	matches := []*models.Match{}
-	for {
-		matchesInRound, err := h.generateMatches(bracket, r)
-		if err != nil {
-			return err
-		}
-		matches = append(matches, matchesInRound...)
-
-		if r == len(bracket.SeatRoundPos) {
-			break
+	// every unfinished match is queued, participants are resolved from the bracket once it is posted
+	matches := make([]*models.Match, 0, len(records))
+	for _, record := range records {
+		if record.Done() {
+			continue
 		}
-
-		r++
+		matches = append(matches, &models.Match{
+			ID: record.ID,
+			P1: &bracket.Node{Position: record.P1Seat},
+			P2: &bracket.Node{Position: record.P2Seat},
+		})
 	}
 
-	go h.MatchQueue.Start(string(tournamentId), bracket, matches, h.ctx, func(match models.Match, matchCount int) {
+	go h.MatchQueue.Start(string(tournamentId), bt, matches, h.ctx, func(match models.Match, matchCount int) {
 		callback(match, matchCount)
 	})
 	return nil
 }
 
-func (h *StartHandler) buildEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, m models.Match, matchCount int) {
+// buildEmbed posts the match with its reporting buttons, matches played in their own thread also get
+// check-in controls. It returns nil when the match could not be posted.
+func (h *StartHandler) buildEmbed(s *discordgo.Session, channelID string, m models.Match, matchCount int,
+	referee string, locale i18n.Locale, color int, threaded bool) *discordgo.Message {
 	var p1, p2 models.AttendeeWithResult
 	pairs := make([]models.AttendeeWithResult, 0, 2)
 
@@ -366,7 +500,7 @@ func (h *StartHandler) buildEmbed(s *discordgo.Session, i *discordgo.Interaction
 		}
 	} else {
 		p1 = models.AttendeeWithResult{}
-		p1.Player.Name = "N/A"
+		p1.Player.Name = i18n.T(locale, "matchup.not_available")
 	}
 
 	if m.P2 != nil {
@@ -376,26 +510,40 @@ func (h *StartHandler) buildEmbed(s *discordgo.Session, i *discordgo.Interaction
 		}
 	} else {
 		p2 = models.AttendeeWithResult{}
-		p2.Player.Name = "N/A"
+		p2.Player.Name = i18n.T(locale, "matchup.not_available")
 	}
 
-	buttons := make([]discordgo.MessageComponent, 0, len(pairs))
+	buttons := make([]discordgo.MessageComponent, 0, len(pairs)+1)
+	if threaded {
+		buttons = append(buttons, discordgo.Button{
+			Label:    i18n.T(locale, "match.check_in"),
+			Style:    discordgo.PrimaryButton,
+			CustomID: router.ID(ROUTE_MATCH_CHECK_IN, m.ID),
+		})
+	}
 	for _, payload := range pairs {
 		buttons = append(buttons, discordgo.Button{
 			Emoji: &discordgo.ComponentEmoji{
 				Name: "✅",
 			},
-			Label:    fmt.Sprintf("%v Wins", payload.Player.Name),
+			Label:    i18n.T(locale, "match.wins", payload.Name()),
 			Style:    discordgo.SecondaryButton,
-			CustomID: fmt.Sprintf("tournament_processresult_%s_%d_%d", payload.TournamentID, payload.Attendee.Id, payload.CurrentSeat.Int64),
+			CustomID: router.ID(ROUTE_MATCH_RESULT, payload.TournamentID, payload.Attendee.Id, payload.CurrentSeat.Int64),
 		})
 	}
-	_, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
-		Embed: components.MatchupEmbed(components.MatchupPayload{
-			P1:    p1,
-			P2:    p2,
-			Match: matchCount,
-		}),
+	embed := components.MatchupEmbed(components.MatchupPayload{
+		P1:      p1,
+		P2:      p2,
+		Match:   matchCount,
+		Referee: referee,
+		Locale:  locale,
+	})
+	if threaded {
+		components.SetCheckIn(locale, embed, false, false)
+	}
+
+	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embed: components.Paint(embed, color),
 		Components: []discordgo.MessageComponent{
 			discordgo.ActionsRow{
 				Components: buttons,
@@ -405,5 +553,11 @@ func (h *StartHandler) buildEmbed(s *discordgo.Session, i *discordgo.Interaction
 
 	if err != nil {
 		fmt.Println("Error sending message:", err)
+		return nil
+	}
+
+	if err := models.NewMatchModel(h.db).Posted(m.ID, msg.ChannelID, msg.ID); err != nil {
+		log.Printf("error marking match %d as live: %v", m.ID, err)
 	}
+	return msg
 }
@@ -0,0 +1,165 @@
+package tournament
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/discord/components"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+	"github.com/dimfu/spade/scheduler"
+)
+
+const (
+	// statusWait lets a burst of results settle before the status message is edited
+	statusWait = 3 * time.Second
+	// statusInterval keeps the edits of a single status message well within the discord rate limits
+	statusInterval = 10 * time.Second
+	statusRecent   = 5
+)
+
+// statusBoard keeps the pinned status message of every running tournament up to date
+var statusBoard struct {
+	once    sync.Once
+	s       *discordgo.Session
+	updates *scheduler.Debouncer
+}
+
+// refreshStatus asks for the status message of the tournament to be updated, updates are coalesced
+// so it is cheap to call after every change
+func refreshStatus(s *discordgo.Session, tournamentID string) {
+	statusBoard.once.Do(func() {
+		statusBoard.s = s
+		statusBoard.updates = scheduler.NewDebouncer(statusWait, statusInterval, updateStatus)
+	})
+	statusBoard.updates.Trigger(tournamentID)
+}
+
+// tournamentStatus builds the status embed of the tournament
+func tournamentStatus(db *sql.DB, t *models.Tournament) (*discordgo.MessageEmbed, error) {
+	records, err := models.NewMatchModel(db).List(string(t.ID))
+	if err != nil {
+		return nil, err
+	}
+	attendees, err := models.NewAttendeeModel(db).List(string(t.ID), true)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(attendees))
+	for _, a := range attendees {
+		names[a.Id] = a.Name()
+	}
+	status := models.BuildStatus(records, attendees, statusRecent)
+	color := base.Settings(t.Guild_ID.String).Color()
+	return components.Paint(components.StatusEmbed(base.GuildLocale(t.Guild_ID.String), t, status, names), color), nil
+}
+
+// updateStatus edits the status message of the tournament, it is posted and pinned in the tournament
+// thread the first time or when it was deleted
+func updateStatus(tournamentID string) error {
+	s := statusBoard.s
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+	t, err := tm.GetById(tournamentID)
+	if err != nil {
+		// the tournament was deleted in the meantime
+		return nil
+	}
+	if !t.Thread_ID.Valid {
+		return nil
+	}
+
+	embed, err := tournamentStatus(db, t)
+	if err != nil {
+		return err
+	}
+
+	if t.Status_Message_ID.Valid {
+		_, err := s.ChannelMessageEditEmbed(t.Thread_ID.String, t.Status_Message_ID.String, embed)
+		var restErr *discordgo.RESTError
+		if !errors.As(err, &restErr) || restErr.Response.StatusCode != http.StatusNotFound {
+			return err
+		}
+	}
+
+	msg, err := s.ChannelMessageSendEmbed(t.Thread_ID.String, embed)
+	if err != nil {
+		return err
+	}
+	if err := s.ChannelMessagePin(t.Thread_ID.String, msg.ID); err != nil {
+		log.Printf("error pinning the status of tournament %s: %v", t.ID, err)
+	}
+	return tm.SetStatusMessage(tournamentID, msg.ID)
+}
+
+type StatusHandler struct{}
+
+func (h *StatusHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "status",
+		Description: "Show the live status of a tournament",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "tournament",
+				Description:  "Tournament to show (default the tournament of this thread)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	}
+}
+
+func (h *StatusHandler) Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var query string
+	if opt := base.Focused(i.ApplicationCommandData().Options); opt != nil {
+		query = opt.StringValue()
+	}
+	base.Suggest(s, i, tournamentChoices(base.Locale(i), i.GuildID, query))
+}
+
+func (h *StatusHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+
+	var tID string
+	if data := i.ApplicationCommandData(); len(data.Options) > 0 {
+		tID = data.Options[0].StringValue()
+	} else {
+		id, err := tm.GetTournamentIDInThread(i.ChannelID)
+		if err != nil {
+			base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+			return
+		}
+		tID = string(id)
+	}
+
+	t, err := tm.GetById(tID)
+	if err != nil || t.Guild_ID.String != i.GuildID {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	embed, err := tournamentStatus(db, t)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{embed},
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
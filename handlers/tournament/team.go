@@ -0,0 +1,425 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+	"github.com/google/uuid"
+)
+
+type TeamHandler struct {
+	Base *base.BaseAdmin
+	db   *sql.DB
+}
+
+func (h *TeamHandler) Command() *discordgo.ApplicationCommand {
+	teamOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "name",
+		Description: "Name of the team",
+		Required:    true,
+		MaxLength:   64,
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "team",
+		Description: "Register and manage your team in the current team tournament",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "create",
+				Description: "Register a new team and become its captain",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{teamOption},
+			},
+			{
+				Name:        "invite",
+				Description: "Invite a player to your team, only the captain can invite",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "player",
+						Description: "Player to invite",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "join",
+				Description: "Join a team you have been invited to",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{teamOption},
+			},
+			{
+				Name:        "roster",
+				Description: "List the teams and their players",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *TeamHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.db = database.GetDB()
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	tm := models.NewTournamentsModel(h.db)
+	tournamentId, err := tm.GetTournamentIDInThread(i.ChannelID)
+	if err != nil {
+		base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+		return
+	}
+
+	t, err := tm.GetById(string(tournamentId))
+	if err != nil {
+		base.SendError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	if !t.IsTeam() {
+		base.Reply("team.solo_tournament", s, i, true)
+		return
+	}
+
+	subcmd := data.Options[0]
+	if subcmd.Name == "roster" {
+		h.roster(s, i, t)
+		return
+	}
+
+	if t.Started_At.Valid {
+		base.Reply("team.started", s, i, true)
+		return
+	}
+
+	switch subcmd.Name {
+	case "create":
+		h.create(s, i, t, strings.TrimSpace(subcmd.Options[0].StringValue()))
+	case "invite":
+		h.invite(s, i, t, subcmd.Options[0].UserValue(s))
+	case "join":
+		h.join(s, i, t, strings.TrimSpace(subcmd.Options[0].StringValue()))
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *TeamHandler) create(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament, name string) {
+	if !t.Registration_Open {
+		base.Reply("register.closed", s, i, true)
+		return
+	}
+
+	if !t.Self_Register {
+		if err := h.Base.Authorize(s, i, t, models.ROLE_ORGANIZER); err != nil {
+			base.Reply("team.self_register_disabled", s, i, true)
+			return
+		}
+	}
+
+	if name == "" {
+		base.Reply("team.empty_name", s, i, true)
+		return
+	}
+
+	teamModel := models.NewTeamModel(h.db)
+	if _, err := teamModel.FindByName(string(t.ID), name); err == nil {
+		base.Reply("team.exists", s, i, true, name)
+		return
+	} else if err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	captain, err := playerForUser(h.db, tx, base.Actor(i))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	// teams are capped without a waitlist, a roster can't wait for a spot the way a player does
+	attendees, err := models.NewAttendeeModel(h.db).List(string(t.ID), false)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if len(attendees) >= t.Cap() {
+		base.Reply("team.full", s, i, true, t.Cap())
+		return
+	}
+
+	if team, err := teamModel.FindByPlayer(string(t.ID), string(captain.ID)); err == nil {
+		base.Reply("team.already_playing", s, i, true, team.Name)
+		return
+	} else if err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	team := &models.Team{
+		TournamentID: string(t.ID),
+		Name:         name,
+		CaptainID:    string(captain.ID),
+	}
+	if err := teamModel.Insert(tx, team); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Reply("team.created", s, i, false, team.Name, captain.DiscordID)
+}
+
+func (h *TeamHandler) invite(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament, user *discordgo.User) {
+	if user.Bot {
+		base.Reply("team.bot", s, i, true)
+		return
+	}
+
+	teamModel := models.NewTeamModel(h.db)
+	team, ok := h.captainTeam(s, i, t)
+	if !ok {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	p, err := playerForUser(h.db, tx, user)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if other, err := teamModel.FindByPlayer(string(t.ID), string(p.ID)); err == nil {
+		base.Reply("team.other_playing", s, i, true, user.Username, other.Name)
+		return
+	} else if err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	count, err := teamModel.CountMembers(tx, team.ID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if count >= t.RosterLimit() {
+		base.Reply("team.roster_full", s, i, true, t.RosterLimit())
+		return
+	}
+
+	// the player has to exist before the invite can refer to it
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := teamModel.Invite(team.ID, string(p.ID), team.CaptainID); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         i18n.T(base.GuildLocale(i.GuildID), "team.invited", user.ID, team.Name, team.Name),
+			AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{user.ID}},
+		},
+	})
+}
+
+func (h *TeamHandler) join(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament, name string) {
+	if !t.Registration_Open {
+		base.Reply("register.closed", s, i, true)
+		return
+	}
+
+	teamModel := models.NewTeamModel(h.db)
+	team, err := teamModel.FindByName(string(t.ID), name)
+	if err == sql.ErrNoRows {
+		base.Reply("team.not_found", s, i, true, name)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	p, err := models.NewPlayerModel(h.db).FindByDiscordId(base.Actor(i).ID)
+	if err == sql.ErrNoRows {
+		base.Reply("team.not_invited", s, i, true, team.Name)
+		return
+	}
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if other, err := teamModel.FindByPlayer(string(t.ID), string(p.ID)); err == nil {
+		base.Reply("team.already_playing", s, i, true, other.Name)
+		return
+	} else if err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return
+	}
+
+	invited, err := teamModel.IsInvited(team.ID, string(p.ID))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if !invited {
+		base.Reply("team.not_invited", s, i, true, team.Name)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	defer tx.Rollback()
+
+	count, err := teamModel.CountMembers(tx, team.ID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if count >= t.RosterLimit() {
+		base.Reply("team.team_full", s, i, true, team.Name, t.RosterLimit())
+		return
+	}
+
+	if err := teamModel.AddMember(tx, team, string(p.ID)); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Reply("team.joined", s, i, false, p.Name, team.Name, count+1, t.RosterLimit())
+}
+
+func (h *TeamHandler) roster(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) {
+	teams, err := models.NewTeamModel(h.db).List(string(t.ID))
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if len(teams) == 0 {
+		base.Reply("team.none", s, i, true)
+		return
+	}
+
+	l := base.Locale(i)
+	fields := make([]*discordgo.MessageEmbedField, 0, len(teams))
+	for _, team := range teams {
+		players := make([]string, 0, len(team.Members))
+		for _, m := range team.Members {
+			line := fmt.Sprintf("<@%s>", m.DiscordID)
+			if string(m.ID) == team.CaptainID {
+				line += " " + i18n.T(l, "team.captain")
+			}
+			players = append(players, line)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s (%d/%d)", team.Name, len(team.Members), t.RosterLimit()),
+			Value:  strings.Join(players, "\n"),
+			Inline: true,
+		})
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       i18n.T(l, "team.roster_title", t.Name),
+					Description: i18n.N(l, "team.roster_description", t.Team_Size),
+					Fields:      fields,
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// captainTeam returns the team captained by the actor, it responds to the interaction by itself
+// when the actor isn't a captain
+func (h *TeamHandler) captainTeam(s *discordgo.Session, i *discordgo.InteractionCreate, t *models.Tournament) (*models.Team, bool) {
+	p, err := models.NewPlayerModel(h.db).FindByDiscordId(base.Actor(i).ID)
+	if err != nil && err != sql.ErrNoRows {
+		base.SendError(err, s, i)
+		return nil, false
+	}
+
+	if p != nil {
+		team, err := models.NewTeamModel(h.db).FindByPlayer(string(t.ID), string(p.ID))
+		if err != nil && err != sql.ErrNoRows {
+			base.SendError(err, s, i)
+			return nil, false
+		}
+		if team != nil && team.CaptainID == string(p.ID) {
+			return team, true
+		}
+	}
+
+	base.Reply("team.captains_only", s, i, true)
+	return nil, false
+}
+
+// playerForUser returns the player of the discord user, the player is registered when it's their first
+// tournament
+func playerForUser(db *sql.DB, tx *sql.Tx, user *discordgo.User) (*models.Player, error) {
+	pm := models.NewPlayerModel(db)
+	p, err := pm.FindByDiscordId(user.ID)
+	if err == nil {
+		return p, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	p = &models.Player{
+		ID:        []uint8(uuid.New().String()),
+		Name:      user.Username,
+		DiscordID: user.ID,
+	}
+	if err := pm.Insert(tx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,209 @@
+package tournament
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+type TemplateHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *TemplateHandler) Command() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "template",
+		Description: "Manage reusable tournament templates",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "save",
+				Description: "Save the configuration of the current tournament as a template",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "Template name, saving with an existing name overwrites it",
+						Required:    true,
+						MaxLength:   64,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name_pattern",
+						Description: "Name of the created tournaments, {n} is the edition and {date} the creation date",
+						Required:    false,
+						MaxLength:   128,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "id",
+						Description:  "Tournament to save (default the tournament of this thread)",
+						Required:     false,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "List the saved templates",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (h *TemplateHandler) Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var query string
+	if opt := base.Focused(i.ApplicationCommandData().Options); opt != nil {
+		query = opt.StringValue()
+	}
+	base.Suggest(s, i, tournamentChoices(base.Locale(i), i.GuildID, query))
+}
+
+func (h *TemplateHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	err := h.Base.Authorize(s, i, nil, models.ROLE_ORGANIZER)
+	if err != nil {
+		base.RespondError(err, s, i)
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		log.Println("empty options")
+		return
+	}
+
+	subcmd := data.Options[0]
+	switch subcmd.Name {
+	case "save":
+		h.save(s, i, subcmd.Options)
+	case "list":
+		h.list(s, i)
+	default:
+		base.Reply("common.unknown_action", s, i, true)
+	}
+}
+
+func (h *TemplateHandler) save(s *discordgo.Session, i *discordgo.InteractionCreate,
+	opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	db := database.GetDB()
+	tm := models.NewTournamentsModel(db)
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range opts {
+		options[opt.Name] = opt
+	}
+
+	var tId string
+	if opt, ok := options["id"]; ok {
+		tId = opt.StringValue()
+	} else {
+		id, err := tm.GetTournamentIDInThread(i.ChannelID)
+		if err != nil {
+			base.RespondError(base.ERR_GET_TOURNAMENT_IN_CHANNEL, s, i)
+			return
+		}
+		tId = string(id)
+	}
+
+	t, err := tm.GetById(tId)
+	if err != nil || t.Guild_ID.String != i.GuildID {
+		base.RespondError(base.ERR_GET_TOURNAMENT, s, i)
+		return
+	}
+
+	tp := &models.TournamentTemplate{
+		GuildID:             i.GuildID,
+		Name:                options["name"].StringValue(),
+		NamePattern:         t.Name,
+		Description:         t.Description,
+		Rules:               t.Rules,
+		Tournament_Types_ID: t.Tournament_Types_ID,
+		BestOf:              t.Best_Of,
+		SelfRegister:        t.Self_Register,
+		Game:                t.Game,
+		TeamSize:            t.Team_Size,
+		RosterLimit:         t.Roster_Limit,
+		CheckInMinutes:      t.Check_In_Minutes,
+		AutoReferee:         t.Auto_Referee,
+		MatchThreads:        t.Match_Threads,
+		CreatedBy:           base.Actor(i).ID,
+	}
+
+	if opt, ok := options["name_pattern"]; ok {
+		tp.NamePattern = opt.StringValue()
+	}
+
+	if err := models.NewTournamentTemplatesModel(db).Save(tp); err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	base.Reply("template.saved", s, i, true, tp.Name, tp.Name)
+}
+
+func (h *TemplateHandler) list(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	templates, err := models.NewTournamentTemplatesModel(database.GetDB()).List(i.GuildID)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	if len(templates) == 0 {
+		base.Reply("template.none", s, i, true)
+		return
+	}
+
+	// embeds can only hold 25 fields
+	l := base.Locale(i)
+	fields := make([]*discordgo.MessageEmbedField, 0, len(templates))
+	for idx, tp := range templates {
+		if idx == 25 {
+			break
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name: tp.Name,
+			Value: fmt.Sprintf("%s\n%s", tp.NamePattern, i18n.N(l, "template.summary", tp.Uses,
+				i18n.T(l, "tournament.format", tp.TournamentType.Bracket_Type, tp.TournamentType.Size), tp.BestOf)),
+		})
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:  i18n.T(l, "template.title"),
+					Fields: fields,
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// fromTemplate copies the template configuration to a tournament that is about to be created
+func fromTemplate(t *models.Tournament, tp *models.TournamentTemplate) {
+	t.Name = tp.TournamentName(time.Now())
+	t.Description = tp.Description
+	t.Rules = tp.Rules
+	t.Tournament_Types_ID = tp.Tournament_Types_ID
+	t.TournamentType = tp.TournamentType
+	t.Best_Of = tp.BestOf
+	t.Self_Register = tp.SelfRegister
+	t.Game = tp.Game
+	t.Team_Size = tp.TeamSize
+	t.Roster_Limit = tp.RosterLimit
+	t.Check_In_Minutes = tp.CheckInMinutes
+	t.Auto_Referee = tp.AutoReferee
+	t.Match_Threads = tp.MatchThreads
+	t.Template_ID = sql.NullInt64{Int64: int64(tp.ID), Valid: true}
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/handlers/base"
+	"github.com/dimfu/spade/i18n"
+	"github.com/dimfu/spade/models"
+	"github.com/dimfu/spade/router"
+)
+
+const tournamentsPageSize = 10
+
+type TournamentsHandler struct {
+	Base *base.BaseAdmin
+}
+
+type TournamentsComponentHandler struct {
+	Base *base.BaseAdmin
+}
+
+func (h *TournamentsHandler) Command() *discordgo.ApplicationCommand {
+	types, err := models.NewTournamentTypesModel(database.GetDB()).List()
+	if err != nil {
+		log.Printf("error querying tournament types, ERR: %v", err.Error())
+		return nil
+	}
+
+	formats := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(types))
+	for _, tt := range types {
+		if tt.Has_Third_Winner {
+			formats = append(formats, &discordgo.ApplicationCommandOptionChoice{
+				Name:              i18n.T(i18n.DEFAULT, "tournament.format", tt.Bracket_Type, tt.Size),
+				NameLocalizations: i18n.Localized("tournament.format", tt.Bracket_Type, tt.Size),
+				Value:             tt.ID,
+			})
+		}
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "tournaments",
+		Description: "List the tournaments of this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "status",
+				Description: "Only list tournaments in this state",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "draft", Value: models.TOURNAMENT_DRAFT},
+					{Name: "open for registration", Value: models.TOURNAMENT_OPEN},
+					{Name: "running", Value: models.TOURNAMENT_RUNNING},
+					{Name: "completed", Value: models.TOURNAMENT_COMPLETED},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "format",
+				Description: "Only list tournaments of this format",
+				Required:    false,
+				Choices:     formats,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "upcoming",
+				Description: "Only list tournaments planned to start later",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "mine",
+				Description: "Only list tournaments you own, staff or play in",
+				Required:    false,
+			},
+		},
+	}
+}
+
+func (h *TournamentsHandler) Handler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.GuildID == "" {
+		base.Reply("common.guild_only", s, i, true)
+		return
+	}
+
+	f := models.TournamentFilter{GuildID: i.GuildID}
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "status":
+			f.State = opt.StringValue()
+		case "format":
+			f.TypeID = int(opt.IntValue())
+		case "upcoming":
+			f.Upcoming = opt.BoolValue()
+		case "mine":
+			if opt.BoolValue() {
+				f.Member = base.Actor(i).ID
+			}
+		}
+	}
+
+	// drafts are listed to organizers only and in a message nobody else sees
+	drafts := f.State == models.TOURNAMENT_DRAFT
+	if drafts {
+		if err := h.Base.Authorize(s, i, nil, models.ROLE_ORGANIZER); err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+	}
+
+	res, err := tournamentsPage(base.ReplyLocale(i, drafts), f, 1)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	if drafts {
+		res.Flags = discordgo.MessageFlagsEphemeral
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: res,
+	})
+}
+
+// ROUTE_TOURNAMENTS_PAGE custom id is built with the page followed by the filter: the state, the
+// format, whether only upcoming tournaments are listed and the member whose tournaments are listed
+const ROUTE_TOURNAMENTS_PAGE = "tournaments.page"
+
+func (h *TournamentsComponentHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: ROUTE_TOURNAMENTS_PAGE, Args: 5, Handler: h.page},
+	}
+}
+
+func (h *TournamentsComponentHandler) page(s *discordgo.Session, i *discordgo.InteractionCreate, cid router.CustomID) {
+	page, err := cid.Int(0)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+	typeID, err := cid.Int(2)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	f := models.TournamentFilter{
+		GuildID:  i.GuildID,
+		State:    cid.Arg(1),
+		TypeID:   typeID,
+		Upcoming: cid.Arg(3) == "1",
+		Member:   cid.Arg(4),
+	}
+
+	drafts := f.State == models.TOURNAMENT_DRAFT
+	if drafts {
+		if err := h.Base.Authorize(s, i, nil, models.ROLE_ORGANIZER); err != nil {
+			base.RespondError(err, s, i)
+			return
+		}
+	}
+
+	res, err := tournamentsPage(base.ReplyLocale(i, drafts), f, page)
+	if err != nil {
+		base.SendError(err, s, i)
+		return
+	}
+
+	router.Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: res,
+	})
+}
+
+// tournamentRow is how a tournament is listed, thread links are left out for drafts which have none
+func tournamentRow(loc i18n.Locale, l models.TournamentListing) string {
+	state := i18n.T(loc, fmt.Sprintf("tournaments.state.%s", l.State()))
+	row := i18n.N(loc, "tournaments.row", l.Cap(), l.Name, state, l.Entrants)
+	switch {
+	case l.Started_At.Valid && !l.Completed:
+		row += " · " + i18n.T(loc, "tournaments.started", l.Started_At.Int64)
+	case l.Starting_At.Valid && !l.Completed:
+		row += " · " + i18n.T(loc, "tournaments.starts", l.Starting_At.Int64)
+	}
+	if l.Thread_ID.Valid {
+		row += fmt.Sprintf(" · <#%s>", l.Thread_ID.String)
+	}
+	return row
+}
+
+func tournamentsPage(loc i18n.Locale, f models.TournamentFilter, page int) (*discordgo.InteractionResponseData, error) {
+	tm := models.NewTournamentsModel(database.GetDB())
+
+	count, err := tm.Count(f)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := (count + tournamentsPageSize - 1) / tournamentsPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+	if page > pages {
+		page = pages
+	}
+
+	listings, err := tm.List(f, tournamentsPageSize, (page-1)*tournamentsPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(listings))
+	for _, l := range listings {
+		lines = append(lines, tournamentRow(loc, l))
+	}
+
+	description := strings.Join(lines, "\n")
+	if len(listings) == 0 {
+		description = i18n.T(loc, "tournaments.empty")
+	}
+
+	upcoming := 0
+	if f.Upcoming {
+		upcoming = 1
+	}
+	pageID := func(page int) string {
+		return router.ID(ROUTE_TOURNAMENTS_PAGE, page, f.State, f.TypeID, upcoming, f.Member)
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.T(loc, "tournaments.title", count),
+				Description: description,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: i18n.T(loc, "common.page", page, pages),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(loc, "common.previous"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page <= 1,
+						CustomID: pageID(page - 1),
+					},
+					discordgo.Button{
+						Label:    i18n.T(loc, "common.next"),
+						Style:    discordgo.SecondaryButton,
+						Disabled: page >= pages,
+						CustomID: pageID(page + 1),
+					},
+				},
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}, nil
+}
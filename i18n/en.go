@@ -0,0 +1,581 @@
+package i18n
+
+var en = map[string]Message{
+	// shared
+	"locale.name":                     M("English"),
+	"error.internal":                  M("Something went wrong while executing this instruction"),
+	"error.creating_tournament":       M("Something went wrong while creating tournament"),
+	"error.generate_bracket":          M("Error occured when generating tournament bracket template"),
+	"error.get_tournament":            M("Cannot find this tournament record"),
+	"error.get_tournament_in_channel": M("Cannot find tournament in this channel, make sure to run this command inside the tournament channel"),
+	"error.get_tournament_types":      M("Cannot get the list of tournament types"),
+	"error.no_tournament_channel":     M("No tournament channel is configured for this server, set one with /config channel"),
+	"error.insufficient_permission":   M("Insufficent permission to use this command"),
+	"error.custom_id_outdated":        M("This button belongs to an older version of the bot, run the command again"),
+	"error.file_too_big":              M("File is too big, the limit is %d KB"),
+	"common.guild_only":               M("This command can only be used inside a server"),
+	"common.unknown_action":           M("Action not listed"),
+	"common.none":                     M("None"),
+	"common.not_set":                  M("Not set"),
+	"common.previous":                 M("Previous"),
+	"common.next":                     M("Next"),
+	"common.page":                     M("Page %d of %d"),
+	"common.and_more":                 M("…and %d more"),
+
+	// admin
+	"error.no_manager_role":                   M("Can't find tournament role"),
+	"command.ping.description":                M("Ping to get ponged"),
+	"ping.pong":                               M("Pong! %vms"),
+	"command.admin.description":               M("Manage permission to manage spade tournaments"),
+	"command.admin.add.description":           M("Add tournament admin role to a user"),
+	"command.admin.add.target.description":    M("Target User"),
+	"command.admin.remove.description":        M("Remove tournament admin role to a user"),
+	"command.admin.remove.target.description": M("Target User"),
+	"admin.invalid_user":                      M("Cannot add invalid user, use @user to properly target user"),
+	"admin.added":                             M("<@%s> is now tournament manager"),
+	"admin.removed":                           M("<@%s> is no longer tournament manager"),
+
+	// audit
+	"command.audit.description":           M("Browse the log of administrative actions"),
+	"command.audit.list.description":      M("Page through the audit log"),
+	"command.audit.list.page.description": M("Page to start from"),
+	"command.audit.export.description":    M("Export the whole audit log as csv"),
+	"audit.empty":                         M("No records to print"),
+	"audit.tournament":                    M("Tournament `%s`"),
+	"audit.description":                   M("Recorded administrative actions"),
+	"audit.none":                          M("No administrative actions recorded yet"),
+	"audit.title":                         M("Audit Log"),
+
+	// config
+	"command.config.description":                            M("Configure spade for this server"),
+	"command.config.show.description":                       M("Show the configuration of this server"),
+	"command.config.channel.description":                    M("Channel tournament threads are opened in"),
+	"command.config.channel.channel.description":            M("Tournament channel"),
+	"command.config.manager_role.description":               M("Role that is allowed to manage tournaments"),
+	"command.config.manager_role.role.description":          M("Manager role"),
+	"command.config.permission.description":                 M("Make the members of a role organizers or referees of every tournament"),
+	"command.config.permission.role.description":            M("Server role"),
+	"command.config.permission.permission.description":      M("Referees can only report results"),
+	"command.config.permission.permission.choice.organizer": M("Co-organizer"),
+	"command.config.permission.permission.choice.referee":   M("Referee"),
+	"command.config.permission.permission.choice.none":      M("None"),
+	"command.config.format.description":                     M("Format used by /create when no configuration is picked"),
+	"command.config.format.configuration.description":       M("Default tournament format"),
+	"command.config.timezone.description":                   M("Time zone start times and season dates are written in"),
+	"command.config.timezone.zone.description":              M("IANA time zone such as Asia/Jakarta"),
+	"command.config.locale.description":                     M("Language the bot replies in"),
+	"command.config.locale.locale.description":              M("Language"),
+	"command.config.color.description":                      M("Color of the embeds posted by the bot"),
+	"command.config.color.color.description":                M("Hex color such as #5865F2, leave empty to use the default"),
+	"config.unknown_timezone":                               M("Unknown time zone %q, use an IANA name such as Asia/Jakarta"),
+	"config.invalid_color":                                  M("Invalid color %q, use the #RRGGBB format"),
+	"tournament.format":                                     M("%v (%v players)"),
+	"role.none":                                             M("none"),
+	"role.referee":                                          M("referee"),
+	"role.organizer":                                        M("organizer"),
+	"role.owner":                                            M("owner"),
+	"role.manager":                                          M("manager"),
+	"settings.title":                                        M("Server Configuration"),
+	"settings.description":                                  M("Change a setting with /config"),
+	"settings.channel":                                      M("Tournament Channel"),
+	"settings.manager_role":                                 M("Manager Role"),
+	"settings.format":                                       M("Default Format"),
+	"settings.timezone":                                     M("Time Zone"),
+	"settings.locale":                                       M("Locale"),
+	"settings.color":                                        M("Embed Color"),
+	"settings.permissions":                                  M("Role Permissions"),
+
+	// leaderboard
+	"command.leaderboard.name":               M("leaderboard"),
+	"command.leaderboard.description":        M("Show the best players of this server"),
+	"command.leaderboard.by.description":     M("What players are ranked by (default rating)"),
+	"command.leaderboard.by.choice.rating":   M("rating"),
+	"command.leaderboard.by.choice.wins":     M("tournament wins"),
+	"command.leaderboard.by.choice.season":   M("season points"),
+	"command.leaderboard.season.description": M("Season to rank by points (default the current season)"),
+	"command.leaderboard.game.description":   M("Rank by the rating of a single game instead of every game"),
+	"command.leaderboard.page.description":   M("Page to start from"),
+	"season.not_found":                       M("Season does not exist, create one with /season create"),
+	"leaderboard.rating":                     M("Rating Leaderboard"),
+	"leaderboard.game_rating":                M("%s Rating Leaderboard"),
+	"leaderboard.rating_entry":               M("%.0f (%d-%d)"),
+	"leaderboard.wins":                       M("Tournament Wins Leaderboard"),
+	"leaderboard.wins_entry":                 M("%d won"),
+	"leaderboard.season":                     M("Season %s Leaderboard"),
+	"leaderboard.season_entry":               {One: "%d point, %d played, %d won", Other: "%d points, %d played, %d won"},
+	"leaderboard.empty":                      M("Nobody is ranked yet"),
+	"leaderboard.game_too_long":              M("The game name is too long to page through, use a shorter name"),
+
+	// notifications
+	"command.notify.name":                                M("notify"),
+	"command.notify.description":                         M("Choose which direct messages you receive"),
+	"command.notify.show.description":                    M("Show your notification preferences"),
+	"command.notify.set.description":                     M("Turn a notification on or off"),
+	"command.notify.set.type.description":                M("Notification to change"),
+	"command.notify.set.type.choice.all":                 M("All notifications"),
+	"command.notify.set.type.choice.match_ready":         M("Your match is ready"),
+	"command.notify.set.type.choice.opponent_checked_in": M("Your opponent checked in"),
+	"command.notify.set.type.choice.results":             M("You advanced or were eliminated"),
+	"command.notify.set.type.choice.starting_soon":       M("Tournament starting soon"),
+	"command.notify.set.enabled.description":             M("Receive the notification by direct message"),
+	"notification.all":                                   M("All notifications"),
+	"notification.match_ready":                           M("Your match is ready"),
+	"notification.opponent_checked_in":                   M("Your opponent checked in"),
+	"notification.results":                               M("You advanced or were eliminated"),
+	"notification.starting_soon":                         M("Tournament starting soon"),
+	"notification.on":                                    M("🔔 On"),
+	"notification.off":                                   M("🔕 Off"),
+	"notification.title":                                 M("Notifications"),
+	"notification.description":                           M("Direct messages you receive in every server, change them with /notify set"),
+	"notification.match_ready_message":                   M("⚔️ Your match #%d against **%s** in **%s** is ready: %s"),
+	"notification.opponent_checked_in_message":           M("✋ Your opponent checked in, join them in <#%s>"),
+	"notification.advanced_message":                      M("🎉 You beat **%s** and advance to the next round of **%s**"),
+	"notification.won_message":                           M("🏆 You beat **%s** and won **%s**!"),
+	"notification.eliminated_message":                    M("You lost to **%s** and were eliminated from **%s**, thanks for playing!"),
+	"notification.starting_soon_message":                 M("⏰ **%s** starts <t:%d:R>, see you in <#%s>!"),
+
+	// match
+	"match.thread_name":           M("Match #%d: %s vs %s"),
+	"match.thread_greeting":       M("%s\nCheck in once you are ready, then report the result here."),
+	"match.thread_opened":         M("Match #%d: **%s** vs **%s** is played in <#%s>"),
+	"match.thread_result":         M("Match #%d: **%s** won against **%s** in <#%s>"),
+	"match.already_over":          M("This match is already over."),
+	"match.check_in_players_only": M("Only the players of this match can check in."),
+	"match.already_checked_in":    M("You are already checked in."),
+	"match.both_ready":            M("Both sides are ready, the match can begin!"),
+	"match.both_ready_referee":    M("Both sides are ready, <@%s> the match can begin!"),
+
+	// matchup
+	"matchup.title":           M("Tournament Match #%d"),
+	"matchup.opponent":        M("Opponent %d"),
+	"matchup.captain":         M("%s (captain <@%s>)"),
+	"matchup.referee":         M("Referee"),
+	"matchup.check_in":        M("Check-in"),
+	"matchup.check_in_status": M("Opponent 1: %s\nOpponent 2: %s"),
+	"matchup.ready":           M("✅ ready"),
+	"matchup.waiting":         M("⏳ waiting"),
+	"matchup.not_available":   M("N/A"),
+
+	// start
+	"command.start.name":        M("start"),
+	"command.start.description": M("Start the tournament"),
+	"start.resumed":             M("Tournament has already been started, resuming with previous result."),
+	"start.started":             M("Tournament is now started"),
+	"start.auto_started":        M("Registration is closed and the tournament is now started"),
+	"start.auto_start_failed":   M("Registration is closed but the tournament could not be started automatically: %s"),
+	"start.already_completed":   M("Tournament has already been completed, use /restart to play it again"),
+	"start.not_enough_seeds":    M("Not enough seed to start the tournament"),
+	"start.minimum_seeds":       {One: "Can't start tournament, you need at least %d seeded player to start", Other: "Can't start tournament, you need at least %d seeded players to start"},
+	"start.short_rosters":       M("Can't start tournament, these teams need more players: %s"),
+	"match.check_in":            M("Check in"),
+	"match.wins":                M("%v Wins"),
+
+	// referee
+	"command.referee.name":                       M("referee"),
+	"command.referee.description":                M("Assign referees to the matches of the current tournament"),
+	"command.referee.assign.description":         M("Make a member the referee of a match, only they and managers can report its result"),
+	"command.referee.assign.match.description":   M("Match number as shown on the match embed"),
+	"command.referee.assign.member.description":  M("Referee of the match"),
+	"command.referee.clear.description":          M("Remove the referee of a match"),
+	"command.referee.clear.match.description":    M("Match number as shown on the match embed"),
+	"command.referee.rotate.description":         M("Rotate the tournament referees across live matches as they are posted"),
+	"command.referee.rotate.enabled.description": M("Whether new matches get a referee automatically"),
+	"referee.rotate_off":                         M("Referees are no longer assigned automatically."),
+	"referee.rotate_on":                          M("New matches are assigned to the tournament referees in turn, add referees with /staff add."),
+	"referee.match_not_found":                    M("Cannot find this match, matches are created when the tournament starts"),
+	"referee.match_played":                       M("This match has already been played"),
+	"referee.assigned":                           M("<@%s> is now the referee of match #%d"),
+	"referee.cleared":                            M("Match #%d no longer has a referee"),
+
+	// tournament
+	"tournament.already_published":            M("Tournament has already been published"),
+	"tournament.published":                    M("Tournament <#%s> is published to the public"),
+	"tournament.edit.title":                   M("Edit Tournament"),
+	"tournament.edit.name":                    M("Tournament Name"),
+	"tournament.edit.description":             M("Description"),
+	"tournament.edit.description_placeholder": M("Describe what's this tournament all about"),
+	"tournament.edit.best_of":                 M("Best Of"),
+	"tournament.edit.rules":                   M("Rules"),
+	"tournament.edit.rules_placeholder":       M("Rules if they are applicable"),
+	"tournament.edit.starting_at":             M("Start Time (%s)"),
+	"tournament.edit.starting_at_placeholder": M("YYYY-MM-DD HH:MM, leave empty to start by hand"),
+	"tournament.delete_channel_failed":        M("Cannot delete tournament channel while deleting tournament: %v"),
+	"tournament.deleted":                      M("Tournament successfully deleted"),
+	"match.referee_only":                      M("Only <@%s> or a manager can report the result of this match"),
+
+	// profile
+	"podium.title":            M("%s Results"),
+	"podium.description":      M("The tournament is over, congratulations to everyone who took part!"),
+	"podium.more_players":     {One: "and %d more player", Other: "and %d more players"},
+	"profile.win":             M("W"),
+	"profile.loss":            M("L"),
+	"profile.record":          M("%dW - %dL"),
+	"profile.match_line":      M("**%s** %d-%d vs %s, %s round %d"),
+	"profile.walkover":        M("(walkover)"),
+	"profile.unrated":         M("Unrated"),
+	"profile.placement":       M("%s in %s"),
+	"profile.opponent":        M("%[2]s, %[1]d played (%[3]s)"),
+	"profile.title":           M("Profile of %s"),
+	"profile.tournaments":     M("Tournaments"),
+	"profile.record_title":    M("Record"),
+	"profile.rating":          M("Rating"),
+	"profile.best_placements": M("Best Placements"),
+	"profile.no_placements":   M("No finished tournament yet"),
+	"profile.recent_matches":  M("Recent Matches"),
+	"profile.no_matches":      M("No match played yet"),
+	"profile.opponents":       M("Most Played Opponents"),
+	"profile.no_opponents":    M("No opponent yet"),
+	"profile.older_matches":   {One: "...and %d older match", Other: "...and %d older matches"},
+	"profile.matches":         M("Matches"),
+	"profile.never_met":       M("These players have never met"),
+	"rating.all_games":        M("All games"),
+	"rating.title":            M("Rating of %s"),
+	"rating.game":             M("Game"),
+	"rating.volatility":       M("Volatility %.4f"),
+
+	// rating
+	"command.profile.name":                   M("profile"),
+	"command.profile.description":            M("Show the tournament history of a player"),
+	"command.profile.player.description":     M("Player to show the profile of (default yourself)"),
+	"command.h2h.description":                M("Show every match played between two players"),
+	"command.h2h.a.description":              M("First player"),
+	"command.h2h.b.description":              M("Second player (default yourself)"),
+	"profile.same_players":                   M("Pick two different players"),
+	"profile.not_played":                     M("%s has not played any tournament yet"),
+	"command.rating.description":             M("Player ratings of this server"),
+	"command.rating.show.description":        M("Show the rating of a player"),
+	"command.rating.show.player.description": M("Player to show the rating of (default yourself)"),
+	"command.rating.show.game.description":   M("Show the rating of a single game instead of every game"),
+	"command.rating.rebuild.description":     M("Recompute every rating of this server from the match history"),
+	"rating.rebuilt":                         {One: "Ratings have been rebuilt from %d match", Other: "Ratings have been rebuilt from %d matches"},
+	"rating.unrated":                         M("%s has no rated matches yet"),
+
+	// import and export
+	"command.import.description":                          M("Import tournament data from a file"),
+	"command.import.tournament.description":               M("Rebuild a tournament from a JSON export"),
+	"command.import.tournament.file.description":          M("JSON file created by /export"),
+	"command.import.participants.description":             M("Register the players listed in a CSV file to the current tournament"),
+	"command.import.participants.file.description":        M("CSV file with the name,discord_id,current_seat columns"),
+	"command.import.participants.apply_seats.description": M("Seat the players by the current_seat column (default true)"),
+	"command.export.description":                          M("Export the tournament participants, results or the whole tournament"),
+	"command.export.type.description":                     M("Type of list you want to export"),
+	"command.export.type.choice.0":                        M("participants"),
+	"command.export.type.choice.1":                        M("seed"),
+	"command.export.type.choice.2":                        M("tournament (json)"),
+	"command.export.type.choice.3":                        M("results"),
+	"command.export.type.choice.4":                        M("matches"),
+	"command.export.profile.description":                  M("Column layout of the results and matches export"),
+	"command.export.profile.choice.default":               M("default"),
+	"import.started":                                      M("Participants can't be imported once the tournament has been started"),
+	"import.team_tournament":                              M("Participants of team tournaments register through /team create"),
+	"import.attach_csv":                                   M("Attach a CSV file with the name,discord_id,current_seat columns"),
+	"import.attach_json":                                  M("Attach the JSON file created by /export"),
+	"import.seat_taken":                                   M("seat %d is taken by %s"),
+	"import.row_error":                                    M("line %d: %s"),
+	"import.dry_run":                                      M("**Dry run of %s**"),
+	"import.new_attendees":                                {One: "%d new attendee", Other: "%d new attendees"},
+	"import.existing_attendees":                           M("%d already registered"),
+	"import.seats_to_apply":                               {One: "%d seat to apply", Other: "%d seats to apply"},
+	"import.rows_with_errors":                             {One: "%d row with errors", Other: "%d rows with errors"},
+	"import.nothing":                                      M("Nothing to import."),
+	"import.over_cap":                                     {One: "Nothing to import, the tournament takes up to %d player and %d are already registered.", Other: "Nothing to import, the tournament takes up to %d players and %d are already registered."},
+	"import.errors_skipped":                               M("Rows with errors are skipped when the import is confirmed."),
+	"import.expired":                                      M("This dry run has expired, run /import again"),
+	"import.cancelled":                                    M("Import cancelled"),
+	"import.imported":                                     {One: "Added %d player to the tournament and applied %d seats.", Other: "Added %d players to the tournament and applied %d seats."},
+	"import.restored":                                     {One: "Imported %d attendee and %d matches, publish the tournament to continue it here.", Other: "Imported %d attendees and %d matches, publish the tournament to continue it here."},
+	"import.unsupported_type":                             M("Tournament type of %d %s players is not supported"),
+	"export.team_archive":                                 M("Team tournaments can't be exported as an archive"),
+	"export.participants_failed":                          M("Cannot extract participants list"),
+	"export.empty":                                        M("No records to print"),
+	"export.no_results":                                   M("Results are available once the final has been played"),
+	"export.not_started":                                  M("Tournament has not been started yet"),
+	"common.confirm":                                      M("Confirm"),
+	"common.cancel":                                       M("Cancel"),
+	"mentions.not_a_user":                                 M("`%s` is not a mention or a user id"),
+	"mentions.listed_twice":                               M("<@%s> is listed more than once"),
+	"archive.invalid":                                     M("Invalid tournament archive: %v"),
+	"archive.unsupported_version":                         M("Unsupported archive version %d, this bot reads up to version %d"),
+	"archive.missing_name":                                M("Archive is missing the tournament name"),
+	"archive.missing_discord_id":                          M("Archive has an attendee without discord id"),
+	"archive.duplicate_attendee":                          M("Attendee %s is listed more than once"),
+	"archive.duplicate_match":                             M("Match %d is listed more than once"),
+	"archive.unknown_attendee":                            M("Match %d refers to %s who is not an attendee"),
+	"participants.empty":                                  M("File is empty"),
+	"participants.invalid":                                M("Invalid CSV file: %v"),
+	"participants.header":                                 M("Header has to be %s"),
+	"participants.missing_discord_id":                     M("missing discord id"),
+	"participants.missing_name":                           M("missing player name"),
+	"participants.not_a_discord_id":                       M("%q is not a discord id"),
+	"participants.duplicate":                              M("player is already listed on line %d"),
+	"participants.not_a_seat":                             M("%q is not a seat"),
+	"participants.not_a_starting_seat":                    M("seat %d is not a starting seat of this bracket"),
+	"participants.seat_taken":                             M("seat %d is already taken on line %d"),
+	"season.tier_format":                                  M("Tier %q has to be written as place=points"),
+	"season.tier_place":                                   M("Place of tier %q has to be a positive number"),
+	"season.tier_points":                                  M("Points of tier %q can't be negative"),
+	"season.tier_order":                                   M("Tiers have to be ordered from the best place"),
+	"season.tier_first":                                   M("Tiers have to start from the first place"),
+
+	// season
+	"command.season.description":               M("Manage the seasons of this server"),
+	"command.season.create.description":        M("Create a new season"),
+	"command.season.create.name.description":   M("Name of the season"),
+	"command.season.create.start.description":  M("First day of the season in the server time zone (YYYY-MM-DD)"),
+	"command.season.create.end.description":    M("Last day of the season in the server time zone (YYYY-MM-DD)"),
+	"command.season.create.points.description": M("Points per placement as place=points"),
+	"command.season.points.description":        M("Change the points awarded per placement"),
+	"command.season.points.name.description":   M("Name of the season"),
+	"command.season.points.points.description": M("Points per placement as place=points, such as 1=100,2=70,3=50"),
+	"command.season.list.description":          M("List the seasons of this server"),
+	"command.season.archive.description":       M("Close the season and keep its final standings"),
+	"command.season.archive.name.description":  M("Name of the season"),
+	"season.start_format":                      M("Start has to be written as YYYY-MM-DD"),
+	"season.end_format":                        M("End has to be written as YYYY-MM-DD"),
+	"season.end_before_start":                  M("Season can't end before it starts"),
+	"season.exists":                            M("Season %s already exists"),
+	"season.created":                           M("Season %s runs from <t:%d:D> to <t:%d:D>, points per placement: %s"),
+	"season.archived":                          M("Season %s has been archived"),
+	"season.archived_winner":                   {One: "Season %[2]s has been archived, congratulations to <@%[3]s> for finishing first with %[1]d point", Other: "Season %[2]s has been archived, congratulations to <@%[3]s> for finishing first with %[1]d points"},
+	"season.points_updated":                    M("Points per placement of season %s are now %s"),
+	"season.already_archived":                  M("Season %s has already been archived"),
+	"season.none":                              M("No seasons have been created yet"),
+	"season.period":                            M("<t:%d:D> to <t:%d:D>\nPoints: %s"),
+	"season.archived_label":                    M("Archived"),
+	"season.title":                             M("Seasons"),
+	"season.named_not_found":                   M("Season %s does not exist"),
+
+	// configuration
+	"configuration.title":                 M("Configuration"),
+	"configuration.subtitle":              M("Available configuration for your tournament"),
+	"configuration.name":                  M("Name"),
+	"configuration.description":           M("Description"),
+	"configuration.rules":                 M("Rules"),
+	"configuration.registration":          M("Registration"),
+	"configuration.registration_open":     M("Open to everyone"),
+	"configuration.registration_managers": M("Managers only"),
+	"configuration.player_cap":            M("Player Cap"),
+	"configuration.team_cap":              M("Team Cap"),
+	"configuration.best_of":               M("Best Of"),
+	"configuration.bracket_type":          M("Bracket Type"),
+	"configuration.single_elimination":    M("Single Elimination"),
+	"configuration.teams":                 M("Teams"),
+	"configuration.team_size":             {One: "%d player, up to %d per roster", Other: "%d players, up to %d per roster"},
+	"configuration.starts":                M("Starts"),
+	"configuration.owner":                 M("Owner"),
+	"configuration.check_in":              M("Check-in"),
+	"configuration.check_in_opens":        {One: "Opens %d minute before the start", Other: "Opens %d minutes before the start"},
+	"configuration.matches":               M("Matches"),
+	"configuration.match_threads":         M("Played in a private thread per match"),
+
+	// create
+	"command.create.name":                       M("create"),
+	"command.create.description":                M("Create a tournament"),
+	"command.create.configurations.description": M("Select pre configured tournament settings"),
+	"command.create.template.description":       M("Create the tournament from a saved template"),
+	"command.create.self_register.description":  M("Allow players to register themselves (default true)"),
+	"command.create.game.description":           M("Game that is played, players get a separate rating per game"),
+	"command.create.team_size.description":      M("Players per team, leave empty for a solo tournament"),
+	"command.create.roster_limit.description":   M("Most players a team can have, substitutes included (default the team size)"),
+	"command.create.starting_at.description":    M("Start the tournament automatically at YYYY-MM-DD HH:MM in the server time zone"),
+	"command.create.check_in.description":       M("Minutes before the start players have to check in, no-shows lose their spot"),
+	"command.create.match_threads.description":  M("Play every match in a private thread with its players and referees"),
+	"create.default_name":                       M("New Tournament"),
+	"create.no_configuration":                   M("Select either a configuration or a template to create the tournament from"),
+	"create.roster_limit":                       M("Roster limit can't be lower than the team size"),
+	"create.check_in_without_start":             M("Check-in needs a start time, set starting_at as well"),
+	"template.not_found":                        M("Cannot find template named %s"),
+	"tournament.publish":                        M("Publish"),
+	"tournament.edit":                           M("Edit"),
+	"tournament.delete":                         M("Delete"),
+	"tournament.best_of_odd":                    M("Best of must be a positive odd number"),
+	"tournament.invalid_starting_at":            M("Invalid start time %q, use the YYYY-MM-DD HH:MM format"),
+	"tournament.starting_at_past":               M("Start time has to be in the future"),
+	"tournament.reminder":                       M("⏰ **%s** starts <t:%d:R>, make sure you are ready!"),
+
+	// delete, restart, seed and status
+	"command.delete.name":                   M("delete"),
+	"command.delete.description":            M("Delete current tournament"),
+	"command.delete.id.description":         M("Tournament to delete (default the tournament of this thread)"),
+	"command.restart.description":           M("Restart the tournament"),
+	"restart.failed":                        M("Something went wrong while restarting the tournament."),
+	"restart.restarted":                     M("Tournament has been restarted, use /start to start again."),
+	"command.seed.description":              M("Define tournament seeds by order ascending order"),
+	"command.seed.players.description":      M("Mentions or user ids of the attendees from the best seed to the worst"),
+	"seed.empty":                            M("You need atleast seed 1 player to this tournament"),
+	"seed.insufficient_permission":          M("Insufficent permission to seed other players to this tournament."),
+	"seed.unregistered":                     M("%s not registered to this tournament"),
+	"seed.seeded":                           {One: "Successfully seeded %d player", Other: "Successfully seeded %d players"},
+	"command.status.description":            M("Show the live status of a tournament"),
+	"command.status.tournament.description": M("Tournament to show (default the tournament of this thread)"),
+	"status.not_started":                    M("Not started yet"),
+	"status.completed":                      M("🏁 Completed"),
+	"status.round":                          M("Round %d of %d"),
+	"status.upcoming":                       M("R%d: %s"),
+	"status.live":                           M("Live Matches"),
+	"status.up_next":                        M("Up Next"),
+	"status.recent":                         M("Recent Results"),
+	"status.remaining":                      M("Remaining (%d)"),
+	"status.updated":                        M("Last updated"),
+
+	// register
+	"command.register.name":                M("register"),
+	"command.register.description":         M("Register a user to current tournament"),
+	"command.register.players.description": M("Mentions or user ids of the players to register (default yourself)"),
+	"register.already_registered":          M("You are already registered to this tournament."),
+	"register.insufficient_permission":     M("Insufficent permission to add other players to this tournament."),
+	"register.closed":                      M("Registration for this tournament is closed."),
+	"register.team_tournament":             M("This is a team tournament, register a team with /team create."),
+	"register.self_register_disabled":      M("Self registration is disabled for this tournament, ask a tournament manager to register you."),
+	"register.added":                       {One: "Added %d player to the tournament.", Other: "Added %d players to the tournament."},
+	"register.waitlisted":                  {One: "The tournament is full, %d player has been put on the waitlist.", Other: "The tournament is full, %d players have been put on the waitlist."},
+
+	// team
+	"command.team.name":                      M("team"),
+	"command.team.description":               M("Register and manage your team in the current team tournament"),
+	"command.team.create.description":        M("Register a new team and become its captain"),
+	"command.team.create.name.description":   M("Name of the team"),
+	"command.team.invite.description":        M("Invite a player to your team, only the captain can invite"),
+	"command.team.invite.player.description": M("Player to invite"),
+	"command.team.join.description":          M("Join a team you have been invited to"),
+	"command.team.join.name.description":     M("Name of the team"),
+	"command.team.roster.description":        M("List the teams and their players"),
+	"team.solo_tournament":                   M("This is a solo tournament, register with /register instead"),
+	"team.started":                           M("Teams can't be changed once the tournament has been started"),
+	"team.self_register_disabled":            M("Self registration is disabled for this tournament, ask a tournament manager to register your team."),
+	"team.empty_name":                        M("Team name can't be empty"),
+	"team.exists":                            M("Team %s already exists"),
+	"team.full":                              M("The tournament is full, it takes up to %d teams"),
+	"team.already_playing":                   M("You are already playing for %s"),
+	"team.created":                           M("Team **%s** has been registered with <@%s> as captain, invite your teammates with /team invite"),
+	"team.bot":                               M("Bots can't be invited to a team"),
+	"team.other_playing":                     M("%s is already playing for %s"),
+	"team.roster_full":                       M("Your roster is full, teams can have up to %d players"),
+	"team.invited":                           M("<@%s>, you have been invited to join **%s**, accept with `/team join %s`"),
+	"team.not_found":                         M("Team %s does not exist"),
+	"team.not_invited":                       M("You have not been invited to %s"),
+	"team.team_full":                         M("%s is full, teams can have up to %d players"),
+	"team.joined":                            M("%s joined **%s** (%d/%d)"),
+	"team.none":                              M("No team has registered yet"),
+	"team.captain":                           M("(captain)"),
+	"team.roster_title":                      M("%s Teams"),
+	"team.roster_description":                {One: "Teams play with %d player", Other: "Teams play with %d players"},
+	"team.captains_only":                     M("Only team captains can invite players, create a team with /team create"),
+
+	// staff
+	"command.staff.description":               M("Manage the co-organizers and referees of the current tournament"),
+	"command.staff.add.description":           M("Make a member co-organizer or referee, co-organizers are picked by the owner"),
+	"command.staff.add.member.description":    M("Server member"),
+	"command.staff.add.role.description":      M("Referees can only report results"),
+	"command.staff.remove.description":        M("Take the role of a member away"),
+	"command.staff.remove.member.description": M("Server member"),
+	"command.staff.list.description":          M("List the owner and staff of the tournament"),
+	"staff.organizer":                         M("Co-organizer"),
+	"staff.referee":                           M("Referee"),
+	"staff.owner":                             M("Owner"),
+	"staff.added":                             M("<@%s> is now %s of this tournament"),
+	"staff.not_staff":                         M("<@%s> is not part of the staff of this tournament"),
+	"staff.removed":                           M("<@%s> is no longer part of the staff of this tournament"),
+	"staff.none":                              M("This tournament has no staff, it is run by the tournament managers."),
+
+	// template
+	"command.template.description":                   M("Manage reusable tournament templates"),
+	"command.template.save.description":              M("Save the configuration of the current tournament as a template"),
+	"command.template.save.name.description":         M("Template name, saving with an existing name overwrites it"),
+	"command.template.save.name_pattern.description": M("Name of the created tournaments, {n} is the edition and {date} the creation date"),
+	"command.template.save.id.description":           M("Tournament to save (default the tournament of this thread)"),
+	"command.template.list.description":              M("List the saved templates"),
+	"template.saved":                                 M("Saved template **%s**, use `/create template:%s` to create a tournament from it"),
+	"template.none":                                  M("No templates saved yet, use `/template save` inside a tournament thread to save one"),
+	"template.summary":                               {One: "%[2]s, best of %[3]d, used %[1]d time", Other: "%[2]s, best of %[3]d, used %[1]d times"},
+	"template.title":                                 M("Tournament Templates"),
+
+	// recurrence
+	"command.recurrence.description":                       M("Repeat the current tournament on a weekly schedule"),
+	"command.recurrence.set.description":                   M("Host a new edition of this tournament every week"),
+	"command.recurrence.set.weekday.description":           M("Day of the week the tournament is held"),
+	"command.recurrence.set.time.description":              M("Time the tournament is held in 24 hour HH:MM format"),
+	"command.recurrence.set.timezone.description":          M("IANA time zone such as Asia/Jakarta (default the server time zone)"),
+	"command.recurrence.set.open_before_hours.description": M("Hours before the tournament the next edition is published (default 24)"),
+	"command.recurrence.set.carry_seeding.description":     M("Seed the next edition by the results of the previous one"),
+	"command.recurrence.set.name_pattern.description":      M("Name of the editions, {n} is the edition and {date} the tournament date"),
+	"command.recurrence.show.description":                  M("Show the schedule of this tournament"),
+	"command.recurrence.stop.description":                  M("Stop creating new editions of this tournament"),
+	"weekday.0":                                            M("Sunday"),
+	"weekday.1":                                            M("Monday"),
+	"weekday.2":                                            M("Tuesday"),
+	"weekday.3":                                            M("Wednesday"),
+	"weekday.4":                                            M("Thursday"),
+	"weekday.5":                                            M("Friday"),
+	"weekday.6":                                            M("Saturday"),
+	"common.yes":                                           M("yes"),
+	"common.no":                                            M("no"),
+	"recurrence.not_recurring":                             M("This tournament is not recurring"),
+	"recurrence.show":                                      {One: "Every %[2]s at %[3]s (%[4]s), editions are published %[1]d hour before.\nCurrent edition: #%[5]d\nCarry over seeding: %[6]s", Other: "Every %[2]s at %[3]s (%[4]s), editions are published %[1]d hours before.\nCurrent edition: #%[5]d\nCarry over seeding: %[6]s"},
+	"recurrence.stopped":                                   M("No new editions of this tournament will be created"),
+	"recurrence.invalid_time":                              M("Invalid time %q, use the 24 hour HH:MM format"),
+	"recurrence.set":                                       M("Tournament now repeats every %s at %s (%s). The next edition is held <t:%d:F> and opens <t:%d:R>."),
+	"recurrence.opened":                                    M("Registration is now open! This edition starts <t:%d:F>, use `/register` to join."),
+
+	// signup and check-in
+	"signup.title":                  M("Sign Up"),
+	"signup.description":            M("Press **Join** to take part in %s, spots are given first come first served."),
+	"signup.closed":                 M("Registration is closed."),
+	"signup.players":                M("Players"),
+	"signup.waitlist":               M("Waitlist"),
+	"signup.registered":             M("Registered"),
+	"signup.waiting":                M("Waiting"),
+	"signup.more":                   M(" and %d more"),
+	"signup.join":                   M("Join"),
+	"signup.leave":                  M("Leave"),
+	"signup.registration_closed":    M("Registration for this tournament is closed."),
+	"signup.self_register_disabled": M("Self registration is disabled for this tournament, ask a tournament manager to register you."),
+	"signup.already_registered":     M("You are already registered to this tournament."),
+	"signup.not_registered":         M("You are not registered to this tournament."),
+	"signup.joined":                 M("You are now registered to the tournament."),
+	"signup.waitlisted":             M("The tournament is full, you have been put on the waitlist and will get a message once a spot opens up."),
+	"signup.left":                   M("You have left the tournament."),
+	"signup.promoted":               M("A spot opened up in **%s**, you have been moved from the waitlist into the tournament."),
+	"signup.promoted_details":       M("See <#%s> for the details."),
+	"check_in.title":                M("Check In"),
+	"check_in.description":          M("Press **Check in** to confirm you are playing in %s."),
+	"check_in.closes":               M("Check-in closes <t:%d:R>, anyone who has not checked in by then loses their spot."),
+	"check_in.closed":               M("Check-in is closed."),
+	"check_in.checked_in":           M("Checked In"),
+	"check_in.waitlist_ready":       M("Waitlist Ready"),
+	"check_in.missing":              M("Missing"),
+	"check_in.button":               M("Check in"),
+	"check_in.window_closed":        M("Check-in for this tournament is closed."),
+	"check_in.captains_only":        M("Only team captains can check their team in."),
+	"check_in.done":                 M("You are checked in, good luck!"),
+	"check_in.done_waitlist":        M("You are checked in from the waitlist, you will get a spot if someone does not show up."),
+	"check_in.dropped":              {One: "Removed %d attendee who did not check in: %s", Other: "Removed %d attendees who did not check in: %s"},
+	"check_in.promoted":             {One: "%d player has been moved in from the waitlist.", Other: "%d players have been moved in from the waitlist."},
+
+	// tournaments
+	"command.tournaments.name":                    M("tournaments"),
+	"command.tournaments.description":             M("List the tournaments of this server"),
+	"command.tournaments.status.description":      M("Only list tournaments in this state"),
+	"command.tournaments.status.choice.draft":     M("draft"),
+	"command.tournaments.status.choice.open":      M("open for registration"),
+	"command.tournaments.status.choice.running":   M("running"),
+	"command.tournaments.status.choice.completed": M("completed"),
+	"command.tournaments.format.description":      M("Only list tournaments of this format"),
+	"command.tournaments.upcoming.description":    M("Only list tournaments planned to start later"),
+	"command.tournaments.mine.description":        M("Only list tournaments you own, staff or play in"),
+	"tournaments.state.draft":                     M("draft"),
+	"tournaments.state.open":                      M("open for registration"),
+	"tournaments.state.running":                   M("running"),
+	"tournaments.state.completed":                 M("completed"),
+	"tournaments.row":                             {One: "**%[2]s** · %[3]s · %[4]d/%[1]d entrant", Other: "**%[2]s** · %[3]s · %[4]d/%[1]d entrants"},
+	"tournaments.started":                         M("started <t:%d:R>"),
+	"tournaments.starts":                          M("starts <t:%d:R>"),
+	"tournaments.empty":                           M("No tournament matches these filters"),
+	"tournaments.title":                           M("Tournaments (%d)"),
+
+	// match scores
+	"match.score_title":   M("Report Result"),
+	"match.score_label":   M("Score, games of the winner first"),
+	"match.score_format":  M("Score %q has to be written as the games of the winner and the loser, such as 2-1"),
+	"match.score_invalid": M("Score %q doesn't fit a best of %d, the winner takes %d games and the loser fewer"),
+}
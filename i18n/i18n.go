@@ -0,0 +1,285 @@
+// Package i18n translates the text members read. Every language has a catalog of messages by key,
+// keys missing from a catalog fall back to english so a partial translation never shows a key.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type Locale string
+
+const (
+	EN Locale = "en"
+	ID Locale = "id"
+
+	DEFAULT = EN
+)
+
+// Locales are the languages the bot speaks, in the order they are offered
+var Locales = []Locale{EN, ID}
+
+// discordLocales are the discord languages every locale answers, the first one is used for the
+// localizations of the slash commands. English is the default of the commands and is not localized.
+var discordLocales = map[Locale][]discordgo.Locale{
+	EN: {discordgo.EnglishUS, discordgo.EnglishGB},
+	ID: {discordgo.Locale("id")},
+}
+
+// Message is a translated text, One is used for a count of one in the languages that tell it apart
+// from other counts and defaults to Other
+type Message struct {
+	One   string
+	Other string
+}
+
+// M is a message that reads the same whatever the count
+func M(text string) Message {
+	return Message{Other: text}
+}
+
+var catalogs = map[Locale]map[string]Message{
+	EN: en,
+	ID: id,
+}
+
+// Parse returns the locale of a setting value or discord language, the second value is false when
+// the bot does not speak it
+func Parse(v string) (Locale, bool) {
+	for _, l := range Locales {
+		if v == string(l) {
+			return l, true
+		}
+		for _, dl := range discordLocales[l] {
+			if v == string(dl) {
+				return l, true
+			}
+		}
+	}
+	return DEFAULT, false
+}
+
+// Name is how the locale is called in its own language
+func (l Locale) Name() string {
+	return T(l, "locale.name")
+}
+
+func lookup(l Locale, key string) (Message, bool) {
+	if m, ok := catalogs[l][key]; ok {
+		return m, true
+	}
+	m, ok := catalogs[DEFAULT][key]
+	return m, ok
+}
+
+// T translates the message, args fill the verbs of the message like fmt.Sprintf. Unknown keys are
+// returned as is.
+func T(l Locale, key string, args ...interface{}) string {
+	m, ok := lookup(l, key)
+	if !ok {
+		return key
+	}
+	return format(m.Other, args)
+}
+
+// Matches reports whether text is the message of key in any language
+func Matches(key, text string) bool {
+	for _, l := range Locales {
+		if m, ok := catalogs[l][key]; ok && m.Other == text {
+			return true
+		}
+	}
+	return false
+}
+
+// N translates the message in the form that fits the count, the count is the first argument of the
+// message
+func N(l Locale, key string, count int, args ...interface{}) string {
+	m, ok := lookup(l, key)
+	if !ok {
+		return key
+	}
+
+	text := m.Other
+	if plural(l, count) == formOne && m.One != "" {
+		text = m.One
+	}
+	return format(text, append([]interface{}{count}, args...))
+}
+
+func format(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+type form int
+
+const (
+	formOne form = iota
+	formOther
+)
+
+// plural picks the form of a count following the plural rules of the language
+func plural(l Locale, count int) form {
+	switch l {
+	case ID:
+		// indonesian nouns don't change with the count
+		return formOther
+	}
+	if count == 1 {
+		return formOne
+	}
+	return formOther
+}
+
+// Ordinal is how the position n is written, such as 3rd in english
+func Ordinal(l Locale, n int) string {
+	switch l {
+	case ID:
+		return fmt.Sprintf("ke-%d", n)
+	}
+
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// Error is an error members read, its text is translated into the language of whoever reads it
+type Error struct {
+	Key  string
+	Args []interface{}
+	// Count picks the plural form of the message, nil for messages that don't have one
+	Count *int
+}
+
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+// NewCountError is an error whose message is in the form that fits the count, see N
+func NewCountError(key string, count int, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args, Count: &count}
+}
+
+// Error is the english text, used in the logs
+func (e *Error) Error() string {
+	return e.In(DEFAULT)
+}
+
+func (e *Error) In(l Locale) string {
+	if e.Count != nil {
+		return N(l, e.Key, *e.Count, e.Args...)
+	}
+	return T(l, e.Key, e.Args...)
+}
+
+// Join returns an error listing every error on its own line
+func Join(errs ...*Error) error {
+	return joined(errs)
+}
+
+type joined []*Error
+
+func (j joined) Error() string {
+	return j.In(DEFAULT)
+}
+
+func (j joined) In(l Locale) string {
+	lines := make([]string, len(j))
+	for idx, e := range j {
+		lines[idx] = e.In(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Text translates err when it is an Error or joins them, other errors are shown as they are
+func Text(l Locale, err error) string {
+	var e interface{ In(Locale) string }
+	if errors.As(err, &e) {
+		return e.In(l)
+	}
+	return err.Error()
+}
+
+// LocalizeCommand fills the localizations of the command, its options and their choices from the
+// catalogs. The keys follow the path of the option, such as command.create.game.description, and
+// choices are found by their value, such as command.tournaments.status.choice.running. Choices that
+// already have localizations, usually because they are built from the database, are kept as they are.
+func LocalizeCommand(cmd *discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	if cmd == nil {
+		return nil
+	}
+	key := "command." + cmd.Name
+
+	names, descriptions := localizations(key)
+	if len(names) > 0 {
+		cmd.NameLocalizations = &names
+	}
+	if len(descriptions) > 0 {
+		cmd.DescriptionLocalizations = &descriptions
+	}
+	localizeOptions(key, cmd.Options)
+	return cmd
+}
+
+func localizeOptions(parent string, options []*discordgo.ApplicationCommandOption) {
+	for _, opt := range options {
+		key := parent + "." + opt.Name
+		opt.NameLocalizations, opt.DescriptionLocalizations = localizations(key)
+		for _, choice := range opt.Choices {
+			if choice.NameLocalizations == nil {
+				choice.NameLocalizations = translations(fmt.Sprintf("%s.choice.%v", key, choice.Value))
+			}
+		}
+		localizeOptions(key, opt.Options)
+	}
+}
+
+// localizations returns the translations of the name and the description of key, names are lower
+// case as discord requires
+func localizations(key string) (names, descriptions map[discordgo.Locale]string) {
+	names = translations(key + ".name")
+	for dl, name := range names {
+		names[dl] = strings.ToLower(name)
+	}
+	return names, translations(key + ".description")
+}
+
+// Localized returns the message of key in every language but english, for the names of command
+// choices that are not known up front
+func Localized(key string, args ...interface{}) map[discordgo.Locale]string {
+	localized := translations(key)
+	for dl, text := range localized {
+		localized[dl] = format(text, args)
+	}
+	return localized
+}
+
+// translations returns the message of key in every language but english, languages without a
+// translation are left out so discord shows the english text
+func translations(key string) map[discordgo.Locale]string {
+	translated := make(map[discordgo.Locale]string)
+	for _, l := range Locales {
+		if l == DEFAULT {
+			continue
+		}
+		if m, ok := catalogs[l][key]; ok {
+			translated[discordLocales[l][0]] = m.Other
+		}
+	}
+	return translated
+}
@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCatalogsMatch(t *testing.T) {
+	for key := range en {
+		if _, ok := id[key]; !ok {
+			t.Errorf("%s is missing from id", key)
+		}
+	}
+	for key := range id {
+		if _, ok := en[key]; !ok {
+			t.Errorf("%s is in id but not in en", key)
+		}
+	}
+}
+
+var verb = regexp.MustCompile(`%(\[\d+\])?[-+# 0-9.]*[a-zA-Z]`)
+
+// TestCatalogsArgs checks translations use as many arguments as the english text, a missing one
+// shows up as %!(EXTRA ...) in the middle of a message
+func TestCatalogsArgs(t *testing.T) {
+	for key, m := range en {
+		want := len(verb.FindAllString(m.Other, -1))
+		for _, text := range []string{id[key].One, id[key].Other} {
+			if text == "" {
+				continue
+			}
+			if got := len(verb.FindAllString(text, -1)); got != want {
+				t.Errorf("%s has %d arguments in id, want %d", key, got, want)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	en["test.only_en"] = M("only %s")
+	defer delete(en, "test.only_en")
+
+	if got := T(ID, "test.only_en", "english"); got != "only english" {
+		t.Errorf("fallback to english = %q", got)
+	}
+	if got := T(ID, "test.unknown"); got != "test.unknown" {
+		t.Errorf("unknown key = %q", got)
+	}
+	if got := T(ID, "common.yes"); got != "ya" {
+		t.Errorf("T(id, common.yes) = %q", got)
+	}
+}
+
+func TestN(t *testing.T) {
+	en["test.players"] = Message{One: "%d player", Other: "%d players"}
+	id["test.players"] = M("%d pemain")
+	defer delete(en, "test.players")
+	defer delete(id, "test.players")
+
+	tests := []struct {
+		l     Locale
+		count int
+		want  string
+	}{
+		{EN, 0, "0 players"},
+		{EN, 1, "1 player"},
+		{EN, 2, "2 players"},
+		{ID, 1, "1 pemain"},
+		{ID, 2, "2 pemain"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.l, "test.players", tt.count); got != tt.want {
+			t.Errorf("N(%s, %d) = %q, want %q", tt.l, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		v    string
+		want Locale
+		ok   bool
+	}{
+		{"en", EN, true},
+		{"en-GB", EN, true},
+		{"id", ID, true},
+		{"fr", DEFAULT, false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.v)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.v, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 112: "112th"} {
+		if got := Ordinal(EN, n); got != want {
+			t.Errorf("Ordinal(en, %d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := Ordinal(ID, 3); got != "ke-3" {
+		t.Errorf("Ordinal(id, 3) = %q", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewError("common.yes"))
+	if got := Text(ID, err); got != "ya" {
+		t.Errorf("Text of a wrapped error = %q", got)
+	}
+	if got := Text(ID, errors.New("plain")); got != "plain" {
+		t.Errorf("Text of a plain error = %q", got)
+	}
+
+	joined := Join(NewError("common.yes"), NewError("common.no"))
+	if got := Text(ID, joined); got != "ya\ntidak" {
+		t.Errorf("Text of joined errors = %q", got)
+	}
+	if got := joined.Error(); got != "yes\nno" {
+		t.Errorf("Error of joined errors = %q", got)
+	}
+}
+
+func TestLocalizeCommand(t *testing.T) {
+	cmd := LocalizeCommand(&discordgo.ApplicationCommand{
+		Name:        "tournaments",
+		Description: "List the tournaments of this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name: "status",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "running", Value: "running"},
+					{Name: "kept", Value: "kept", NameLocalizations: map[discordgo.Locale]string{"id": "tetap"}},
+				},
+			},
+		},
+	})
+
+	indonesian := discordgo.Locale("id")
+	if got := (*cmd.NameLocalizations)[indonesian]; got != "turnamen" {
+		t.Errorf("name = %q", got)
+	}
+	if _, ok := (*cmd.NameLocalizations)[discordgo.EnglishUS]; ok {
+		t.Error("english should not be localized")
+	}
+	if got := (*cmd.DescriptionLocalizations)[indonesian]; got != id["command.tournaments.description"].Other {
+		t.Errorf("description = %q", got)
+	}
+
+	status := cmd.Options[0]
+	if got := status.DescriptionLocalizations[indonesian]; got != id["command.tournaments.status.description"].Other {
+		t.Errorf("option description = %q", got)
+	}
+	if got := status.Choices[0].NameLocalizations[indonesian]; got != "berjalan" {
+		t.Errorf("choice = %q", got)
+	}
+	if got := status.Choices[1].NameLocalizations[indonesian]; got != "tetap" {
+		t.Errorf("kept choice = %q", got)
+	}
+}
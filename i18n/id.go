@@ -0,0 +1,581 @@
+package i18n
+
+var id = map[string]Message{
+	// shared
+	"locale.name":                     M("Bahasa Indonesia"),
+	"error.internal":                  M("Terjadi kesalahan saat menjalankan perintah ini"),
+	"error.creating_tournament":       M("Terjadi kesalahan saat membuat turnamen"),
+	"error.generate_bracket":          M("Terjadi kesalahan saat membuat templat bracket turnamen"),
+	"error.get_tournament":            M("Data turnamen ini tidak ditemukan"),
+	"error.get_tournament_in_channel": M("Tidak ada turnamen di kanal ini, pastikan perintah ini dijalankan di dalam kanal turnamen"),
+	"error.get_tournament_types":      M("Daftar jenis turnamen tidak dapat diambil"),
+	"error.no_tournament_channel":     M("Server ini belum memiliki kanal turnamen, atur dengan /config channel"),
+	"error.insufficient_permission":   M("Kamu tidak memiliki izin untuk menggunakan perintah ini"),
+	"error.custom_id_outdated":        M("Tombol ini berasal dari versi bot yang lebih lama, jalankan perintahnya lagi"),
+	"error.file_too_big":              M("Berkas terlalu besar, batasnya %d KB"),
+	"common.guild_only":               M("Perintah ini hanya dapat digunakan di dalam server"),
+	"common.unknown_action":           M("Aksi tidak tersedia"),
+	"common.none":                     M("Tidak ada"),
+	"common.not_set":                  M("Belum diatur"),
+	"common.previous":                 M("Sebelumnya"),
+	"common.next":                     M("Berikutnya"),
+	"common.page":                     M("Halaman %d dari %d"),
+	"common.and_more":                 M("…dan %d lainnya"),
+
+	// admin
+	"error.no_manager_role":                   M("Role turnamen tidak ditemukan"),
+	"command.ping.description":                M("Ping untuk mendapatkan pong"),
+	"ping.pong":                               M("Pong! %vms"),
+	"command.admin.description":               M("Atur izin untuk mengelola turnamen spade"),
+	"command.admin.add.description":           M("Berikan role admin turnamen kepada pengguna"),
+	"command.admin.add.target.description":    M("Pengguna tujuan"),
+	"command.admin.remove.description":        M("Cabut role admin turnamen dari pengguna"),
+	"command.admin.remove.target.description": M("Pengguna tujuan"),
+	"admin.invalid_user":                      M("Pengguna tidak valid, gunakan @pengguna untuk memilih pengguna"),
+	"admin.added":                             M("<@%s> sekarang menjadi pengelola turnamen"),
+	"admin.removed":                           M("<@%s> bukan lagi pengelola turnamen"),
+
+	// audit
+	"command.audit.description":           M("Telusuri log tindakan administratif"),
+	"command.audit.list.description":      M("Telusuri log audit per halaman"),
+	"command.audit.list.page.description": M("Halaman awal"),
+	"command.audit.export.description":    M("Ekspor seluruh log audit sebagai csv"),
+	"audit.empty":                         M("Tidak ada catatan untuk dicetak"),
+	"audit.tournament":                    M("Turnamen `%s`"),
+	"audit.description":                   M("Tindakan administratif yang tercatat"),
+	"audit.none":                          M("Belum ada tindakan administratif yang tercatat"),
+	"audit.title":                         M("Log Audit"),
+
+	// config
+	"command.config.description":                            M("Atur spade untuk server ini"),
+	"command.config.show.description":                       M("Tampilkan konfigurasi server ini"),
+	"command.config.channel.description":                    M("Kanal tempat thread turnamen dibuka"),
+	"command.config.channel.channel.description":            M("Kanal turnamen"),
+	"command.config.manager_role.description":               M("Role yang boleh mengelola turnamen"),
+	"command.config.manager_role.role.description":          M("Role pengelola"),
+	"command.config.permission.description":                 M("Jadikan anggota sebuah role penyelenggara atau wasit di setiap turnamen"),
+	"command.config.permission.role.description":            M("Role server"),
+	"command.config.permission.permission.description":      M("Wasit hanya dapat melaporkan hasil"),
+	"command.config.permission.permission.choice.organizer": M("Wakil penyelenggara"),
+	"command.config.permission.permission.choice.referee":   M("Wasit"),
+	"command.config.permission.permission.choice.none":      M("Tidak ada"),
+	"command.config.format.description":                     M("Format yang dipakai /create jika tidak ada konfigurasi yang dipilih"),
+	"command.config.format.configuration.description":       M("Format turnamen bawaan"),
+	"command.config.timezone.description":                   M("Zona waktu untuk waktu mulai dan tanggal musim"),
+	"command.config.timezone.zone.description":              M("Zona waktu IANA seperti Asia/Jakarta"),
+	"command.config.locale.description":                     M("Bahasa yang dipakai bot untuk membalas"),
+	"command.config.locale.locale.description":              M("Bahasa"),
+	"command.config.color.description":                      M("Warna embed yang dikirim bot"),
+	"command.config.color.color.description":                M("Warna hex seperti #5865F2, kosongkan untuk memakai warna bawaan"),
+	"config.unknown_timezone":                               M("Zona waktu %q tidak dikenal, gunakan nama IANA seperti Asia/Jakarta"),
+	"config.invalid_color":                                  M("Warna %q tidak valid, gunakan format #RRGGBB"),
+	"tournament.format":                                     M("%v (%v pemain)"),
+	"role.none":                                             M("tidak ada"),
+	"role.referee":                                          M("wasit"),
+	"role.organizer":                                        M("penyelenggara"),
+	"role.owner":                                            M("pemilik"),
+	"role.manager":                                          M("pengelola"),
+	"settings.title":                                        M("Konfigurasi Server"),
+	"settings.description":                                  M("Ubah pengaturan dengan /config"),
+	"settings.channel":                                      M("Kanal Turnamen"),
+	"settings.manager_role":                                 M("Role Pengelola"),
+	"settings.format":                                       M("Format Bawaan"),
+	"settings.timezone":                                     M("Zona Waktu"),
+	"settings.locale":                                       M("Bahasa"),
+	"settings.color":                                        M("Warna Embed"),
+	"settings.permissions":                                  M("Izin Role"),
+
+	// leaderboard
+	"command.leaderboard.name":               M("peringkat"),
+	"command.leaderboard.description":        M("Tampilkan pemain terbaik di server ini"),
+	"command.leaderboard.by.description":     M("Dasar peringkat pemain (bawaan rating)"),
+	"command.leaderboard.by.choice.rating":   M("rating"),
+	"command.leaderboard.by.choice.wins":     M("kemenangan turnamen"),
+	"command.leaderboard.by.choice.season":   M("poin musim"),
+	"command.leaderboard.season.description": M("Musim yang dijadikan dasar poin (bawaan musim saat ini)"),
+	"command.leaderboard.game.description":   M("Urutkan berdasarkan rating satu game, bukan semua game"),
+	"command.leaderboard.page.description":   M("Halaman awal"),
+	"season.not_found":                       M("Musim tidak ditemukan, buat dengan /season create"),
+	"leaderboard.rating":                     M("Peringkat Rating"),
+	"leaderboard.game_rating":                M("Peringkat Rating %s"),
+	"leaderboard.rating_entry":               M("%.0f (%d-%d)"),
+	"leaderboard.wins":                       M("Peringkat Kemenangan Turnamen"),
+	"leaderboard.wins_entry":                 M("%d menang"),
+	"leaderboard.season":                     M("Peringkat Musim %s"),
+	"leaderboard.season_entry":               M("%d poin, %d dimainkan, %d menang"),
+	"leaderboard.empty":                      M("Belum ada yang masuk peringkat"),
+	"leaderboard.game_too_long":              M("Nama game terlalu panjang untuk dibuka per halaman, gunakan nama yang lebih pendek"),
+
+	// notifications
+	"command.notify.name":                                M("notifikasi"),
+	"command.notify.description":                         M("Pilih pesan langsung yang ingin kamu terima"),
+	"command.notify.show.description":                    M("Tampilkan pengaturan notifikasimu"),
+	"command.notify.set.description":                     M("Nyalakan atau matikan notifikasi"),
+	"command.notify.set.type.description":                M("Notifikasi yang ingin diubah"),
+	"command.notify.set.type.choice.all":                 M("Semua notifikasi"),
+	"command.notify.set.type.choice.match_ready":         M("Pertandinganmu siap"),
+	"command.notify.set.type.choice.opponent_checked_in": M("Lawanmu sudah check-in"),
+	"command.notify.set.type.choice.results":             M("Kamu lolos atau tersingkir"),
+	"command.notify.set.type.choice.starting_soon":       M("Turnamen segera dimulai"),
+	"command.notify.set.enabled.description":             M("Terima notifikasi lewat pesan langsung"),
+	"notification.all":                                   M("Semua notifikasi"),
+	"notification.match_ready":                           M("Pertandinganmu siap"),
+	"notification.opponent_checked_in":                   M("Lawanmu sudah check-in"),
+	"notification.results":                               M("Kamu lolos atau tersingkir"),
+	"notification.starting_soon":                         M("Turnamen segera dimulai"),
+	"notification.on":                                    M("🔔 Aktif"),
+	"notification.off":                                   M("🔕 Nonaktif"),
+	"notification.title":                                 M("Notifikasi"),
+	"notification.description":                           M("Pesan langsung yang kamu terima di setiap server, ubah dengan /notify set"),
+	"notification.match_ready_message":                   M("⚔️ Pertandingan #%d kamu melawan **%s** di **%s** sudah siap: %s"),
+	"notification.opponent_checked_in_message":           M("✋ Lawanmu sudah check-in, temui mereka di <#%s>"),
+	"notification.advanced_message":                      M("🎉 Kamu mengalahkan **%s** dan lolos ke babak berikutnya di **%s**"),
+	"notification.won_message":                           M("🏆 Kamu mengalahkan **%s** dan menjuarai **%s**!"),
+	"notification.eliminated_message":                    M("Kamu kalah dari **%s** dan tersingkir dari **%s**, terima kasih sudah bermain!"),
+	"notification.starting_soon_message":                 M("⏰ **%s** dimulai <t:%d:R>, sampai jumpa di <#%s>!"),
+
+	// match
+	"match.thread_name":           M("Pertandingan #%d: %s vs %s"),
+	"match.thread_greeting":       M("%s\nCheck-in jika sudah siap, lalu laporkan hasilnya di sini."),
+	"match.thread_opened":         M("Pertandingan #%d: **%s** vs **%s** dimainkan di <#%s>"),
+	"match.thread_result":         M("Pertandingan #%d: **%s** menang melawan **%s** di <#%s>"),
+	"match.already_over":          M("Pertandingan ini sudah selesai."),
+	"match.check_in_players_only": M("Hanya pemain pertandingan ini yang dapat check-in."),
+	"match.already_checked_in":    M("Kamu sudah check-in."),
+	"match.both_ready":            M("Kedua pihak sudah siap, pertandingan bisa dimulai!"),
+	"match.both_ready_referee":    M("Kedua pihak sudah siap, <@%s> pertandingan bisa dimulai!"),
+
+	// matchup
+	"matchup.title":           M("Pertandingan Turnamen #%d"),
+	"matchup.opponent":        M("Lawan %d"),
+	"matchup.captain":         M("%s (kapten <@%s>)"),
+	"matchup.referee":         M("Wasit"),
+	"matchup.check_in":        M("Check-in"),
+	"matchup.check_in_status": M("Lawan 1: %s\nLawan 2: %s"),
+	"matchup.ready":           M("✅ siap"),
+	"matchup.waiting":         M("⏳ menunggu"),
+	"matchup.not_available":   M("T/A"),
+
+	// start
+	"command.start.name":        M("mulai"),
+	"command.start.description": M("Mulai turnamen"),
+	"start.resumed":             M("Turnamen sudah dimulai sebelumnya, dilanjutkan dengan hasil sebelumnya."),
+	"start.started":             M("Turnamen sudah dimulai"),
+	"start.auto_started":        M("Pendaftaran ditutup dan turnamen sudah dimulai"),
+	"start.auto_start_failed":   M("Pendaftaran ditutup tetapi turnamen tidak dapat dimulai secara otomatis: %s"),
+	"start.already_completed":   M("Turnamen sudah selesai, gunakan /restart untuk memainkannya lagi"),
+	"start.not_enough_seeds":    M("Peserta belum cukup untuk memulai turnamen"),
+	"start.minimum_seeds":       M("Turnamen tidak dapat dimulai, dibutuhkan paling sedikit %d peserta"),
+	"start.short_rosters":       M("Turnamen tidak dapat dimulai, tim berikut masih kekurangan pemain: %s"),
+	"match.check_in":            M("Check-in"),
+	"match.wins":                M("%v Menang"),
+
+	// referee
+	"command.referee.name":                       M("wasit"),
+	"command.referee.description":                M("Tetapkan wasit untuk pertandingan di turnamen ini"),
+	"command.referee.assign.description":         M("Jadikan anggota wasit sebuah pertandingan, hanya wasit dan pengelola yang dapat melaporkan hasilnya"),
+	"command.referee.assign.match.description":   M("Nomor pertandingan seperti pada embed pertandingan"),
+	"command.referee.assign.member.description":  M("Wasit pertandingan"),
+	"command.referee.clear.description":          M("Hapus wasit sebuah pertandingan"),
+	"command.referee.clear.match.description":    M("Nomor pertandingan seperti pada embed pertandingan"),
+	"command.referee.rotate.description":         M("Gilir wasit turnamen pada setiap pertandingan yang diposting"),
+	"command.referee.rotate.enabled.description": M("Apakah pertandingan baru mendapat wasit secara otomatis"),
+	"referee.rotate_off":                         M("Wasit tidak lagi ditetapkan secara otomatis."),
+	"referee.rotate_on":                          M("Pertandingan baru diberikan kepada wasit turnamen secara bergiliran, tambahkan wasit dengan /staff add."),
+	"referee.match_not_found":                    M("Pertandingan tidak ditemukan, pertandingan dibuat saat turnamen dimulai"),
+	"referee.match_played":                       M("Pertandingan ini sudah dimainkan"),
+	"referee.assigned":                           M("<@%s> sekarang menjadi wasit pertandingan #%d"),
+	"referee.cleared":                            M("Pertandingan #%d tidak lagi memiliki wasit"),
+
+	// tournament
+	"tournament.already_published":            M("Turnamen sudah dipublikasikan"),
+	"tournament.published":                    M("Turnamen <#%s> sudah dipublikasikan"),
+	"tournament.edit.title":                   M("Ubah Turnamen"),
+	"tournament.edit.name":                    M("Nama Turnamen"),
+	"tournament.edit.description":             M("Deskripsi"),
+	"tournament.edit.description_placeholder": M("Jelaskan tentang turnamen ini"),
+	"tournament.edit.best_of":                 M("Best Of"),
+	"tournament.edit.rules":                   M("Peraturan"),
+	"tournament.edit.rules_placeholder":       M("Peraturan jika ada"),
+	"tournament.edit.starting_at":             M("Waktu Mulai (%s)"),
+	"tournament.edit.starting_at_placeholder": M("YYYY-MM-DD HH:MM, kosongkan untuk memulai secara manual"),
+	"tournament.delete_channel_failed":        M("Kanal turnamen tidak dapat dihapus saat menghapus turnamen: %v"),
+	"tournament.deleted":                      M("Turnamen berhasil dihapus"),
+	"match.referee_only":                      M("Hanya <@%s> atau pengelola yang dapat melaporkan hasil pertandingan ini"),
+
+	// profile
+	"podium.title":            M("Hasil %s"),
+	"podium.description":      M("Turnamen telah selesai, selamat kepada semua yang ikut serta!"),
+	"podium.more_players":     M("dan %d pemain lainnya"),
+	"profile.win":             M("M"),
+	"profile.loss":            M("K"),
+	"profile.record":          M("%dM - %dK"),
+	"profile.match_line":      M("**%s** %d-%d vs %s, %s babak %d"),
+	"profile.walkover":        M("(menang WO)"),
+	"profile.unrated":         M("Belum ada rating"),
+	"profile.placement":       M("%s di %s"),
+	"profile.opponent":        M("%[2]s, %[1]d kali bertanding (%[3]s)"),
+	"profile.title":           M("Profil %s"),
+	"profile.tournaments":     M("Turnamen"),
+	"profile.record_title":    M("Rekor"),
+	"profile.rating":          M("Rating"),
+	"profile.best_placements": M("Peringkat Terbaik"),
+	"profile.no_placements":   M("Belum ada turnamen yang selesai"),
+	"profile.recent_matches":  M("Pertandingan Terakhir"),
+	"profile.no_matches":      M("Belum ada pertandingan"),
+	"profile.opponents":       M("Lawan Paling Sering"),
+	"profile.no_opponents":    M("Belum ada lawan"),
+	"profile.older_matches":   M("...dan %d pertandingan sebelumnya"),
+	"profile.matches":         M("Pertandingan"),
+	"profile.never_met":       M("Kedua pemain ini belum pernah bertemu"),
+	"rating.all_games":        M("Semua game"),
+	"rating.title":            M("Rating %s"),
+	"rating.game":             M("Game"),
+	"rating.volatility":       M("Volatilitas %.4f"),
+
+	// rating
+	"command.profile.name":                   M("profil"),
+	"command.profile.description":            M("Tampilkan riwayat turnamen seorang pemain"),
+	"command.profile.player.description":     M("Pemain yang ingin ditampilkan profilnya (bawaan dirimu sendiri)"),
+	"command.h2h.description":                M("Tampilkan semua pertandingan antara dua pemain"),
+	"command.h2h.a.description":              M("Pemain pertama"),
+	"command.h2h.b.description":              M("Pemain kedua (bawaan dirimu sendiri)"),
+	"profile.same_players":                   M("Pilih dua pemain yang berbeda"),
+	"profile.not_played":                     M("%s belum pernah bermain di turnamen"),
+	"command.rating.description":             M("Rating pemain di server ini"),
+	"command.rating.show.description":        M("Tampilkan rating seorang pemain"),
+	"command.rating.show.player.description": M("Pemain yang ingin ditampilkan ratingnya (bawaan dirimu sendiri)"),
+	"command.rating.show.game.description":   M("Tampilkan rating satu game saja, bukan semua game"),
+	"command.rating.rebuild.description":     M("Hitung ulang semua rating server ini dari riwayat pertandingan"),
+	"rating.rebuilt":                         M("Rating telah dihitung ulang dari %d pertandingan"),
+	"rating.unrated":                         M("%s belum memiliki pertandingan yang dinilai"),
+
+	// import and export
+	"command.import.description":                          M("Impor data turnamen dari berkas"),
+	"command.import.tournament.description":               M("Bangun ulang turnamen dari ekspor JSON"),
+	"command.import.tournament.file.description":          M("Berkas JSON yang dibuat oleh /export"),
+	"command.import.participants.description":             M("Daftarkan pemain dari berkas CSV ke turnamen ini"),
+	"command.import.participants.file.description":        M("Berkas CSV dengan kolom name,discord_id,current_seat"),
+	"command.import.participants.apply_seats.description": M("Tempatkan pemain sesuai kolom current_seat (bawaan true)"),
+	"command.export.description":                          M("Ekspor peserta, hasil, atau seluruh turnamen"),
+	"command.export.type.description":                     M("Jenis daftar yang ingin diekspor"),
+	"command.export.type.choice.0":                        M("peserta"),
+	"command.export.type.choice.1":                        M("unggulan"),
+	"command.export.type.choice.2":                        M("turnamen (json)"),
+	"command.export.type.choice.3":                        M("hasil"),
+	"command.export.type.choice.4":                        M("pertandingan"),
+	"command.export.profile.description":                  M("Susunan kolom untuk ekspor hasil dan pertandingan"),
+	"command.export.profile.choice.default":               M("bawaan"),
+	"import.started":                                      M("Peserta tidak dapat diimpor setelah turnamen dimulai"),
+	"import.team_tournament":                              M("Peserta turnamen tim mendaftar lewat /team create"),
+	"import.attach_csv":                                   M("Lampirkan berkas CSV dengan kolom name,discord_id,current_seat"),
+	"import.attach_json":                                  M("Lampirkan berkas JSON yang dibuat oleh /export"),
+	"import.seat_taken":                                   M("kursi %d sudah ditempati %s"),
+	"import.row_error":                                    M("baris %d: %s"),
+	"import.dry_run":                                      M("**Uji coba %s**"),
+	"import.new_attendees":                                M("%d peserta baru"),
+	"import.existing_attendees":                           M("%d sudah terdaftar"),
+	"import.seats_to_apply":                               M("%d kursi akan diterapkan"),
+	"import.rows_with_errors":                             M("%d baris bermasalah"),
+	"import.nothing":                                      M("Tidak ada yang diimpor."),
+	"import.over_cap":                                     M("Tidak ada yang diimpor, turnamen menampung paling banyak %d pemain dan %d sudah terdaftar."),
+	"import.errors_skipped":                               M("Baris bermasalah dilewati saat impor dikonfirmasi."),
+	"import.expired":                                      M("Uji coba ini sudah kedaluwarsa, jalankan /import lagi"),
+	"import.cancelled":                                    M("Impor dibatalkan"),
+	"import.imported":                                     M("Menambahkan %d pemain ke turnamen dan menerapkan %d kursi."),
+	"import.restored":                                     M("Mengimpor %d peserta dan %d pertandingan, publikasikan turnamen untuk melanjutkannya di sini."),
+	"import.unsupported_type":                             M("Jenis turnamen %d pemain %s tidak didukung"),
+	"export.team_archive":                                 M("Turnamen tim tidak dapat diekspor sebagai arsip"),
+	"export.participants_failed":                          M("Daftar peserta tidak dapat diambil"),
+	"export.empty":                                        M("Tidak ada data untuk dicetak"),
+	"export.no_results":                                   M("Hasil tersedia setelah final dimainkan"),
+	"export.not_started":                                  M("Turnamen belum dimulai"),
+	"common.confirm":                                      M("Konfirmasi"),
+	"common.cancel":                                       M("Batal"),
+	"mentions.not_a_user":                                 M("`%s` bukan mention atau id pengguna"),
+	"mentions.listed_twice":                               M("<@%s> tercantum lebih dari sekali"),
+	"archive.invalid":                                     M("Arsip turnamen tidak valid: %v"),
+	"archive.unsupported_version":                         M("Versi arsip %d tidak didukung, bot ini membaca sampai versi %d"),
+	"archive.missing_name":                                M("Arsip tidak memiliki nama turnamen"),
+	"archive.missing_discord_id":                          M("Arsip memiliki peserta tanpa id discord"),
+	"archive.duplicate_attendee":                          M("Peserta %s tercantum lebih dari sekali"),
+	"archive.duplicate_match":                             M("Pertandingan %d tercantum lebih dari sekali"),
+	"archive.unknown_attendee":                            M("Pertandingan %d merujuk ke %s yang bukan peserta"),
+	"participants.empty":                                  M("Berkas kosong"),
+	"participants.invalid":                                M("Berkas CSV tidak valid: %v"),
+	"participants.header":                                 M("Header harus %s"),
+	"participants.missing_discord_id":                     M("id discord tidak ada"),
+	"participants.missing_name":                           M("nama pemain tidak ada"),
+	"participants.not_a_discord_id":                       M("%q bukan id discord"),
+	"participants.duplicate":                              M("pemain sudah tercantum di baris %d"),
+	"participants.not_a_seat":                             M("%q bukan kursi"),
+	"participants.not_a_starting_seat":                    M("kursi %d bukan kursi awal bracket ini"),
+	"participants.seat_taken":                             M("kursi %d sudah dipakai di baris %d"),
+	"season.tier_format":                                  M("Tingkat %q harus ditulis sebagai peringkat=poin"),
+	"season.tier_place":                                   M("Peringkat pada tingkat %q harus bilangan positif"),
+	"season.tier_points":                                  M("Poin pada tingkat %q tidak boleh negatif"),
+	"season.tier_order":                                   M("Tingkat harus diurutkan dari peringkat terbaik"),
+	"season.tier_first":                                   M("Tingkat harus dimulai dari peringkat pertama"),
+
+	// season
+	"command.season.description":               M("Kelola musim di server ini"),
+	"command.season.create.description":        M("Buat musim baru"),
+	"command.season.create.name.description":   M("Nama musim"),
+	"command.season.create.start.description":  M("Hari pertama musim dalam zona waktu server (YYYY-MM-DD)"),
+	"command.season.create.end.description":    M("Hari terakhir musim dalam zona waktu server (YYYY-MM-DD)"),
+	"command.season.create.points.description": M("Poin per peringkat sebagai peringkat=poin"),
+	"command.season.points.description":        M("Ubah poin yang diberikan per peringkat"),
+	"command.season.points.name.description":   M("Nama musim"),
+	"command.season.points.points.description": M("Poin per peringkat sebagai peringkat=poin, misalnya 1=100,2=70,3=50"),
+	"command.season.list.description":          M("Daftar musim di server ini"),
+	"command.season.archive.description":       M("Tutup musim dan simpan klasemen akhirnya"),
+	"command.season.archive.name.description":  M("Nama musim"),
+	"season.start_format":                      M("Tanggal mulai harus ditulis sebagai YYYY-MM-DD"),
+	"season.end_format":                        M("Tanggal selesai harus ditulis sebagai YYYY-MM-DD"),
+	"season.end_before_start":                  M("Musim tidak boleh berakhir sebelum dimulai"),
+	"season.exists":                            M("Musim %s sudah ada"),
+	"season.created":                           M("Musim %s berlangsung dari <t:%d:D> sampai <t:%d:D>, poin per peringkat: %s"),
+	"season.archived":                          M("Musim %s telah diarsipkan"),
+	"season.archived_winner":                   M("Musim %[2]s telah diarsipkan, selamat kepada <@%[3]s> yang finis pertama dengan %[1]d poin"),
+	"season.points_updated":                    M("Poin per peringkat musim %s sekarang %s"),
+	"season.already_archived":                  M("Musim %s sudah diarsipkan"),
+	"season.none":                              M("Belum ada musim yang dibuat"),
+	"season.period":                            M("<t:%d:D> sampai <t:%d:D>\nPoin: %s"),
+	"season.archived_label":                    M("Diarsipkan"),
+	"season.title":                             M("Musim"),
+	"season.named_not_found":                   M("Musim %s tidak ada"),
+
+	// configuration
+	"configuration.title":                 M("Konfigurasi"),
+	"configuration.subtitle":              M("Konfigurasi yang tersedia untuk turnamenmu"),
+	"configuration.name":                  M("Nama"),
+	"configuration.description":           M("Deskripsi"),
+	"configuration.rules":                 M("Peraturan"),
+	"configuration.registration":          M("Pendaftaran"),
+	"configuration.registration_open":     M("Terbuka untuk semua"),
+	"configuration.registration_managers": M("Hanya pengelola"),
+	"configuration.player_cap":            M("Batas Pemain"),
+	"configuration.team_cap":              M("Batas Tim"),
+	"configuration.best_of":               M("Best Of"),
+	"configuration.bracket_type":          M("Jenis Bracket"),
+	"configuration.single_elimination":    M("Gugur Tunggal"),
+	"configuration.teams":                 M("Tim"),
+	"configuration.team_size":             M("%d pemain, paling banyak %d per roster"),
+	"configuration.starts":                M("Mulai"),
+	"configuration.owner":                 M("Pemilik"),
+	"configuration.check_in":              M("Check-in"),
+	"configuration.check_in_opens":        M("Dibuka %d menit sebelum mulai"),
+	"configuration.matches":               M("Pertandingan"),
+	"configuration.match_threads":         M("Dimainkan di thread privat untuk setiap pertandingan"),
+
+	// create
+	"command.create.name":                       M("buat"),
+	"command.create.description":                M("Buat turnamen"),
+	"command.create.configurations.description": M("Pilih pengaturan turnamen yang sudah disiapkan"),
+	"command.create.template.description":       M("Buat turnamen dari templat yang tersimpan"),
+	"command.create.self_register.description":  M("Izinkan pemain mendaftar sendiri (bawaan true)"),
+	"command.create.game.description":           M("Game yang dimainkan, pemain mendapat rating terpisah per game"),
+	"command.create.team_size.description":      M("Pemain per tim, kosongkan untuk turnamen perorangan"),
+	"command.create.roster_limit.description":   M("Jumlah pemain terbanyak dalam tim, termasuk cadangan (bawaan ukuran tim)"),
+	"command.create.starting_at.description":    M("Mulai turnamen secara otomatis pada YYYY-MM-DD HH:MM dalam zona waktu server"),
+	"command.create.check_in.description":       M("Berapa menit sebelum mulai pemain harus check-in, yang tidak hadir kehilangan tempatnya"),
+	"command.create.match_threads.description":  M("Mainkan setiap pertandingan di thread privat bersama pemain dan wasitnya"),
+	"create.default_name":                       M("Turnamen Baru"),
+	"create.no_configuration":                   M("Pilih konfigurasi atau templat untuk membuat turnamen"),
+	"create.roster_limit":                       M("Batas roster tidak boleh lebih kecil dari ukuran tim"),
+	"create.check_in_without_start":             M("Check-in membutuhkan waktu mulai, atur juga starting_at"),
+	"template.not_found":                        M("Templat bernama %s tidak ditemukan"),
+	"tournament.publish":                        M("Publikasikan"),
+	"tournament.edit":                           M("Ubah"),
+	"tournament.delete":                         M("Hapus"),
+	"tournament.best_of_odd":                    M("Best of harus bilangan ganjil positif"),
+	"tournament.invalid_starting_at":            M("Waktu mulai %q tidak valid, gunakan format YYYY-MM-DD HH:MM"),
+	"tournament.starting_at_past":               M("Waktu mulai harus di masa depan"),
+	"tournament.reminder":                       M("⏰ **%s** dimulai <t:%d:R>, pastikan kamu sudah siap!"),
+
+	// delete, restart, seed and status
+	"command.delete.name":                   M("hapus"),
+	"command.delete.description":            M("Hapus turnamen ini"),
+	"command.delete.id.description":         M("Turnamen yang ingin dihapus (bawaan turnamen di thread ini)"),
+	"command.restart.description":           M("Mulai ulang turnamen"),
+	"restart.failed":                        M("Terjadi kesalahan saat memulai ulang turnamen."),
+	"restart.restarted":                     M("Turnamen telah diulang, gunakan /start untuk memulainya lagi."),
+	"command.seed.description":              M("Tentukan unggulan turnamen secara berurutan"),
+	"command.seed.players.description":      M("Mention atau id pengguna peserta dari unggulan terbaik sampai terburuk"),
+	"seed.empty":                            M("Tentukan paling sedikit 1 pemain unggulan untuk turnamen ini"),
+	"seed.insufficient_permission":          M("Kamu tidak memiliki izin untuk menentukan unggulan di turnamen ini."),
+	"seed.unregistered":                     M("%s tidak terdaftar di turnamen ini"),
+	"seed.seeded":                           M("Berhasil menentukan %d pemain unggulan"),
+	"command.status.description":            M("Tampilkan status langsung sebuah turnamen"),
+	"command.status.tournament.description": M("Turnamen yang ingin ditampilkan (bawaan turnamen di thread ini)"),
+	"status.not_started":                    M("Belum dimulai"),
+	"status.completed":                      M("🏁 Selesai"),
+	"status.round":                          M("Babak %d dari %d"),
+	"status.upcoming":                       M("B%d: %s"),
+	"status.live":                           M("Pertandingan Berlangsung"),
+	"status.up_next":                        M("Berikutnya"),
+	"status.recent":                         M("Hasil Terbaru"),
+	"status.remaining":                      M("Tersisa (%d)"),
+	"status.updated":                        M("Terakhir diperbarui"),
+
+	// register
+	"command.register.name":                M("daftar"),
+	"command.register.description":         M("Daftarkan pengguna ke turnamen ini"),
+	"command.register.players.description": M("Mention atau id pengguna pemain yang akan didaftarkan (bawaan dirimu sendiri)"),
+	"register.already_registered":          M("Kamu sudah terdaftar di turnamen ini."),
+	"register.insufficient_permission":     M("Kamu tidak memiliki izin untuk menambahkan pemain lain ke turnamen ini."),
+	"register.closed":                      M("Pendaftaran turnamen ini sudah ditutup."),
+	"register.team_tournament":             M("Ini turnamen tim, daftarkan tim dengan /team create."),
+	"register.self_register_disabled":      M("Pendaftaran mandiri dinonaktifkan untuk turnamen ini, minta pengelola turnamen untuk mendaftarkanmu."),
+	"register.added":                       M("Menambahkan %d pemain ke turnamen."),
+	"register.waitlisted":                  M("Turnamen sudah penuh, %d pemain dimasukkan ke daftar tunggu."),
+
+	// team
+	"command.team.name":                      M("tim"),
+	"command.team.description":               M("Daftarkan dan kelola timmu di turnamen tim ini"),
+	"command.team.create.description":        M("Daftarkan tim baru dan jadilah kaptennya"),
+	"command.team.create.name.description":   M("Nama tim"),
+	"command.team.invite.description":        M("Undang pemain ke timmu, hanya kapten yang dapat mengundang"),
+	"command.team.invite.player.description": M("Pemain yang diundang"),
+	"command.team.join.description":          M("Bergabung dengan tim yang mengundangmu"),
+	"command.team.join.name.description":     M("Nama tim"),
+	"command.team.roster.description":        M("Daftar tim beserta pemainnya"),
+	"team.solo_tournament":                   M("Ini turnamen perorangan, daftar dengan /register"),
+	"team.started":                           M("Tim tidak dapat diubah setelah turnamen dimulai"),
+	"team.self_register_disabled":            M("Pendaftaran mandiri dinonaktifkan untuk turnamen ini, minta pengelola turnamen untuk mendaftarkan timmu."),
+	"team.empty_name":                        M("Nama tim tidak boleh kosong"),
+	"team.exists":                            M("Tim %s sudah ada"),
+	"team.full":                              M("Turnamen sudah penuh, paling banyak %d tim"),
+	"team.already_playing":                   M("Kamu sudah bermain untuk %s"),
+	"team.created":                           M("Tim **%s** telah terdaftar dengan <@%s> sebagai kapten, undang rekan timmu dengan /team invite"),
+	"team.bot":                               M("Bot tidak dapat diundang ke tim"),
+	"team.other_playing":                     M("%s sudah bermain untuk %s"),
+	"team.roster_full":                       M("Rostermu sudah penuh, tim paling banyak berisi %d pemain"),
+	"team.invited":                           M("<@%s>, kamu diundang bergabung dengan **%s**, terima dengan `/team join %s`"),
+	"team.not_found":                         M("Tim %s tidak ada"),
+	"team.not_invited":                       M("Kamu belum diundang ke %s"),
+	"team.team_full":                         M("%s sudah penuh, tim paling banyak berisi %d pemain"),
+	"team.joined":                            M("%s bergabung dengan **%s** (%d/%d)"),
+	"team.none":                              M("Belum ada tim yang terdaftar"),
+	"team.captain":                           M("(kapten)"),
+	"team.roster_title":                      M("Tim %s"),
+	"team.roster_description":                M("Tim bermain dengan %d pemain"),
+	"team.captains_only":                     M("Hanya kapten tim yang dapat mengundang pemain, buat tim dengan /team create"),
+
+	// staff
+	"command.staff.description":               M("Kelola co-organizer dan wasit turnamen ini"),
+	"command.staff.add.description":           M("Jadikan anggota co-organizer atau wasit, co-organizer dipilih oleh pemilik"),
+	"command.staff.add.member.description":    M("Anggota server"),
+	"command.staff.add.role.description":      M("Wasit hanya dapat melaporkan hasil"),
+	"command.staff.remove.description":        M("Cabut peran seorang anggota"),
+	"command.staff.remove.member.description": M("Anggota server"),
+	"command.staff.list.description":          M("Daftar pemilik dan staf turnamen"),
+	"staff.organizer":                         M("Co-organizer"),
+	"staff.referee":                           M("Wasit"),
+	"staff.owner":                             M("Pemilik"),
+	"staff.added":                             M("<@%s> sekarang menjadi %s turnamen ini"),
+	"staff.not_staff":                         M("<@%s> bukan staf turnamen ini"),
+	"staff.removed":                           M("<@%s> bukan lagi staf turnamen ini"),
+	"staff.none":                              M("Turnamen ini tidak memiliki staf, turnamen dijalankan oleh pengelola turnamen."),
+
+	// template
+	"command.template.description":                   M("Kelola templat turnamen"),
+	"command.template.save.description":              M("Simpan konfigurasi turnamen ini sebagai templat"),
+	"command.template.save.name.description":         M("Nama templat, menyimpan dengan nama yang sudah ada akan menimpanya"),
+	"command.template.save.name_pattern.description": M("Nama turnamen yang dibuat, {n} adalah edisi dan {date} tanggal pembuatan"),
+	"command.template.save.id.description":           M("Turnamen yang ingin disimpan (bawaan turnamen di thread ini)"),
+	"command.template.list.description":              M("Daftar templat yang tersimpan"),
+	"template.saved":                                 M("Templat **%s** tersimpan, gunakan `/create template:%s` untuk membuat turnamen darinya"),
+	"template.none":                                  M("Belum ada templat, gunakan `/template save` di dalam thread turnamen untuk menyimpannya"),
+	"template.summary":                               M("%[2]s, best of %[3]d, dipakai %[1]d kali"),
+	"template.title":                                 M("Templat Turnamen"),
+
+	// recurrence
+	"command.recurrence.description":                       M("Ulangi turnamen ini setiap minggu"),
+	"command.recurrence.set.description":                   M("Adakan edisi baru turnamen ini setiap minggu"),
+	"command.recurrence.set.weekday.description":           M("Hari turnamen diadakan"),
+	"command.recurrence.set.time.description":              M("Jam turnamen diadakan dalam format 24 jam HH:MM"),
+	"command.recurrence.set.timezone.description":          M("Zona waktu IANA seperti Asia/Jakarta (bawaan zona waktu server)"),
+	"command.recurrence.set.open_before_hours.description": M("Berapa jam sebelum turnamen edisi berikutnya dipublikasikan (bawaan 24)"),
+	"command.recurrence.set.carry_seeding.description":     M("Tentukan unggulan edisi berikutnya dari hasil edisi sebelumnya"),
+	"command.recurrence.set.name_pattern.description":      M("Nama edisi, {n} adalah edisi dan {date} tanggal turnamen"),
+	"command.recurrence.show.description":                  M("Tampilkan jadwal turnamen ini"),
+	"command.recurrence.stop.description":                  M("Berhenti membuat edisi baru turnamen ini"),
+	"weekday.0":                                            M("Minggu"),
+	"weekday.1":                                            M("Senin"),
+	"weekday.2":                                            M("Selasa"),
+	"weekday.3":                                            M("Rabu"),
+	"weekday.4":                                            M("Kamis"),
+	"weekday.5":                                            M("Jumat"),
+	"weekday.6":                                            M("Sabtu"),
+	"common.yes":                                           M("ya"),
+	"common.no":                                            M("tidak"),
+	"recurrence.not_recurring":                             M("Turnamen ini tidak berulang"),
+	"recurrence.show":                                      M("Setiap %[2]s pukul %[3]s (%[4]s), edisi dipublikasikan %[1]d jam sebelumnya.\nEdisi saat ini: #%[5]d\nUnggulan dari edisi sebelumnya: %[6]s"),
+	"recurrence.stopped":                                   M("Edisi baru turnamen ini tidak akan dibuat lagi"),
+	"recurrence.invalid_time":                              M("Jam %q tidak valid, gunakan format 24 jam HH:MM"),
+	"recurrence.set":                                       M("Turnamen sekarang diulang setiap %s pukul %s (%s). Edisi berikutnya diadakan <t:%d:F> dan dibuka <t:%d:R>."),
+	"recurrence.opened":                                    M("Pendaftaran sudah dibuka! Edisi ini dimulai <t:%d:F>, gunakan `/register` untuk ikut."),
+
+	// signup and check-in
+	"signup.title":                  M("Pendaftaran"),
+	"signup.description":            M("Tekan **Gabung** untuk ikut %s, tempat diberikan kepada yang lebih dulu mendaftar."),
+	"signup.closed":                 M("Pendaftaran sudah ditutup."),
+	"signup.players":                M("Pemain"),
+	"signup.waitlist":               M("Daftar Tunggu"),
+	"signup.registered":             M("Terdaftar"),
+	"signup.waiting":                M("Menunggu"),
+	"signup.more":                   M(" dan %d lainnya"),
+	"signup.join":                   M("Gabung"),
+	"signup.leave":                  M("Keluar"),
+	"signup.registration_closed":    M("Pendaftaran turnamen ini sudah ditutup."),
+	"signup.self_register_disabled": M("Pendaftaran mandiri dinonaktifkan untuk turnamen ini, minta pengelola turnamen untuk mendaftarkanmu."),
+	"signup.already_registered":     M("Kamu sudah terdaftar di turnamen ini."),
+	"signup.not_registered":         M("Kamu tidak terdaftar di turnamen ini."),
+	"signup.joined":                 M("Kamu sekarang terdaftar di turnamen."),
+	"signup.waitlisted":             M("Turnamen sudah penuh, kamu dimasukkan ke daftar tunggu dan akan mendapat pesan begitu ada tempat kosong."),
+	"signup.left":                   M("Kamu telah keluar dari turnamen."),
+	"signup.promoted":               M("Ada tempat kosong di **%s**, kamu telah dipindahkan dari daftar tunggu ke dalam turnamen."),
+	"signup.promoted_details":       M("Lihat <#%s> untuk detailnya."),
+	"check_in.title":                M("Check In"),
+	"check_in.description":          M("Tekan **Check in** untuk memastikan kamu bermain di %s."),
+	"check_in.closes":               M("Check-in ditutup <t:%d:R>, siapa pun yang belum check-in saat itu kehilangan tempatnya."),
+	"check_in.closed":               M("Check-in sudah ditutup."),
+	"check_in.checked_in":           M("Sudah Check In"),
+	"check_in.waitlist_ready":       M("Daftar Tunggu Siap"),
+	"check_in.missing":              M("Belum Hadir"),
+	"check_in.button":               M("Check in"),
+	"check_in.window_closed":        M("Check-in turnamen ini sudah ditutup."),
+	"check_in.captains_only":        M("Hanya kapten tim yang bisa melakukan check-in untuk timnya."),
+	"check_in.done":                 M("Kamu sudah check-in, semoga berhasil!"),
+	"check_in.done_waitlist":        M("Kamu sudah check-in dari daftar tunggu, kamu akan mendapat tempat jika ada yang tidak hadir."),
+	"check_in.dropped":              M("Menghapus %d peserta yang tidak check-in: %s"),
+	"check_in.promoted":             M("%d pemain telah dipindahkan dari daftar tunggu."),
+
+	// tournaments
+	"command.tournaments.name":                    M("turnamen"),
+	"command.tournaments.description":             M("Daftar turnamen di server ini"),
+	"command.tournaments.status.description":      M("Hanya tampilkan turnamen dengan status ini"),
+	"command.tournaments.status.choice.draft":     M("draf"),
+	"command.tournaments.status.choice.open":      M("pendaftaran dibuka"),
+	"command.tournaments.status.choice.running":   M("berjalan"),
+	"command.tournaments.status.choice.completed": M("selesai"),
+	"command.tournaments.format.description":      M("Hanya tampilkan turnamen dengan format ini"),
+	"command.tournaments.upcoming.description":    M("Hanya tampilkan turnamen yang dijadwalkan mulai nanti"),
+	"command.tournaments.mine.description":        M("Hanya tampilkan turnamen yang kamu miliki, kelola, atau ikuti"),
+	"tournaments.state.draft":                     M("draf"),
+	"tournaments.state.open":                      M("pendaftaran dibuka"),
+	"tournaments.state.running":                   M("berjalan"),
+	"tournaments.state.completed":                 M("selesai"),
+	"tournaments.row":                             M("**%[2]s** · %[3]s · %[4]d/%[1]d peserta"),
+	"tournaments.started":                         M("dimulai <t:%d:R>"),
+	"tournaments.starts":                          M("mulai <t:%d:R>"),
+	"tournaments.empty":                           M("Tidak ada turnamen yang cocok dengan filter ini"),
+	"tournaments.title":                           M("Turnamen (%d)"),
+
+	// match scores
+	"match.score_title":   M("Laporkan Hasil"),
+	"match.score_label":   M("Skor, jumlah game pemenang lebih dulu"),
+	"match.score_format":  M("Skor %q harus ditulis sebagai jumlah game pemenang dan yang kalah, seperti 2-1"),
+	"match.score_invalid": M("Skor %q tidak cocok untuk best of %d, pemenang mengambil %d game dan yang kalah lebih sedikit"),
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // the container image does not ship time zone data
 
 	"github.com/dimfu/spade/config"
 	"github.com/dimfu/spade/database"
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+// ARCHIVE_VERSION is bumped whenever the archive format changes in a way older readers can't handle
+const ARCHIVE_VERSION = 1
+
+// TournamentArchive is a self contained copy of a tournament, participants are referred to by their
+// discord id so the archive can be imported into another server
+type TournamentArchive struct {
+	Version    int                `json:"version"`
+	ExportedAt int64              `json:"exported_at"`
+	Tournament ArchivedTournament `json:"tournament"`
+	Attendees  []ArchivedAttendee `json:"attendees"`
+	Matches    []ArchivedMatch    `json:"matches"`
+	Standings  []ArchivedStanding `json:"standings"`
+}
+
+type ArchivedTournament struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	Description      string       `json:"description,omitempty"`
+	Rules            string       `json:"rules,omitempty"`
+	Type             ArchivedType `json:"type"`
+	Game             string       `json:"game,omitempty"`
+	BestOf           int          `json:"best_of"`
+	SelfRegister     bool         `json:"self_register"`
+	RegistrationOpen bool         `json:"registration_open"`
+	StartingAt       int64        `json:"starting_at,omitempty"`
+	StartedAt        int64        `json:"started_at,omitempty"`
+	CreatedAt        int64        `json:"created_at"`
+}
+
+type ArchivedType struct {
+	Size           int    `json:"size"`
+	BracketType    string `json:"bracket_type"`
+	HasThirdWinner bool   `json:"has_third_winner"`
+}
+
+type ArchivedAttendee struct {
+	DiscordID    string `json:"discord_id"`
+	Name         string `json:"name"`
+	StartingSeat int64  `json:"starting_seat,omitempty"`
+	CurrentSeat  int64  `json:"current_seat,omitempty"`
+}
+
+type ArchivedMatch struct {
+	Number      int         `json:"number"`
+	Round       int         `json:"round"`
+	P1          string      `json:"p1,omitempty"`
+	P2          string      `json:"p2,omitempty"`
+	P1Seat      int         `json:"p1_seat"`
+	P2Seat      int         `json:"p2_seat"`
+	WinnerTo    int         `json:"winner_to"`
+	Winner      string      `json:"winner,omitempty"`
+	Status      MatchStatus `json:"status"`
+	P1Score     int         `json:"p1_score"`
+	P2Score     int         `json:"p2_score"`
+	StartedAt   int64       `json:"started_at,omitempty"`
+	CompletedAt int64       `json:"completed_at,omitempty"`
+}
+
+type ArchivedStanding struct {
+	Place     int    `json:"place"`
+	DiscordID string `json:"discord_id"`
+	Name      string `json:"name"`
+	Wins      int    `json:"wins"`
+	Losses    int    `json:"losses"`
+}
+
+// NewArchive builds the archive of the tournament from its attendees and match records
+func NewArchive(t *Tournament, attendees []Attendee, records []MatchRecord) *TournamentArchive {
+	size, _ := strconv.Atoi(t.TournamentType.Size)
+	createdAt, _ := strconv.ParseInt(t.Created_At, 10, 64)
+
+	a := &TournamentArchive{
+		Version:    ARCHIVE_VERSION,
+		ExportedAt: time.Now().Unix(),
+		Tournament: ArchivedTournament{
+			ID:          string(t.ID),
+			Name:        t.Name,
+			Description: t.Description.String,
+			Rules:       t.Rules.String,
+			Type: ArchivedType{
+				Size:           size,
+				BracketType:    t.TournamentType.Bracket_Type,
+				HasThirdWinner: t.TournamentType.Has_Third_Winner,
+			},
+			Game:             t.Game.String,
+			BestOf:           t.Best_Of,
+			SelfRegister:     t.Self_Register,
+			RegistrationOpen: t.Registration_Open,
+			StartingAt:       t.Starting_At.Int64,
+			StartedAt:        t.Started_At.Int64,
+			CreatedAt:        createdAt,
+		},
+		Attendees: make([]ArchivedAttendee, 0, len(attendees)),
+		Matches:   make([]ArchivedMatch, 0, len(records)),
+		Standings: []ArchivedStanding{},
+	}
+
+	discordIDs := make(map[int64]string, len(attendees))
+	names := make(map[int]string, len(attendees))
+	for _, at := range attendees {
+		discordIDs[int64(at.Id)] = at.Player.DiscordID
+		names[at.Id] = at.Player.Name
+		a.Attendees = append(a.Attendees, ArchivedAttendee{
+			DiscordID:    at.Player.DiscordID,
+			Name:         at.Player.Name,
+			StartingSeat: at.StartingSeat.Int64,
+			CurrentSeat:  at.CurrentSeat.Int64,
+		})
+	}
+
+	for _, r := range records {
+		a.Matches = append(a.Matches, ArchivedMatch{
+			Number:      r.Number,
+			Round:       r.Round,
+			P1:          discordIDs[r.P1AttendeeID.Int64],
+			P2:          discordIDs[r.P2AttendeeID.Int64],
+			P1Seat:      r.P1Seat,
+			P2Seat:      r.P2Seat,
+			WinnerTo:    r.WinnerTo,
+			Winner:      discordIDs[r.WinnerAttendeeID.Int64],
+			Status:      r.Status,
+			P1Score:     r.P1Score,
+			P2Score:     r.P2Score,
+			StartedAt:   r.StartedAt.Int64,
+			CompletedAt: r.CompletedAt.Int64,
+		})
+	}
+
+	for _, s := range Standings(records) {
+		a.Standings = append(a.Standings, ArchivedStanding{
+			Place:     s.Place,
+			DiscordID: discordIDs[int64(s.AttendeeID)],
+			Name:      names[s.AttendeeID],
+			Wins:      s.Wins,
+			Losses:    s.Losses,
+		})
+	}
+
+	return a
+}
+
+// DecodeArchive reads an archive and checks that it can be imported as is
+func DecodeArchive(r io.Reader) (*TournamentArchive, error) {
+	a := &TournamentArchive{}
+	if err := json.NewDecoder(r).Decode(a); err != nil {
+		return nil, i18n.NewError("archive.invalid", err)
+	}
+
+	if a.Version < 1 || a.Version > ARCHIVE_VERSION {
+		return nil, i18n.NewError("archive.unsupported_version", a.Version, ARCHIVE_VERSION)
+	}
+
+	if a.Tournament.Name == "" {
+		return nil, i18n.NewError("archive.missing_name")
+	}
+
+	attendees := make(map[string]bool, len(a.Attendees))
+	for _, at := range a.Attendees {
+		if at.DiscordID == "" {
+			return nil, i18n.NewError("archive.missing_discord_id")
+		}
+		if attendees[at.DiscordID] {
+			return nil, i18n.NewError("archive.duplicate_attendee", at.DiscordID)
+		}
+		attendees[at.DiscordID] = true
+	}
+
+	numbers := make(map[int]bool, len(a.Matches))
+	for _, m := range a.Matches {
+		if numbers[m.Number] {
+			return nil, i18n.NewError("archive.duplicate_match", m.Number)
+		}
+		numbers[m.Number] = true
+
+		for _, p := range []string{m.P1, m.P2, m.Winner} {
+			if p != "" && !attendees[p] {
+				return nil, i18n.NewError("archive.unknown_attendee", m.Number, p)
+			}
+		}
+	}
+
+	return a, nil
+}
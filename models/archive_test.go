@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeArchive(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		wantErr bool
+	}
+
+	tests := []testCase{
+		{
+			name: "valid archive",
+			input: `{"version": 1, "tournament": {"name": "Weekly"},
+				"attendees": [{"discord_id": "1"}, {"discord_id": "2"}],
+				"matches": [{"number": 1, "p1": "1", "p2": "2", "winner": "2"}]}`,
+		},
+		{
+			name:    "newer version",
+			input:   `{"version": 99, "tournament": {"name": "Weekly"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			input:   `{"tournament": {"name": "Weekly"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicated attendee",
+			input:   `{"version": 1, "tournament": {"name": "Weekly"}, "attendees": [{"discord_id": "1"}, {"discord_id": "1"}]}`,
+			wantErr: true,
+		},
+		{
+			name: "match with unknown participant",
+			input: `{"version": 1, "tournament": {"name": "Weekly"}, "attendees": [{"discord_id": "1"}],
+				"matches": [{"number": 1, "p1": "1", "p2": "2"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   `name,discord_id`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecodeArchive(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
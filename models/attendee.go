@@ -3,6 +3,9 @@ package models
 import (
 	"database/sql"
 	"log"
+	"time"
+
+	"github.com/dimfu/spade/bracket"
 )
 
 type Attendee struct {
@@ -11,10 +14,22 @@ type Attendee struct {
 	PlayerID     string
 	StartingSeat sql.NullInt64
 	CurrentSeat  sql.NullInt64
+	TeamID       sql.NullInt64
+	WaitlistedAt sql.NullInt64
+	CheckedInAt  sql.NullInt64
 	Player       Player
+	Team         Team
 	Tournament   Tournament
 }
 
+// Name is how the attendee is shown in the bracket, the team name in team tournaments
+func (a *Attendee) Name() string {
+	if a.TeamID.Valid && a.Team.Name != "" {
+		return a.Team.Name
+	}
+	return a.Player.Name
+}
+
 type AttendeeWithResult struct {
 	Attendee
 	Result    int
@@ -33,20 +48,44 @@ func NewAttendeeModel(db *sql.DB) *AttendeeModel {
 
 func (m *AttendeeModel) FindById(tournamentId, playerId string) (*Attendee, error) {
 	a := &Attendee{}
-	q := `SELECT id, tournament_id, player_id FROM attendees WHERE tournament_id = ? AND player_id = ?`
-	err := m.DB.QueryRow(q, tournamentId, playerId).Scan(&a.Id, &a.TournamentID, &a.PlayerID)
+	q := `SELECT id, tournament_id, player_id, waitlisted_at, checked_in_at FROM attendees
+		  WHERE tournament_id = ? AND player_id = ?`
+	err := m.DB.QueryRow(q, tournamentId, playerId).Scan(&a.Id, &a.TournamentID, &a.PlayerID, &a.WaitlistedAt,
+		&a.CheckedInAt)
 	if err != nil {
 		return nil, err
 	}
 	return a, nil
 }
 
+func (m *AttendeeModel) Insert(tx *sql.Tx, a *Attendee) error {
+	q := `INSERT INTO attendees (tournament_id, player_id, starting_seat, current_seat) VALUES (?, ?, ?, ?)`
+	result, err := tx.Exec(q, a.TournamentID, a.PlayerID, a.StartingSeat, a.CurrentSeat)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	a.Id = int(id)
+	return nil
+}
+
 func (m *AttendeeModel) StartingSeat(id, seat int) error {
 	q := `UPDATE attendees SET current_seat = ?, starting_seat = ? WHERE id = ?`
 	_, err := m.DB.Exec(q, seat, seat, id)
 	return err
 }
 
+// SeatTx puts the attendee on its starting seat as part of a transaction
+func (m *AttendeeModel) SeatTx(tx *sql.Tx, id, seat int) error {
+	q := `UPDATE attendees SET current_seat = ?, starting_seat = ? WHERE id = ?`
+	_, err := tx.Exec(q, seat, seat, id)
+	return err
+}
+
 func (m *AttendeeModel) ResetSeatPos(tournamentId string) error {
 	q := `UPDATE attendees SET current_seat = NULL WHERE tournament_id = ?`
 	result, err := m.DB.Exec(q, tournamentId)
@@ -57,11 +96,14 @@ func (m *AttendeeModel) ResetSeatPos(tournamentId string) error {
 	return nil
 }
 
+// List returns the attendees taking part in the tournament, waitlisted attendees are left out
 func (m *AttendeeModel) List(tournamentId string, seeded bool) ([]Attendee, error) {
 	attendees := []Attendee{}
-	q := `SELECT a.id, a.tournament_id, a.player_id, a.current_seat, p.id, p.name, p.discord_id
+	q := `SELECT a.id, a.tournament_id, a.player_id, a.starting_seat, a.current_seat, a.team_id, a.checked_in_at,
+			p.id, p.name, p.discord_id, IFNULL(tm.name, '')
 		  FROM attendees a JOIN players p ON a.player_id = p.id
-		  WHERE a.tournament_id = ? `
+		  LEFT JOIN teams tm ON tm.id = a.team_id
+		  WHERE a.tournament_id = ? AND a.waitlisted_at IS NULL `
 
 	if seeded {
 		q += `AND a.current_seat IS NOT NULL`
@@ -76,14 +118,303 @@ func (m *AttendeeModel) List(tournamentId string, seeded bool) ([]Attendee, erro
 	for rows.Next() {
 		a := &Attendee{}
 		err := rows.Scan(
-			&a.Id, &a.TournamentID, &a.PlayerID, &a.CurrentSeat, &a.Player.ID,
-			&a.Player.Name, &a.Player.DiscordID,
+			&a.Id, &a.TournamentID, &a.PlayerID, &a.StartingSeat, &a.CurrentSeat, &a.TeamID, &a.CheckedInAt,
+			&a.Player.ID, &a.Player.Name, &a.Player.DiscordID, &a.Team.Name,
 		)
 		if err != nil {
 			log.Fatal(err)
 		}
+		a.Team.ID = int(a.TeamID.Int64)
 		attendees = append(attendees, *a)
 	}
 
 	return attendees, nil
 }
+
+// Register adds the player to the tournament, players past the cap are put on the waitlist. Nothing
+// happens when the player is already registered or waitlisted.
+func (m *AttendeeModel) Register(tx *sql.Tx, tournamentID, playerID string, cap int) (registered, waitlisted bool, err error) {
+	var exists int
+	q := `SELECT COUNT(*) FROM attendees WHERE tournament_id = ? AND player_id = ?`
+	if err := tx.QueryRow(q, tournamentID, playerID).Scan(&exists); err != nil {
+		return false, false, err
+	}
+	if exists > 0 {
+		return false, false, nil
+	}
+
+	taken, err := m.Taken(tx, tournamentID)
+	if err != nil {
+		return false, false, err
+	}
+
+	var waitlistedAt sql.NullInt64
+	if cap > 0 && taken >= cap {
+		waitlistedAt = sql.NullInt64{Int64: time.Now().Unix(), Valid: true}
+	}
+
+	q = `INSERT INTO attendees (tournament_id, player_id, current_seat, waitlisted_at) VALUES (?, ?, NULL, ?)`
+	if _, err := tx.Exec(q, tournamentID, playerID, waitlistedAt); err != nil {
+		return false, false, err
+	}
+	return true, waitlistedAt.Valid, nil
+}
+
+// Taken counts the attendees that are not waitlisted, it locks the taken spots so two registrations
+// can't take the last one at the same time
+func (m *AttendeeModel) Taken(tx *sql.Tx, tournamentID string) (int, error) {
+	var taken int
+	q := `SELECT COUNT(*) FROM attendees WHERE tournament_id = ? AND waitlisted_at IS NULL FOR UPDATE`
+	err := tx.QueryRow(q, tournamentID).Scan(&taken)
+	return taken, err
+}
+
+// Leave removes the player from a tournament that has not been started, the first waitlisted attendee
+// takes the spot when the player was taking part. In a seeded bracket the promoted attendee takes the
+// seat of the player, or a free seat when the player had none. promoted is nil when nobody was promoted.
+func (m *AttendeeModel) Leave(tx *sql.Tx, tournamentID, playerID string, cap int) (promoted *Attendee, err error) {
+	var (
+		id           int
+		seat         sql.NullInt64
+		waitlistedAt sql.NullInt64
+	)
+	q := `SELECT id, current_seat, waitlisted_at FROM attendees WHERE tournament_id = ? AND player_id = ? FOR UPDATE`
+	if err := tx.QueryRow(q, tournamentID, playerID).Scan(&id, &seat, &waitlistedAt); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM attendees WHERE id = ?`, id); err != nil {
+		return nil, err
+	}
+	if waitlistedAt.Valid {
+		return nil, nil
+	}
+
+	promoted = &Attendee{}
+	q = `SELECT a.id, a.tournament_id, a.player_id, p.id, p.name, p.discord_id
+		 FROM attendees a JOIN players p ON p.id = a.player_id
+		 WHERE a.tournament_id = ? AND a.waitlisted_at IS NOT NULL
+		 ORDER BY a.waitlisted_at, a.id LIMIT 1 FOR UPDATE`
+	err = tx.QueryRow(q, tournamentID).Scan(&promoted.Id, &promoted.TournamentID, &promoted.PlayerID,
+		&promoted.Player.ID, &promoted.Player.Name, &promoted.Player.DiscordID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Promote(tx, promoted.Id); err != nil {
+		return nil, err
+	}
+	if seat.Valid {
+		err = m.SeatTx(tx, promoted.Id, int(seat.Int64))
+	} else {
+		err = m.seatPromoted(tx, tournamentID, cap, []Attendee{*promoted})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// Promote moves the attendee from the waitlist into the tournament
+func (m *AttendeeModel) Promote(tx *sql.Tx, id int) error {
+	_, err := tx.Exec(`UPDATE attendees SET waitlisted_at = NULL WHERE id = ?`, id)
+	return err
+}
+
+// Waitlist returns the waitlisted attendees of the tournament in the order they will be promoted
+func (m *AttendeeModel) Waitlist(tournamentId string) ([]Attendee, error) {
+	attendees := []Attendee{}
+	q := `SELECT a.id, a.tournament_id, a.player_id, a.waitlisted_at, a.checked_in_at, p.id, p.name, p.discord_id
+		  FROM attendees a JOIN players p ON a.player_id = p.id
+		  WHERE a.tournament_id = ? AND a.waitlisted_at IS NOT NULL
+		  ORDER BY a.waitlisted_at, a.id`
+
+	rows, err := m.DB.Query(q, tournamentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Attendee
+		err := rows.Scan(&a.Id, &a.TournamentID, &a.PlayerID, &a.WaitlistedAt, &a.CheckedInAt, &a.Player.ID,
+			&a.Player.Name, &a.Player.DiscordID)
+		if err != nil {
+			return nil, err
+		}
+		attendees = append(attendees, a)
+	}
+
+	return attendees, rows.Err()
+}
+
+// DiscordIDs returns the discord ids of the members the attendee stands for, the whole roster when the
+// attendee is a team
+func (m *AttendeeModel) DiscordIDs(id int) ([]string, error) {
+	ids := []string{}
+	q := `SELECT p.discord_id FROM attendees a JOIN players p ON p.id = a.player_id
+		  WHERE a.id = ? AND a.team_id IS NULL
+		  UNION
+		  SELECT p.discord_id FROM attendees a
+		  JOIN team_members mb ON mb.team_id = a.team_id
+		  JOIN players p ON p.id = mb.player_id
+		  WHERE a.id = ?`
+
+	rows, err := m.DB.Query(q, id, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var discordID string
+		if err := rows.Scan(&discordID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, discordID)
+	}
+
+	return ids, rows.Err()
+}
+
+// CheckIn marks the attendee as present, checking in twice keeps the first check-in time
+func (m *AttendeeModel) CheckIn(id int) error {
+	q := `UPDATE attendees SET checked_in_at = IFNULL(checked_in_at, ?) WHERE id = ?`
+	_, err := m.DB.Exec(q, time.Now().Unix(), id)
+	return err
+}
+
+// CloseCheckIn removes the attendees that did not check in and fills the freed spots with checked-in
+// waitlisted attendees in the order they joined the waitlist. Team attendees are removed along with
+// their team. Waitlisted attendees that did not check in stay on the waitlist.
+func (m *AttendeeModel) CloseCheckIn(tx *sql.Tx, tournamentID string, cap int) (dropped, promoted []Attendee, err error) {
+	q := `SELECT a.id, a.team_id, p.id, p.name, p.discord_id
+		  FROM attendees a JOIN players p ON p.id = a.player_id
+		  WHERE a.tournament_id = ? AND a.waitlisted_at IS NULL AND a.checked_in_at IS NULL
+		  FOR UPDATE`
+	dropped, err = scanCheckInAttendees(tx, q, tournamentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, a := range dropped {
+		if a.TeamID.Valid {
+			_, err = tx.Exec(`DELETE FROM teams WHERE id = ?`, a.TeamID.Int64)
+		} else {
+			_, err = tx.Exec(`DELETE FROM attendees WHERE id = ?`, a.Id)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var taken int
+	q = `SELECT COUNT(*) FROM attendees WHERE tournament_id = ? AND waitlisted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRow(q, tournamentID).Scan(&taken); err != nil {
+		return nil, nil, err
+	}
+
+	free := cap - taken
+	if cap <= 0 || free <= 0 {
+		return dropped, []Attendee{}, nil
+	}
+
+	q = `SELECT a.id, a.team_id, p.id, p.name, p.discord_id
+		 FROM attendees a JOIN players p ON p.id = a.player_id
+		 WHERE a.tournament_id = ? AND a.waitlisted_at IS NOT NULL AND a.checked_in_at IS NOT NULL
+		 ORDER BY a.waitlisted_at, a.id LIMIT ? FOR UPDATE`
+	promoted, err = scanCheckInAttendees(tx, q, tournamentID, free)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, a := range promoted {
+		if err := m.Promote(tx, a.Id); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := m.seatPromoted(tx, tournamentID, cap, promoted); err != nil {
+		return nil, nil, err
+	}
+	return dropped, promoted, nil
+}
+
+// seatPromoted puts the promoted attendees on the seats left free in a seeded bracket, such as the
+// seats of the dropped attendees, so the seeding of everyone else is kept. A bracket that has not been
+// seeded is left alone, it is seeded with every attendee when the tournament starts.
+func (m *AttendeeModel) seatPromoted(tx *sql.Tx, tournamentID string, cap int, promoted []Attendee) error {
+	if len(promoted) == 0 {
+		return nil
+	}
+
+	q := `SELECT current_seat FROM attendees
+		  WHERE tournament_id = ? AND waitlisted_at IS NULL AND current_seat IS NOT NULL`
+	rows, err := tx.Query(q, tournamentID)
+	if err != nil {
+		return err
+	}
+	occupied := make(map[int]bool)
+	for rows.Next() {
+		var seat int
+		if err := rows.Scan(&seat); err != nil {
+			rows.Close()
+			return err
+		}
+		occupied[seat] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(occupied) == 0 {
+		return nil
+	}
+
+	b, err := bracket.GenerateFromTemplate(cap)
+	if err != nil {
+		return err
+	}
+	seats := FreeSeats(b.StartingSeats, occupied)
+	for idx, a := range promoted {
+		if idx >= len(seats) {
+			break
+		}
+		if err := m.SeatTx(tx, a.Id, seats[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FreeSeats returns the starting seats nobody holds in the order they are seeded
+func FreeSeats(starting []int, occupied map[int]bool) []int {
+	free := make([]int, 0, len(starting))
+	for _, seat := range starting {
+		if !occupied[seat] {
+			free = append(free, seat)
+		}
+	}
+	return free
+}
+
+func scanCheckInAttendees(tx *sql.Tx, q string, args ...interface{}) ([]Attendee, error) {
+	attendees := []Attendee{}
+	rows, err := tx.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Attendee
+		if err := rows.Scan(&a.Id, &a.TeamID, &a.Player.ID, &a.Player.Name, &a.Player.DiscordID); err != nil {
+			return nil, err
+		}
+		attendees = append(attendees, a)
+	}
+	return attendees, rows.Err()
+}
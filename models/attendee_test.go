@@ -0,0 +1,17 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFreeSeats(t *testing.T) {
+	starting := []int{1, 7, 5, 3}
+
+	if got := FreeSeats(starting, map[int]bool{1: true, 3: true}); !reflect.DeepEqual(got, []int{7, 5}) {
+		t.Errorf("got %v, want the free seats in seeding order", got)
+	}
+	if got := FreeSeats(starting, map[int]bool{1: true, 3: true, 5: true, 7: true}); len(got) != 0 {
+		t.Errorf("got %v, want no free seat in a full bracket", got)
+	}
+}
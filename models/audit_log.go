@@ -0,0 +1,158 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	AUDIT_SEED           = "seed"
+	AUDIT_START          = "start"
+	AUDIT_RESTART        = "restart"
+	AUDIT_DELETE         = "delete"
+	AUDIT_RESULT         = "processresult"
+	AUDIT_ADMIN_ADD      = "admin_add"
+	AUDIT_ADMIN_REMOVE   = "admin_remove"
+	AUDIT_EDIT           = "edit"
+	AUDIT_PUBLISH        = "publish"
+	AUDIT_IMPORT         = "import"
+	AUDIT_RATING_REBUILD = "rating_rebuild"
+	AUDIT_SEASON_CREATE  = "season_create"
+	AUDIT_SEASON_POINTS  = "season_points"
+	AUDIT_SEASON_ARCHIVE = "season_archive"
+	AUDIT_CONFIG         = "config"
+	AUDIT_STAFF_ADD      = "staff_add"
+	AUDIT_STAFF_REMOVE   = "staff_remove"
+	AUDIT_PERMISSION     = "permission"
+	AUDIT_REFEREE        = "referee"
+)
+
+type AuditLog struct {
+	ID           int
+	GuildID      sql.NullString
+	ActorID      string
+	Action       string
+	TournamentID sql.NullString
+	Before       sql.NullString
+	After        sql.NullString
+	CreatedAt    int64
+}
+
+// snapshot of the tournament props that are worth keeping in the audit log,
+// tournament ids are stored as []uint8 so it can't be marshalled as is
+type TournamentSnapshot struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	Rules            string `json:"rules,omitempty"`
+	TournamentTypeID int    `json:"tournament_type_id"`
+	ThreadID         string `json:"thread_id,omitempty"`
+	Published        bool   `json:"published"`
+	StartingAt       int64  `json:"starting_at,omitempty"`
+	BestOf           int    `json:"best_of"`
+	SelfRegister     bool   `json:"self_register"`
+}
+
+type SeatSnapshot struct {
+	AttendeeID int    `json:"attendee_id"`
+	DiscordID  string `json:"discord_id"`
+	Seat       int64  `json:"seat"`
+}
+
+func SnapshotTournament(t *Tournament) *TournamentSnapshot {
+	if t == nil {
+		return nil
+	}
+	return &TournamentSnapshot{
+		ID:               string(t.ID),
+		Name:             t.Name,
+		Description:      t.Description.String,
+		Rules:            t.Rules.String,
+		TournamentTypeID: t.Tournament_Types_ID,
+		ThreadID:         t.Thread_ID.String,
+		Published:        t.Published,
+		StartingAt:       t.Starting_At.Int64,
+		BestOf:           t.Best_Of,
+		SelfRegister:     t.Self_Register,
+	}
+}
+
+func SnapshotSeats(attendees []Attendee) []SeatSnapshot {
+	seats := make([]SeatSnapshot, 0, len(attendees))
+	for _, a := range attendees {
+		seats = append(seats, SeatSnapshot{
+			AttendeeID: a.Id,
+			DiscordID:  a.Player.DiscordID,
+			Seat:       a.CurrentSeat.Int64,
+		})
+	}
+	return seats
+}
+
+type AuditLogModel struct {
+	DB *sql.DB
+}
+
+func NewAuditLogModel(db *sql.DB) *AuditLogModel {
+	return &AuditLogModel{
+		DB: db,
+	}
+}
+
+func (m *AuditLogModel) Insert(l *AuditLog) error {
+	q := `INSERT INTO audit_logs (guild_id, actor_id, action, tournament_id, before_data, after_data, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	l.CreatedAt = time.Now().Unix()
+	result, err := m.DB.Exec(q, l.GuildID, l.ActorID, l.Action, l.TournamentID, l.Before, l.After, l.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	l.ID = int(id)
+	return nil
+}
+
+func (m *AuditLogModel) Count(guildID string) (int, error) {
+	var count int
+	q := `SELECT COUNT(*) FROM audit_logs WHERE guild_id = ?`
+	err := m.DB.QueryRow(q, guildID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// List returns the audit entries of a guild from the newest one, a limit of 0 returns every entry
+func (m *AuditLogModel) List(guildID string, limit, offset int) ([]AuditLog, error) {
+	logs := []AuditLog{}
+	q := `SELECT id, guild_id, actor_id, action, tournament_id, before_data, after_data, created_at
+		  FROM audit_logs WHERE guild_id = ? ORDER BY id DESC`
+	args := []interface{}{guildID}
+
+	if limit > 0 {
+		q += ` LIMIT ? OFFSET ?`
+		args = append(args, limit, offset)
+	}
+
+	rows, err := m.DB.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l AuditLog
+		err := rows.Scan(
+			&l.ID, &l.GuildID, &l.ActorID, &l.Action, &l.TournamentID, &l.Before, &l.After, &l.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	return logs, rows.Err()
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+const (
+	DEFAULT_TIMEZONE = "UTC"
+	DEFAULT_LOCALE   = "en"
+)
+
+type GuildSettings struct {
+	GuildID             string
+	TournamentChannelID sql.NullString
+	ManagerRoleID       sql.NullString
+	DefaultTypeID       sql.NullInt64
+	Timezone            string
+	Locale              string
+	EmbedColor          sql.NullInt64
+	UpdatedBy           string
+	UpdatedAt           int64
+}
+
+// DefaultGuildSettings are the settings of a guild that has never been configured
+func DefaultGuildSettings(guildID string) *GuildSettings {
+	return &GuildSettings{
+		GuildID:  guildID,
+		Timezone: DEFAULT_TIMEZONE,
+		Locale:   DEFAULT_LOCALE,
+	}
+}
+
+// Location is the time zone start times of the guild are written in, unknown zones fall back to UTC
+func (g *GuildSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(g.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Color is the embed color of the guild, 0 leaves the discord default
+func (g *GuildSettings) Color() int {
+	return int(g.EmbedColor.Int64)
+}
+
+// ParseEmbedColor parses a hex color such as #5865F2, an empty value clears the color
+func ParseEmbedColor(v string) (sql.NullInt64, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(v), "#")
+	if hex == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	c, err := strconv.ParseInt(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return sql.NullInt64{}, i18n.NewError("config.invalid_color", v)
+	}
+	return sql.NullInt64{Int64: c, Valid: true}, nil
+}
+
+func FormatEmbedColor(c sql.NullInt64) string {
+	if !c.Valid {
+		return ""
+	}
+	return fmt.Sprintf("#%06X", c.Int64)
+}
+
+type GuildSettingsModel struct {
+	DB *sql.DB
+}
+
+func NewGuildSettingsModel(db *sql.DB) *GuildSettingsModel {
+	return &GuildSettingsModel{
+		DB: db,
+	}
+}
+
+// Get returns the settings of the guild, or the defaults when the guild has not been configured
+func (m *GuildSettingsModel) Get(guildID string) (*GuildSettings, error) {
+	g := &GuildSettings{}
+	q := `SELECT guild_id, tournament_channel_id, manager_role_id, default_tournament_types_id, timezone, locale,
+			embed_color, updated_by, updated_at
+		  FROM guild_settings WHERE guild_id = ?`
+	err := m.DB.QueryRow(q, guildID).Scan(
+		&g.GuildID, &g.TournamentChannelID, &g.ManagerRoleID, &g.DefaultTypeID, &g.Timezone, &g.Locale,
+		&g.EmbedColor, &g.UpdatedBy, &g.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return DefaultGuildSettings(guildID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Save stores every setting of the guild, replacing the previous ones
+func (m *GuildSettingsModel) Save(g *GuildSettings) error {
+	q := `INSERT INTO guild_settings (guild_id, tournament_channel_id, manager_role_id, default_tournament_types_id,
+			timezone, locale, embed_color, updated_by, updated_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE tournament_channel_id = VALUES(tournament_channel_id),
+			manager_role_id = VALUES(manager_role_id), default_tournament_types_id = VALUES(default_tournament_types_id),
+			timezone = VALUES(timezone), locale = VALUES(locale), embed_color = VALUES(embed_color),
+			updated_by = VALUES(updated_by), updated_at = VALUES(updated_at)`
+
+	g.UpdatedAt = time.Now().Unix()
+	_, err := m.DB.Exec(q, g.GuildID, g.TournamentChannelID, g.ManagerRoleID, g.DefaultTypeID, g.Timezone,
+		g.Locale, g.EmbedColor, g.UpdatedBy, g.UpdatedAt)
+	return err
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestParseEmbedColor(t *testing.T) {
+	cases := map[string]sql.NullInt64{
+		"#5865F2":   {Int64: 0x5865F2, Valid: true},
+		"ff0000":    {Int64: 0xFF0000, Valid: true},
+		" #000000 ": {Int64: 0, Valid: true},
+		"":          {},
+	}
+	for v, expected := range cases {
+		got, err := ParseEmbedColor(v)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", v, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("expected %v for %q, got %v", expected, v, got)
+		}
+	}
+
+	for _, v := range []string{"#fff", "red", "#GGGGGG", "#1234567"} {
+		if _, err := ParseEmbedColor(v); err == nil {
+			t.Errorf("expected %q to be rejected", v)
+		}
+	}
+
+	if got := FormatEmbedColor(sql.NullInt64{Int64: 0x5865F2, Valid: true}); got != "#5865F2" {
+		t.Errorf("expected #5865F2, got %s", got)
+	}
+}
+
+func TestGuildSettingsLocation(t *testing.T) {
+	g := DefaultGuildSettings("1")
+	if g.Location() != time.UTC {
+		t.Errorf("expected the default time zone to be UTC, got %v", g.Location())
+	}
+
+	g.Timezone = "Asia/Jakarta"
+	if g.Location().String() != "Asia/Jakarta" {
+		t.Errorf("expected Asia/Jakarta, got %v", g.Location())
+	}
+
+	g.Timezone = "Mars/Olympus"
+	if g.Location() != time.UTC {
+		t.Errorf("expected unknown zones to fall back to UTC, got %v", g.Location())
+	}
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"database/sql"
+	"sort"
+)
+
+// LeaderboardEntry is one ranked player, Value is whatever the leaderboard is ranked by
+type LeaderboardEntry struct {
+	Position       int
+	Player         Player
+	Value          float64
+	Wins           int
+	Losses         int
+	Tournaments    int
+	TournamentWins int
+}
+
+type LeaderboardModel struct {
+	DB *sql.DB
+}
+
+func NewLeaderboardModel(db *sql.DB) *LeaderboardModel {
+	return &LeaderboardModel{
+		DB: db,
+	}
+}
+
+func (m *LeaderboardModel) query(q string, args ...interface{}) ([]LeaderboardEntry, error) {
+	entries := []LeaderboardEntry{}
+	rows, err := m.DB.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e LeaderboardEntry
+		err := rows.Scan(&e.Player.ID, &e.Player.Name, &e.Player.DiscordID, &e.Value, &e.Wins, &e.Losses,
+			&e.Tournaments, &e.TournamentWins)
+		if err != nil {
+			return nil, err
+		}
+		e.Position = len(entries) + 1
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// ByRating ranks the rated players of the guild by their glicko-2 rating
+func (m *LeaderboardModel) ByRating(guildID, game string) ([]LeaderboardEntry, error) {
+	q := `SELECT p.id, p.name, p.discord_id, r.rating, r.wins, r.losses, 0, 0
+		  FROM player_ratings r JOIN players p ON p.id = r.player_id
+		  WHERE r.guild_id = ? AND r.game = ?
+		  ORDER BY r.rating DESC, r.deviation, p.name`
+	return m.query(q, guildID, game)
+}
+
+// ByTournamentWins ranks the players of the guild by how many tournaments they won, a tournament is
+// won by the winner of its last round
+func (m *LeaderboardModel) ByTournamentWins(guildID string) ([]LeaderboardEntry, error) {
+	q := `SELECT p.id, p.name, p.discord_id, COUNT(*) AS won, 0, 0, 0, COUNT(*)
+		  FROM matches m
+		  JOIN tournaments t ON t.id = m.tournament_id
+		  JOIN attendees a ON a.id = m.winner_attendee_id
+		  JOIN players p ON p.id = a.player_id
+		  WHERE t.guild_id = ? AND m.status IN (?, ?)
+			AND m.round = (SELECT MAX(f.round) FROM matches f WHERE f.tournament_id = m.tournament_id)
+		  GROUP BY p.id, p.name, p.discord_id
+		  ORDER BY won DESC, p.name`
+	return m.query(q, guildID, MATCH_COMPLETED, MATCH_WALKOVER)
+}
+
+// BySeason ranks the players by the points they collected in the season, archived seasons are read
+// from their final standings
+func (m *LeaderboardModel) BySeason(s *Season) ([]LeaderboardEntry, error) {
+	if s.Archived {
+		q := `SELECT p.id, p.name, p.discord_id, st.points, 0, 0, st.tournaments, st.tournament_wins
+			  FROM season_standings st JOIN players p ON p.id = st.player_id
+			  WHERE st.season_id = ?
+			  ORDER BY st.position`
+		return m.query(q, s.ID)
+	}
+
+	tiers, err := ParsePointTiers(s.PointTiers)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := NewSeasonModel(m.DB).Records(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `SELECT a.id, p.id, p.name, p.discord_id
+		  FROM attendees a
+		  JOIN tournaments t ON t.id = a.tournament_id
+		  JOIN players p ON p.id = a.player_id
+		  WHERE t.guild_id = ? AND t.started_at BETWEEN ? AND ?`
+	rows, err := m.DB.Query(q, s.GuildID, s.StartsAt, s.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attendees := make(map[int]string)
+	players := make(map[string]Player)
+	for rows.Next() {
+		var (
+			attendeeID int
+			p          Player
+		)
+		if err := rows.Scan(&attendeeID, &p.ID, &p.Name, &p.DiscordID); err != nil {
+			return nil, err
+		}
+		attendees[attendeeID] = string(p.ID)
+		players[string(p.ID)] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := []LeaderboardEntry{}
+	for playerID, score := range ScoreSeason(records, attendees, tiers) {
+		entries = append(entries, LeaderboardEntry{
+			Player:         players[playerID],
+			Value:          float64(score.Points),
+			Tournaments:    score.Tournaments,
+			TournamentWins: score.TournamentWins,
+		})
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].Value != entries[b].Value {
+			return entries[a].Value > entries[b].Value
+		}
+		if entries[a].TournamentWins != entries[b].TournamentWins {
+			return entries[a].TournamentWins > entries[b].TournamentWins
+		}
+		return entries[a].Player.Name < entries[b].Player.Name
+	})
+	for idx := range entries {
+		entries[idx].Position = idx + 1
+	}
+
+	return entries, nil
+}
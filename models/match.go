@@ -1,8 +1,512 @@
 package models
 
-import "github.com/dimfu/spade/bracket"
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/dimfu/spade/bracket"
+	"github.com/dimfu/spade/i18n"
+)
+
+type MatchStatus = string
+
+const (
+	MATCH_PENDING   MatchStatus = "pending"
+	MATCH_READY     MatchStatus = "ready"
+	MATCH_LIVE      MatchStatus = "live"
+	MATCH_COMPLETED MatchStatus = "completed"
+	MATCH_WALKOVER  MatchStatus = "walkover"
+)
+
+// Match is the unit being processed by the match queue, ID refers to the persisted match record
 type Match struct {
+	ID int
 	P1 *bracket.Node
 	P2 *bracket.Node
 }
+
+type MatchRecord struct {
+	ID               int
+	TournamentID     string
+	Round            int
+	Number           int
+	P1AttendeeID     sql.NullInt64
+	P2AttendeeID     sql.NullInt64
+	P1Seat           int
+	P2Seat           int
+	WinnerTo         int
+	WinnerAttendeeID sql.NullInt64
+	Status           MatchStatus
+	P1Score          int
+	P2Score          int
+	ChannelID        sql.NullString
+	MessageID        sql.NullString
+	StartedAt        sql.NullInt64
+	CompletedAt      sql.NullInt64
+	CreatedAt        int64
+	RefereeID        sql.NullString
+	P1CheckedInAt    sql.NullInt64
+	P2CheckedInAt    sql.NullInt64
+}
+
+func (r *MatchRecord) Done() bool {
+	return r.Status == MATCH_COMPLETED || r.Status == MATCH_WALKOVER
+}
+
+// Side returns 1 or 2 when the attendee plays the match as the first or second participant, and 0
+// when the attendee does not play it
+func (r *MatchRecord) Side(attendeeID int) int {
+	switch {
+	case r.P1AttendeeID.Valid && int(r.P1AttendeeID.Int64) == attendeeID:
+		return 1
+	case r.P2AttendeeID.Valid && int(r.P2AttendeeID.Int64) == attendeeID:
+		return 2
+	}
+	return 0
+}
+
+type MatchModel struct {
+	DB *sql.DB
+}
+
+func NewMatchModel(db *sql.DB) *MatchModel {
+	return &MatchModel{
+		DB: db,
+	}
+}
+
+const matchColumns = `id, tournament_id, round, number, p1_attendee_id, p2_attendee_id, p1_seat, p2_seat,
+	winner_to, winner_attendee_id, status, p1_score, p2_score, channel_id, message_id,
+	started_at, completed_at, created_at, referee_id, p1_checked_in_at, p2_checked_in_at`
+
+// prefixColumns qualifies every column of the list with the table alias, for queries that join tables
+// sharing column names
+func prefixColumns(alias, columns string) string {
+	cols := strings.Split(columns, ",")
+	for idx, col := range cols {
+		cols[idx] = alias + "." + strings.TrimSpace(col)
+	}
+	return strings.Join(cols, ", ")
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func scanMatch(row rowScanner) (*MatchRecord, error) {
+	r := &MatchRecord{}
+	err := row.Scan(
+		&r.ID, &r.TournamentID, &r.Round, &r.Number, &r.P1AttendeeID, &r.P2AttendeeID, &r.P1Seat, &r.P2Seat,
+		&r.WinnerTo, &r.WinnerAttendeeID, &r.Status, &r.P1Score, &r.P2Score, &r.ChannelID, &r.MessageID,
+		&r.StartedAt, &r.CompletedAt, &r.CreatedAt, &r.RefereeID, &r.P1CheckedInAt, &r.P2CheckedInAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *MatchModel) Insert(tx *sql.Tx, r *MatchRecord) error {
+	q := `INSERT INTO matches (tournament_id, round, number, p1_attendee_id, p2_attendee_id, p1_seat, p2_seat,
+			winner_to, status, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	r.CreatedAt = time.Now().Unix()
+	result, err := tx.Exec(q, r.TournamentID, r.Round, r.Number, r.P1AttendeeID, r.P2AttendeeID, r.P1Seat, r.P2Seat,
+		r.WinnerTo, r.Status, r.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.ID = int(id)
+	return nil
+}
+
+// BracketMatches lays out every match of the bracket with the attendees seated in it. Matches found in
+// the history are completed with its result, which rebuilds the matches of tournaments that were played
+// before matches were stored. The history only tells who won so those matches are scored 1-0.
+func BracketMatches(tournamentID string, b *bracket.BracketTree, seated map[int]int, histories []History) []*MatchRecord {
+	seatRound := make(map[int]int)
+	for round, seats := range b.SeatRoundPos {
+		for _, seat := range seats {
+			seatRound[seat] = round
+		}
+	}
+
+	occupants := make(map[int]int, len(seated)+len(histories))
+	for seat, attendeeID := range seated {
+		occupants[seat] = attendeeID
+	}
+	won := make(map[int]History)
+	for _, h := range histories {
+		if !h.Seat.Valid {
+			continue
+		}
+		seat := int(h.Seat.Int64)
+		occupants[seat] = h.AttendeeID
+		if h.Result == 1 {
+			won[seat] = h
+		}
+	}
+
+	records := make([]*MatchRecord, 0, len(b.Matches))
+	for idx, match := range b.Matches {
+		r := &MatchRecord{
+			TournamentID: tournamentID,
+			Round:        seatRound[match.Seats[0]],
+			Number:       idx + 1,
+			P1Seat:       match.Seats[0],
+			P2Seat:       match.Seats[1],
+			WinnerTo:     match.WinnerTo,
+			Status:       MATCH_PENDING,
+		}
+		if id, ok := occupants[r.P1Seat]; ok {
+			r.P1AttendeeID = sql.NullInt64{Int64: int64(id), Valid: true}
+		}
+		if id, ok := occupants[r.P2Seat]; ok {
+			r.P2AttendeeID = sql.NullInt64{Int64: int64(id), Valid: true}
+		}
+		records = append(records, r)
+
+		winner, p1Won := won[r.P1Seat]
+		if !p1Won {
+			var p2Won bool
+			if winner, p2Won = won[r.P2Seat]; !p2Won {
+				// first round matches can be played right away even with a missing opponent
+				if (r.Round == 1 && (r.P1AttendeeID.Valid || r.P2AttendeeID.Valid)) ||
+					(r.P1AttendeeID.Valid && r.P2AttendeeID.Valid) {
+					r.Status = MATCH_READY
+				}
+				continue
+			}
+		}
+
+		r.WinnerAttendeeID = sql.NullInt64{Int64: int64(winner.AttendeeID), Valid: true}
+		r.CompletedAt = winner.CreatedAt
+		r.Status = MATCH_COMPLETED
+		if !r.P1AttendeeID.Valid || !r.P2AttendeeID.Valid {
+			r.Status = MATCH_WALKOVER
+		}
+		if p1Won {
+			r.P1Score = 1
+		} else {
+			r.P2Score = 1
+		}
+	}
+	return records
+}
+
+// Create stores every match of the bracket, see BracketMatches
+func (m *MatchModel) Create(tournamentID string, b *bracket.BracketTree) error {
+	attendees, err := NewAttendeeModel(m.DB).List(tournamentID, true)
+	if err != nil {
+		return err
+	}
+	seated := make(map[int]int, len(attendees))
+	for _, a := range attendees {
+		seated[int(a.CurrentSeat.Int64)] = a.Id
+	}
+
+	histories, err := NewMatchHistoryModel(m.DB).List(tournamentID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, r := range BracketMatches(tournamentID, b, seated, histories) {
+		if err := m.Restore(tx, r); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Backfill creates the matches of the tournaments that were played before matches were stored, their
+// results were only kept in match_histories
+func (m *MatchModel) Backfill() error {
+	q := `SELECT DISTINCT a.tournament_id, tt.size
+		  FROM match_histories mh
+		  JOIN attendees a ON a.id = mh.attendee_id
+		  JOIN tournaments t ON t.id = a.tournament_id
+		  JOIN tournament_types tt ON tt.id = t.tournament_types_id
+		  WHERE NOT EXISTS (SELECT 1 FROM matches m WHERE m.tournament_id = a.tournament_id)`
+	rows, err := m.DB.Query(q)
+	if err != nil {
+		return err
+	}
+
+	sizes := make(map[string]int)
+	for rows.Next() {
+		var (
+			tournamentID string
+			size         int
+		)
+		if err := rows.Scan(&tournamentID, &size); err != nil {
+			rows.Close()
+			return err
+		}
+		sizes[tournamentID] = size
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for tournamentID, size := range sizes {
+		b, err := bracket.GenerateFromTemplate(size)
+		if err != nil {
+			return fmt.Errorf("error backfilling matches of tournament %s: %w", tournamentID, err)
+		}
+		if err := m.Create(tournamentID, b); err != nil {
+			return fmt.Errorf("error backfilling matches of tournament %s: %w", tournamentID, err)
+		}
+	}
+	return nil
+}
+
+// Restore inserts a match record as is, including its result, discord messages are not carried over
+func (m *MatchModel) Restore(tx *sql.Tx, r *MatchRecord) error {
+	q := `INSERT INTO matches (tournament_id, round, number, p1_attendee_id, p2_attendee_id, p1_seat, p2_seat,
+			winner_to, winner_attendee_id, status, p1_score, p2_score, started_at, completed_at, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	r.CreatedAt = time.Now().Unix()
+	result, err := tx.Exec(q, r.TournamentID, r.Round, r.Number, r.P1AttendeeID, r.P2AttendeeID, r.P1Seat, r.P2Seat,
+		r.WinnerTo, r.WinnerAttendeeID, r.Status, r.P1Score, r.P2Score, r.StartedAt, r.CompletedAt, r.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.ID = int(id)
+	return nil
+}
+
+func (m *MatchModel) GetById(id int) (*MatchRecord, error) {
+	q := `SELECT ` + matchColumns + ` FROM matches WHERE id = ?`
+	return scanMatch(m.DB.QueryRow(q, id))
+}
+
+// List returns every match of the tournament in the order they should be played
+func (m *MatchModel) List(tournamentID string) ([]MatchRecord, error) {
+	return listMatches(m.DB, tournamentID)
+}
+
+// ListTx is List within a transaction, so matches completed by the transaction are seen
+func (m *MatchModel) ListTx(tx *sql.Tx, tournamentID string) ([]MatchRecord, error) {
+	return listMatches(tx, tournamentID)
+}
+
+func listMatches(db querier, tournamentID string) ([]MatchRecord, error) {
+	records := []MatchRecord{}
+	q := `SELECT ` + matchColumns + ` FROM matches WHERE tournament_id = ? ORDER BY number`
+
+	rows, err := db.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *r)
+	}
+
+	return records, rows.Err()
+}
+
+// FindByMessage returns the match that was posted as the given discord message
+func (m *MatchModel) FindByMessage(messageID string) (*MatchRecord, error) {
+	q := `SELECT ` + matchColumns + ` FROM matches WHERE message_id = ?`
+	return scanMatch(m.DB.QueryRow(q, messageID))
+}
+
+func (m *MatchModel) FindByNumber(tournamentID string, number int) (*MatchRecord, error) {
+	q := `SELECT ` + matchColumns + ` FROM matches WHERE tournament_id = ? AND number = ?`
+	return scanMatch(m.DB.QueryRow(q, tournamentID, number))
+}
+
+// AssignReferee sets the referee of the match, an invalid referee id clears it
+func (m *MatchModel) AssignReferee(id int, refereeID sql.NullString) error {
+	_, err := m.DB.Exec(`UPDATE matches SET referee_id = ? WHERE id = ?`, refereeID, id)
+	return err
+}
+
+// RefereeLoad counts the live matches every referee of the tournament is watching
+func (m *MatchModel) RefereeLoad(tournamentID string) (map[string]int, error) {
+	load := make(map[string]int)
+	q := `SELECT referee_id, COUNT(*) FROM matches
+		  WHERE tournament_id = ? AND status = ? AND referee_id IS NOT NULL
+		  GROUP BY referee_id`
+
+	rows, err := m.DB.Query(q, tournamentID, MATCH_LIVE)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			referee string
+			count   int
+		)
+		if err := rows.Scan(&referee, &count); err != nil {
+			return nil, err
+		}
+		load[referee] = count
+	}
+
+	return load, rows.Err()
+}
+
+// PickReferee rotates the referees across live matches, the referee watching the fewest matches is
+// picked and ties go to the referee listed first. It returns an empty string without referees.
+func PickReferee(referees []string, load map[string]int) string {
+	picked := ""
+	for _, r := range referees {
+		if picked == "" || load[r] < load[picked] {
+			picked = r
+		}
+	}
+	return picked
+}
+
+// CheckIn marks the side of the match as ready to play, checking in twice keeps the first time
+func (m *MatchModel) CheckIn(id, side int) error {
+	column := "p1_checked_in_at"
+	if side == 2 {
+		column = "p2_checked_in_at"
+	}
+	q := `UPDATE matches SET ` + column + ` = IFNULL(` + column + `, ?) WHERE id = ?`
+	_, err := m.DB.Exec(q, time.Now().Unix(), id)
+	return err
+}
+
+// Posted marks the match as live once the match embed is sent to discord
+func (m *MatchModel) Posted(id int, channelID, messageID string) error {
+	q := `UPDATE matches SET status = ?, channel_id = ?, message_id = ?, started_at = IFNULL(started_at, ?)
+		  WHERE id = ?`
+	_, err := m.DB.Exec(q, MATCH_LIVE, channelID, messageID, time.Now().Unix(), id)
+	return err
+}
+
+// ParseScore reads a reported score written as the games of the winner and the loser, such as 2-1.
+// The winner takes the majority of a best of and the loser fewer games than that.
+func ParseScore(text string, bestOf int) (winner, loser int, err error) {
+	if _, err := fmt.Sscanf(strings.ReplaceAll(strings.TrimSpace(text), " ", ""), "%d-%d", &winner, &loser); err != nil {
+		return 0, 0, i18n.NewError("match.score_format", text)
+	}
+
+	wins := bestOf/2 + 1
+	if winner != wins || loser < 0 || loser >= wins {
+		return 0, 0, i18n.NewError("match.score_invalid", text, bestOf, wins)
+	}
+	return winner, loser, nil
+}
+
+// Complete stores the match winner, a match that is missing one of the participant is stored as walkover
+func (m *MatchModel) Complete(tx *sql.Tx, id, winnerAttendeeID, winnerScore, loserScore int) error {
+	q := `UPDATE matches SET
+			winner_attendee_id = ?,
+			p1_score = IF(p1_attendee_id = ?, ?, ?),
+			p2_score = IF(p2_attendee_id = ?, ?, ?),
+			status = IF(p1_attendee_id IS NULL OR p2_attendee_id IS NULL, ?, ?),
+			completed_at = ?
+		  WHERE id = ?`
+
+	result, err := tx.Exec(q,
+		winnerAttendeeID,
+		winnerAttendeeID, winnerScore, loserScore,
+		winnerAttendeeID, winnerScore, loserScore,
+		MATCH_WALKOVER, MATCH_COMPLETED,
+		time.Now().Unix(), id,
+	)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("No match record updated")
+	}
+	return nil
+}
+
+// Advance puts the attendee into the match that is played from the given seat, the match becomes
+// ready once both participants are known. Advancing to the bracket root updates nothing.
+func (m *MatchModel) Advance(tx *sql.Tx, tournamentID string, seat, attendeeID int) error {
+	q := `UPDATE matches SET
+			p1_attendee_id = IF(p1_seat = ?, ?, p1_attendee_id),
+			p2_attendee_id = IF(p2_seat = ?, ?, p2_attendee_id)
+		  WHERE tournament_id = ? AND (p1_seat = ? OR p2_seat = ?)`
+	_, err := tx.Exec(q, seat, attendeeID, seat, attendeeID, tournamentID, seat, seat)
+	if err != nil {
+		return err
+	}
+
+	q = `UPDATE matches SET status = ?
+		 WHERE tournament_id = ? AND status = ? AND p1_attendee_id IS NOT NULL AND p2_attendee_id IS NOT NULL`
+	_, err = tx.Exec(q, MATCH_READY, tournamentID, MATCH_PENDING)
+	return err
+}
+
+// PlayerRanking returns the player ids of the tournament attendees ordered by how many matches they won
+func (m *MatchModel) PlayerRanking(tournamentID string) ([]string, error) {
+	ranking := []string{}
+	q := `SELECT a.player_id, COUNT(m.id) AS wins
+		  FROM attendees a
+		  LEFT JOIN matches m ON m.winner_attendee_id = a.id
+		  WHERE a.tournament_id = ?
+		  GROUP BY a.id, a.player_id
+		  ORDER BY wins DESC, a.starting_seat IS NULL, a.starting_seat`
+
+	rows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			playerID string
+			wins     int
+		)
+		if err := rows.Scan(&playerID, &wins); err != nil {
+			return nil, err
+		}
+		ranking = append(ranking, playerID)
+	}
+
+	return ranking, rows.Err()
+}
@@ -54,24 +54,53 @@ func (m *MatchHistoryModel) Insert(tx *sql.Tx, h *History) error {
 	return nil
 }
 
+// List returns the history of every attendee of the tournament in the order the results came in
+func (m *MatchHistoryModel) List(tournamentID string) ([]History, error) {
+	q := `SELECT mh.id, mh.attendee_id, mh.result, mh.seat, mh.created_at
+		  FROM match_histories mh JOIN attendees a ON a.id = mh.attendee_id
+		  WHERE a.tournament_id = ?
+		  ORDER BY mh.created_at, mh.id`
+	rows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []History
+	for rows.Next() {
+		var h History
+		if err := rows.Scan(&h.ID, &h.AttendeeID, &h.Result, &h.Seat, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+	return histories, rows.Err()
+}
+
+// CurrentTournamentHistory rebuilds the history of every seated attendee from the finished matches
+// of the tournament, each finished match becomes one history entry per participant
 func (m *MatchHistoryModel) CurrentTournamentHistory(tournamentID []uint8) ([]MatchHistory, error) {
 	q := `SELECT
-			mh.id AS history_id, 
-			mh.result, 
-			mh.seat, 
-			mh.created_at, 
-			a.id AS attendee_id, 
-			a.tournament_id, 
-			a.player_id, 
+			m.id AS history_id,
+			IF(m.winner_attendee_id = a.id, 1, 0) AS result,
+			IF(m.p1_attendee_id = a.id, m.p1_seat, m.p2_seat) AS seat,
+			m.completed_at,
+			a.id AS attendee_id,
+			a.tournament_id,
+			a.player_id,
 			a.current_seat,
 			p.name,
-			p.discord_id
+			p.discord_id,
+			a.team_id,
+			IFNULL(tm.name, '')
 		FROM attendees a
-		LEFT JOIN match_histories mh ON mh.attendee_id = a.id
+		LEFT JOIN matches m ON (m.p1_attendee_id = a.id OR m.p2_attendee_id = a.id) AND m.status IN (?, ?)
 		LEFT JOIN players p ON p.id = a.player_id
-		WHERE a.tournament_id = ? AND a.current_seat IS NOT NULL;
+		LEFT JOIN teams tm ON tm.id = a.team_id
+		WHERE a.tournament_id = ? AND a.current_seat IS NOT NULL
+		ORDER BY m.number;
 		`
-	rows, err := m.DB.Query(q, tournamentID)
+	rows, err := m.DB.Query(q, MATCH_COMPLETED, MATCH_WALKOVER, tournamentID)
 	if err != nil {
 		return nil, err
 	}
@@ -91,11 +120,13 @@ func (m *MatchHistoryModel) CurrentTournamentHistory(tournamentID []uint8) ([]Ma
 			currentSeat     int
 			playerName      string
 			playerDiscordID string
+			teamID          sql.NullInt64
+			teamName        string
 		)
 
 		if err := rows.Scan(
 			&historyID, &result, &seat, &createdAt, &attendeeID, &tournamentID,
-			&playerID, &currentSeat, &playerName, &playerDiscordID,
+			&playerID, &currentSeat, &playerName, &playerDiscordID, &teamID, &teamName,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -107,19 +138,27 @@ func (m *MatchHistoryModel) CurrentTournamentHistory(tournamentID []uint8) ([]Ma
 					TournamentID: tournamentID,
 					PlayerID:     string(playerID),
 					CurrentSeat:  sql.NullInt64{Int64: int64(currentSeat), Valid: true},
+					TeamID:       teamID,
 					Player: Player{
 						ID:        playerID,
 						Name:      playerName,
 						DiscordID: playerDiscordID,
 					},
+					Team: Team{
+						ID:           int(teamID.Int64),
+						TournamentID: tournamentID,
+						Name:         teamName,
+						CaptainID:    string(playerID),
+					},
 				},
 				Histories: []History{},
 			}
 		}
 		if historyID.Valid {
 			historyMap[attendeeID].Histories = append(historyMap[attendeeID].Histories, History{
-				ID:     int(historyID.Int64),
-				Result: int(result.Int64),
+				ID:         int(historyID.Int64),
+				AttendeeID: attendeeID,
+				Result:     int(result.Int64),
 				Seat: sql.NullInt64{
 					Int64: seat.Int64,
 					Valid: seat.Valid,
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/dimfu/spade/bracket"
+)
+
+func TestMatchRecordSide(t *testing.T) {
+	r := &MatchRecord{
+		P1AttendeeID: sql.NullInt64{Int64: 4, Valid: true},
+		P2AttendeeID: sql.NullInt64{Int64: 9, Valid: true},
+	}
+
+	cases := map[int]int{4: 1, 9: 2, 7: 0}
+	for attendeeID, want := range cases {
+		if got := r.Side(attendeeID); got != want {
+			t.Errorf("Side(%d) = %d, want %d", attendeeID, got, want)
+		}
+	}
+
+	// a missing participant is not the attendee with id 0
+	if got := (&MatchRecord{}).Side(0); got != 0 {
+		t.Errorf("Side(0) of an empty match = %d, want 0", got)
+	}
+}
+
+func TestParseScore(t *testing.T) {
+	tests := []struct {
+		text   string
+		bestOf int
+		winner int
+		loser  int
+		ok     bool
+	}{
+		{"2-1", 3, 2, 1, true},
+		{" 3 - 0 ", 5, 3, 0, true},
+		{"1-0", 1, 1, 0, true},
+		{"2-2", 3, 0, 0, false},
+		{"1-2", 3, 0, 0, false},
+		{"3-1", 3, 0, 0, false},
+		{"2:1", 3, 0, 0, false},
+		{"", 3, 0, 0, false},
+	}
+	for _, tt := range tests {
+		winner, loser, err := ParseScore(tt.text, tt.bestOf)
+		if (err == nil) != tt.ok || winner != tt.winner || loser != tt.loser {
+			t.Errorf("ParseScore(%q, %d) = %d, %d, %v", tt.text, tt.bestOf, winner, loser, err)
+		}
+	}
+}
+
+func TestBracketMatches(t *testing.T) {
+	b, err := bracket.GenerateFromTemplate(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a fresh bracket only has its first round seated
+	fresh := BracketMatches("t", b, map[int]int{1: 10, 3: 11, 5: 12}, nil)
+	if len(fresh) != 3 {
+		t.Fatalf("got %d matches, want 3", len(fresh))
+	}
+	for _, r := range fresh {
+		want := MATCH_PENDING
+		if r.Round == 1 {
+			want = MATCH_READY
+		}
+		if r.Status != want || r.WinnerAttendeeID.Valid {
+			t.Errorf("match %d: status %s winner %v, want %s without winner", r.Number, r.Status, r.WinnerAttendeeID, want)
+		}
+	}
+
+	// 10 beat 11, 12 had no opponent and 10 is now waiting in the final
+	histories := []History{
+		{AttendeeID: 10, Result: 1, Seat: sql.NullInt64{Int64: 1, Valid: true}, CreatedAt: sql.NullInt64{Int64: 100, Valid: true}},
+		{AttendeeID: 11, Result: 0, Seat: sql.NullInt64{Int64: 3, Valid: true}, CreatedAt: sql.NullInt64{Int64: 100, Valid: true}},
+		{AttendeeID: 12, Result: 1, Seat: sql.NullInt64{Int64: 5, Valid: true}, CreatedAt: sql.NullInt64{Int64: 120, Valid: true}},
+	}
+	records := BracketMatches("t", b, map[int]int{2: 10, 3: 11, 6: 12}, histories)
+
+	byNumber := make(map[int]*MatchRecord)
+	for _, r := range records {
+		byNumber[r.Number] = r
+	}
+	first, bye, final := byNumber[1], byNumber[2], byNumber[3]
+	if first.Status != MATCH_COMPLETED || first.WinnerAttendeeID.Int64 != 10 || first.P1Score != 1 || first.P2Score != 0 ||
+		first.CompletedAt.Int64 != 100 {
+		t.Errorf("first match = %+v", first)
+	}
+	if bye.Status != MATCH_WALKOVER || bye.WinnerAttendeeID.Int64 != 12 {
+		t.Errorf("walkover = %+v", bye)
+	}
+	if final.Status != MATCH_READY || final.P1AttendeeID.Int64 != 10 || final.P2AttendeeID.Int64 != 12 || final.WinnerAttendeeID.Valid {
+		t.Errorf("final = %+v", final)
+	}
+}
+
+func TestPickReferee(t *testing.T) {
+	referees := []string{"a", "b", "c"}
+	cases := []struct {
+		load     map[string]int
+		expected string
+	}{
+		{map[string]int{}, "a"},
+		{map[string]int{"a": 1}, "b"},
+		{map[string]int{"a": 1, "b": 1}, "c"},
+		{map[string]int{"a": 2, "b": 1, "c": 1}, "b"},
+		{map[string]int{"a": 1, "b": 2, "c": 3, "gone": 0}, "a"},
+	}
+	for _, c := range cases {
+		if got := PickReferee(referees, c.load); got != c.expected {
+			t.Errorf("expected %s for %v, got %s", c.expected, c.load, got)
+		}
+	}
+
+	if got := PickReferee(nil, map[string]int{}); got != "" {
+		t.Errorf("expected no referee, got %s", got)
+	}
+}
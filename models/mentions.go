@@ -0,0 +1,52 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+// mentionToken splits typed player lists on whitespace and commas, mentions written back to back
+// without a space in between are still split apart
+var mentionToken = regexp.MustCompile(`<@!?\d+>|[^\s,]+`)
+
+// ParseMention returns the user id of a `<@id>` or `<@!id>` mention or a plain user id
+func ParseMention(input string) (string, bool) {
+	id := strings.TrimSpace(input)
+	if strings.HasPrefix(id, "<@") && strings.HasSuffix(id, ">") {
+		id = strings.TrimPrefix(id[2:len(id)-1], "!")
+	}
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// ParseMentions returns the user ids of a list of players in the order they were given. Every entry
+// that is not a user, or that is listed twice, is named in the returned error.
+func ParseMentions(input string) ([]string, error) {
+	var (
+		ids      []string
+		problems []*i18n.Error
+	)
+	seen := make(map[string]bool)
+	for _, token := range mentionToken.FindAllString(input, -1) {
+		id, ok := ParseMention(token)
+		switch {
+		case !ok:
+			problems = append(problems, i18n.NewError("mentions.not_a_user", token))
+		case seen[id]:
+			problems = append(problems, i18n.NewError("mentions.listed_twice", id))
+		default:
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, i18n.Join(problems...)
+	}
+	return ids, nil
+}
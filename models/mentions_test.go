@@ -0,0 +1,53 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMention(t *testing.T) {
+	cases := []struct {
+		input string
+		id    string
+		ok    bool
+	}{
+		{input: "<@100>", id: "100", ok: true},
+		{input: "<@!200>", id: "200", ok: true},
+		{input: " 300 ", id: "300", ok: true},
+		{input: "<@&400>"},
+		{input: "<#500>"},
+		{input: "alice"},
+		{input: "<@>"},
+		{input: ""},
+	}
+
+	for _, c := range cases {
+		id, ok := ParseMention(c.input)
+		if id != c.id || ok != c.ok {
+			t.Errorf("ParseMention(%q) = %q, %v, want %q, %v", c.input, id, ok, c.id, c.ok)
+		}
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	ids, err := ParseMentions("<@100> <@!200>,300\n<@400><@500>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"100", "200", "300", "400", "500"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+
+	if ids, err := ParseMentions("   "); err != nil || len(ids) != 0 {
+		t.Errorf("empty input = %v, %v, want no ids", ids, err)
+	}
+
+	_, err = ParseMentions("<@100> bob <@!100> <@&5>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := "`bob` is not a mention or a user id\n<@100> is listed more than once\n`<@&5>` is not a mention or a user id"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type NotificationKind string
+
+const (
+	NOTIFY_MATCH_READY         NotificationKind = "match_ready"
+	NOTIFY_OPPONENT_CHECKED_IN NotificationKind = "opponent_checked_in"
+	NOTIFY_RESULTS             NotificationKind = "results"
+	NOTIFY_STARTING_SOON       NotificationKind = "starting_soon"
+)
+
+// NotificationKinds lists every kind in the order they are shown
+var NotificationKinds = []NotificationKind{
+	NOTIFY_MATCH_READY, NOTIFY_OPPONENT_CHECKED_IN, NOTIFY_RESULTS, NOTIFY_STARTING_SOON,
+}
+
+// NotificationPreferences are the direct messages a member wants to receive, they apply in every guild
+type NotificationPreferences struct {
+	DiscordID string
+	Enabled   map[NotificationKind]bool
+	UpdatedAt int64
+}
+
+// DefaultNotificationPreferences sends every notification
+func DefaultNotificationPreferences(discordID string) *NotificationPreferences {
+	p := &NotificationPreferences{DiscordID: discordID, Enabled: make(map[NotificationKind]bool)}
+	for _, kind := range NotificationKinds {
+		p.Enabled[kind] = true
+	}
+	return p
+}
+
+// Wants reports whether the member wants to be notified of kind, unknown kinds are always sent
+func (p *NotificationPreferences) Wants(kind NotificationKind) bool {
+	enabled, ok := p.Enabled[kind]
+	return enabled || !ok
+}
+
+type NotificationModel struct {
+	DB *sql.DB
+}
+
+func NewNotificationModel(db *sql.DB) *NotificationModel {
+	return &NotificationModel{
+		DB: db,
+	}
+}
+
+// Get returns the preferences of the member, the defaults when they never changed them
+func (m *NotificationModel) Get(discordID string) (*NotificationPreferences, error) {
+	p := DefaultNotificationPreferences(discordID)
+	var matchReady, checkedIn, results, startingSoon bool
+	q := `SELECT match_ready, opponent_checked_in, results, starting_soon, updated_at
+		  FROM notification_preferences WHERE discord_id = ?`
+	err := m.DB.QueryRow(q, discordID).Scan(&matchReady, &checkedIn, &results, &startingSoon, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.Enabled[NOTIFY_MATCH_READY] = matchReady
+	p.Enabled[NOTIFY_OPPONENT_CHECKED_IN] = checkedIn
+	p.Enabled[NOTIFY_RESULTS] = results
+	p.Enabled[NOTIFY_STARTING_SOON] = startingSoon
+	return p, nil
+}
+
+func (m *NotificationModel) Save(p *NotificationPreferences) error {
+	q := `INSERT INTO notification_preferences (discord_id, match_ready, opponent_checked_in, results,
+			starting_soon, updated_at)
+		  VALUES (?, ?, ?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE match_ready = VALUES(match_ready),
+			opponent_checked_in = VALUES(opponent_checked_in), results = VALUES(results),
+			starting_soon = VALUES(starting_soon), updated_at = VALUES(updated_at)`
+
+	p.UpdatedAt = time.Now().Unix()
+	_, err := m.DB.Exec(q, p.DiscordID, p.Wants(NOTIFY_MATCH_READY), p.Wants(NOTIFY_OPPONENT_CHECKED_IN),
+		p.Wants(NOTIFY_RESULTS), p.Wants(NOTIFY_STARTING_SOON), p.UpdatedAt)
+	return err
+}
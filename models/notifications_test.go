@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestNotificationPreferences(t *testing.T) {
+	p := DefaultNotificationPreferences("100")
+	for _, kind := range NotificationKinds {
+		if !p.Wants(kind) {
+			t.Errorf("%s is off by default", kind)
+		}
+	}
+
+	p.Enabled[NOTIFY_RESULTS] = false
+	if p.Wants(NOTIFY_RESULTS) {
+		t.Error("results are still sent after being turned off")
+	}
+	if !p.Wants(NOTIFY_MATCH_READY) {
+		t.Error("turning results off turned match ready off")
+	}
+
+	// kinds added later are sent until the member turns them off
+	if !p.Wants(NotificationKind("bracket_updated")) {
+		t.Error("unknown kinds are not sent")
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+// ParticipantRow is one line of a participants CSV, rows with Err set are skipped on import
+type ParticipantRow struct {
+	Line      int
+	Name      string
+	DiscordID string
+	Seat      int
+	Err       *i18n.Error
+}
+
+var participantsHeader = []string{"name", "discord_id", "current_seat"}
+
+// ParseParticipants reads a CSV in the format written by the participants export. Seats are only
+// validated against validSeats when it is not nil, otherwise they are ignored. An error is returned
+// when the file itself can't be read, problems with a single row are reported on the row.
+func ParseParticipants(r io.Reader, validSeats map[int]bool) ([]ParticipantRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, i18n.NewError("participants.empty")
+	}
+	if err != nil {
+		return nil, i18n.NewError("participants.invalid", err)
+	}
+	for idx, col := range participantsHeader {
+		if idx >= len(header) || strings.ToLower(strings.TrimSpace(header[idx])) != col {
+			return nil, i18n.NewError("participants.header", strings.Join(participantsHeader, ","))
+		}
+	}
+
+	rows := []ParticipantRow{}
+	discordIDs := make(map[string]int)
+	seats := make(map[int]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, i18n.NewError("participants.invalid", err)
+		}
+
+		row := ParticipantRow{Line: line}
+		if len(record) < 2 {
+			row.Err = i18n.NewError("participants.missing_discord_id")
+			rows = append(rows, row)
+			continue
+		}
+
+		row.Name = strings.TrimSpace(record[0])
+		id, ok := ParseMention(record[1])
+		row.DiscordID = id
+
+		if row.Name == "" {
+			row.Err = i18n.NewError("participants.missing_name")
+		} else if !ok {
+			row.Err = i18n.NewError("participants.not_a_discord_id", record[1])
+		} else if prev, ok := discordIDs[row.DiscordID]; ok {
+			row.Err = i18n.NewError("participants.duplicate", prev)
+		}
+
+		if row.Err == nil && validSeats != nil && len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			seat, err := strconv.Atoi(strings.TrimSpace(record[2]))
+			switch {
+			case err != nil:
+				row.Err = i18n.NewError("participants.not_a_seat", record[2])
+			case seat == 0:
+				// unseated attendees are exported with seat 0
+			case !validSeats[seat]:
+				row.Err = i18n.NewError("participants.not_a_starting_seat", seat)
+			case seats[seat] != 0:
+				row.Err = i18n.NewError("participants.seat_taken", seat, seats[seat])
+			default:
+				row.Seat = seat
+				seats[seat] = line
+			}
+		}
+
+		if row.Err == nil {
+			discordIDs[row.DiscordID] = line
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseParticipants(t *testing.T) {
+	input := strings.Join([]string{
+		"name,discord_id,current_seat",
+		"alice,<@100>,1",
+		"bob,<@!200>,3",
+		"carol,300,0",
+		"dave,not-an-id,5",
+		"erin,<@100>,7",
+		"frank,400,2",
+		"grace,500,3",
+		"heidi,600,",
+	}, "\n")
+
+	rows, err := ParseParticipants(strings.NewReader(input), map[int]bool{1: true, 3: true, 5: true, 7: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type expected struct {
+		discordID string
+		seat      int
+		hasErr    bool
+	}
+
+	want := []expected{
+		{discordID: "100", seat: 1},
+		{discordID: "200", seat: 3},
+		{discordID: "300"},
+		{hasErr: true},
+		{hasErr: true},
+		{hasErr: true},
+		{hasErr: true},
+		{discordID: "600"},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d", len(want), len(rows))
+	}
+
+	for idx, w := range want {
+		row := rows[idx]
+		if (row.Err != nil) != w.hasErr {
+			t.Errorf("line %d: expected error %v, got %v", row.Line, w.hasErr, row.Err)
+			continue
+		}
+		if w.hasErr {
+			continue
+		}
+		if row.DiscordID != w.discordID || row.Seat != w.seat {
+			t.Errorf("line %d: expected %s on seat %d, got %s on seat %d", row.Line, w.discordID, w.seat, row.DiscordID, row.Seat)
+		}
+	}
+}
+
+func TestParseParticipantsIgnoresSeats(t *testing.T) {
+	rows, err := ParseParticipants(strings.NewReader("name,discord_id,current_seat\nalice,100,42\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Err != nil || rows[0].Seat != 0 {
+		t.Errorf("expected seat to be ignored, got %+v", rows)
+	}
+}
+
+func TestParseParticipantsMissingName(t *testing.T) {
+	rows, err := ParseParticipants(strings.NewReader("name,discord_id,current_seat\n,100,\n  ,200,\nalice,300,\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	for _, row := range rows[:2] {
+		if row.Err == nil || row.Err.Key != "participants.missing_name" {
+			t.Errorf("line %d: expected a missing name error, got %v", row.Line, row.Err)
+		}
+	}
+	if rows[2].Err != nil || rows[2].Line != 4 {
+		t.Errorf("expected line 4 to be accepted, got %+v", rows[2])
+	}
+}
+
+func TestParseParticipantsHeader(t *testing.T) {
+	if _, err := ParseParticipants(strings.NewReader("discord_id,name\n100,alice\n"), nil); err == nil {
+		t.Error("expected an error for a wrong header")
+	}
+}
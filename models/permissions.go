@@ -0,0 +1,191 @@
+package models
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+)
+
+// Role is what a member is allowed to do in a tournament, every role can do what the roles below it can
+type Role int
+
+const (
+	ROLE_NONE Role = iota
+	// referees can only report match results
+	ROLE_REFEREE
+	// co-organizers run the tournament, from registering players to starting it
+	ROLE_ORGANIZER
+	// owners can also delete the tournament and pick its staff
+	ROLE_OWNER
+	// managers own every tournament of the guild
+	ROLE_MANAGER
+)
+
+var roleNames = map[Role]string{
+	ROLE_NONE:      "none",
+	ROLE_REFEREE:   "referee",
+	ROLE_ORGANIZER: "organizer",
+	ROLE_OWNER:     "owner",
+	ROLE_MANAGER:   "manager",
+}
+
+func (r Role) String() string {
+	return roleNames[r]
+}
+
+// ParseRole parses the roles that can be handed out to staff and guild roles
+func ParseRole(v string) (Role, bool) {
+	switch v {
+	case "referee":
+		return ROLE_REFEREE, true
+	case "organizer":
+		return ROLE_ORGANIZER, true
+	}
+	return ROLE_NONE, false
+}
+
+type StaffMember struct {
+	TournamentID string
+	UserID       string
+	Role         Role
+	AddedBy      string
+	AddedAt      int64
+}
+
+// RoleMapping gives the members of a guild role a role in every tournament of the guild
+type RoleMapping struct {
+	GuildID string
+	RoleID  string
+	Role    Role
+}
+
+// Member is what the role of a guild member is resolved from
+type Member struct {
+	UserID  string
+	Roles   []string
+	Manager bool
+}
+
+// ResolveRole returns the highest role the member holds in the tournament, a nil tournament only
+// resolves the roles that apply to every tournament of the guild
+func ResolveRole(m Member, t *Tournament, staff []StaffMember, mappings []RoleMapping) Role {
+	if m.Manager {
+		return ROLE_MANAGER
+	}
+	if t != nil && t.Owner_ID.Valid && t.Owner_ID.String == m.UserID {
+		return ROLE_OWNER
+	}
+
+	role := ROLE_NONE
+	if t != nil {
+		for _, st := range staff {
+			if st.TournamentID == string(t.ID) && st.UserID == m.UserID && st.Role > role {
+				role = st.Role
+			}
+		}
+	}
+	for _, mp := range mappings {
+		if slices.Contains(m.Roles, mp.RoleID) && mp.Role > role {
+			role = mp.Role
+		}
+	}
+	return role
+}
+
+type PermissionModel struct {
+	DB *sql.DB
+}
+
+func NewPermissionModel(db *sql.DB) *PermissionModel {
+	return &PermissionModel{
+		DB: db,
+	}
+}
+
+func (m *PermissionModel) Staff(tournamentID string) ([]StaffMember, error) {
+	staff := []StaffMember{}
+	q := `SELECT tournament_id, user_id, role, added_by, added_at FROM tournament_staff
+		  WHERE tournament_id = ? ORDER BY added_at`
+
+	rows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			st   StaffMember
+			role string
+		)
+		if err := rows.Scan(&st.TournamentID, &st.UserID, &role, &st.AddedBy, &st.AddedAt); err != nil {
+			return nil, err
+		}
+		st.Role, _ = ParseRole(role)
+		staff = append(staff, st)
+	}
+
+	return staff, rows.Err()
+}
+
+// AddStaff gives the member a role in the tournament, replacing the role the member had before
+func (m *PermissionModel) AddStaff(st *StaffMember) error {
+	q := `INSERT INTO tournament_staff (tournament_id, user_id, role, added_by, added_at) VALUES (?, ?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE role = VALUES(role), added_by = VALUES(added_by), added_at = VALUES(added_at)`
+
+	st.AddedAt = time.Now().Unix()
+	_, err := m.DB.Exec(q, st.TournamentID, st.UserID, st.Role.String(), st.AddedBy, st.AddedAt)
+	return err
+}
+
+// RemoveStaff takes the role of the member away, it returns sql.ErrNoRows when the member had none
+func (m *PermissionModel) RemoveStaff(tournamentID, userID string) error {
+	result, err := m.DB.Exec(`DELETE FROM tournament_staff WHERE tournament_id = ? AND user_id = ?`, tournamentID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (m *PermissionModel) Mappings(guildID string) ([]RoleMapping, error) {
+	mappings := []RoleMapping{}
+	rows, err := m.DB.Query(`SELECT guild_id, role_id, role FROM role_permissions WHERE guild_id = ?`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			mp   RoleMapping
+			role string
+		)
+		if err := rows.Scan(&mp.GuildID, &mp.RoleID, &role); err != nil {
+			return nil, err
+		}
+		mp.Role, _ = ParseRole(role)
+		mappings = append(mappings, mp)
+	}
+
+	return mappings, rows.Err()
+}
+
+// MapRole gives the members of the guild role a role in every tournament, ROLE_NONE removes the mapping
+func (m *PermissionModel) MapRole(guildID, roleID string, role Role) error {
+	if role == ROLE_NONE {
+		_, err := m.DB.Exec(`DELETE FROM role_permissions WHERE guild_id = ? AND role_id = ?`, guildID, roleID)
+		return err
+	}
+
+	q := `INSERT INTO role_permissions (guild_id, role_id, role) VALUES (?, ?, ?)
+		  ON DUPLICATE KEY UPDATE role = VALUES(role)`
+	_, err := m.DB.Exec(q, guildID, roleID, role.String())
+	return err
+}
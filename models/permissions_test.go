@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestResolveRole(t *testing.T) {
+	tournament := &Tournament{ID: []uint8("t1"), Owner_ID: sql.NullString{String: "owner", Valid: true}}
+	staff := []StaffMember{
+		{TournamentID: "t1", UserID: "ref", Role: ROLE_REFEREE},
+		{TournamentID: "t1", UserID: "org", Role: ROLE_ORGANIZER},
+		{TournamentID: "t2", UserID: "other", Role: ROLE_ORGANIZER},
+	}
+	mappings := []RoleMapping{
+		{GuildID: "g", RoleID: "referees", Role: ROLE_REFEREE},
+		{GuildID: "g", RoleID: "organizers", Role: ROLE_ORGANIZER},
+	}
+
+	cases := []struct {
+		name       string
+		member     Member
+		tournament *Tournament
+		expected   Role
+	}{
+		{"manager", Member{UserID: "x", Manager: true}, tournament, ROLE_MANAGER},
+		{"owner", Member{UserID: "owner"}, tournament, ROLE_OWNER},
+		{"staff referee", Member{UserID: "ref"}, tournament, ROLE_REFEREE},
+		{"staff organizer", Member{UserID: "org"}, tournament, ROLE_ORGANIZER},
+		{"staff of another tournament", Member{UserID: "other"}, tournament, ROLE_NONE},
+		{"mapped referee", Member{UserID: "x", Roles: []string{"referees"}}, tournament, ROLE_REFEREE},
+		{"highest mapping wins", Member{UserID: "ref", Roles: []string{"organizers"}}, tournament, ROLE_ORGANIZER},
+		{"mapping without tournament", Member{UserID: "x", Roles: []string{"organizers"}}, nil, ROLE_ORGANIZER},
+		{"owner without tournament", Member{UserID: "owner"}, nil, ROLE_NONE},
+		{"nobody", Member{UserID: "x", Roles: []string{"everyone"}}, tournament, ROLE_NONE},
+	}
+
+	for _, c := range cases {
+		if got := ResolveRole(c.member, c.tournament, staff, mappings); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	for _, r := range []Role{ROLE_REFEREE, ROLE_ORGANIZER} {
+		if got, ok := ParseRole(r.String()); !ok || got != r {
+			t.Errorf("expected %v to round trip, got %v", r, got)
+		}
+	}
+	for _, v := range []string{"owner", "manager", "none", ""} {
+		if _, ok := ParseRole(v); ok {
+			t.Errorf("expected %q to be rejected", v)
+		}
+	}
+}
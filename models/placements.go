@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Placement is where a player finished in a tournament, Place is 0 while the tournament is running
+type Placement struct {
+	TournamentID   string
+	TournamentName string
+	AttendeeID     int
+	Place          int
+	Wins           int
+	Losses         int
+	Player         Player
+	TeamName       string
+}
+
+type PlacementModel struct {
+	DB *sql.DB
+}
+
+func NewPlacementModel(db *sql.DB) *PlacementModel {
+	return &PlacementModel{
+		DB: db,
+	}
+}
+
+// Save replaces the placements of the tournament with the given standings
+func (m *PlacementModel) Save(tx *sql.Tx, tournamentID string, standings []Standing) error {
+	if _, err := tx.Exec(`DELETE FROM placements WHERE tournament_id = ?`, tournamentID); err != nil {
+		return err
+	}
+
+	q := `INSERT INTO placements (tournament_id, attendee_id, place, wins, losses, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?)`
+	now := time.Now().Unix()
+	for _, st := range standings {
+		if _, err := tx.Exec(q, tournamentID, st.AttendeeID, st.Place, st.Wins, st.Losses, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the placements of the tournament from the champion
+func (m *PlacementModel) List(tournamentID string) ([]Placement, error) {
+	placements := []Placement{}
+	q := `SELECT t.id, t.name, pl.attendee_id, pl.place, pl.wins, pl.losses, p.id, p.name, p.discord_id,
+			IFNULL(tm.name, '')
+		  FROM placements pl
+		  JOIN tournaments t ON t.id = pl.tournament_id
+		  JOIN attendees a ON a.id = pl.attendee_id
+		  JOIN players p ON p.id = a.player_id
+		  LEFT JOIN teams tm ON tm.id = a.team_id
+		  WHERE pl.tournament_id = ?
+		  ORDER BY pl.place, pl.wins DESC, p.name`
+
+	rows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p Placement
+		err := rows.Scan(&p.TournamentID, &p.TournamentName, &p.AttendeeID, &p.Place, &p.Wins, &p.Losses,
+			&p.Player.ID, &p.Player.Name, &p.Player.DiscordID, &p.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		placements = append(placements, p)
+	}
+
+	return placements, rows.Err()
+}
+
+// Complete persists the final placements of the tournament from its match records and marks it as
+// completed, it returns no placements when the final has not been played yet
+func (m *PlacementModel) Complete(tx *sql.Tx, tournamentID string) ([]Standing, error) {
+	records, err := NewMatchModel(m.DB).ListTx(tx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := Standings(records)
+	if len(standings) == 0 {
+		return nil, nil
+	}
+
+	if err := m.Save(tx, tournamentID, standings); err != nil {
+		return nil, err
+	}
+
+	q := `UPDATE tournaments SET completed = true, completed_at = ? WHERE id = ?`
+	if _, err := tx.Exec(q, time.Now().Unix(), tournamentID); err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"strings"
 )
 
 type Player struct {
@@ -48,3 +49,39 @@ func (m *PlayerModel) Insert(tx *sql.Tx, p *Player) error {
 
 	return nil
 }
+
+// SearchInGuild lists the players who took part in a tournament of the guild and whose name
+// contains query or whose discord id starts with it
+func (m *PlayerModel) SearchInGuild(guildID, query string, limit int) ([]Player, error) {
+	q := `SELECT DISTINCT p.id, p.name, p.discord_id
+		  FROM players p
+		  JOIN attendees a ON a.player_id = p.id
+		  JOIN tournaments t ON t.id = a.tournament_id
+		  WHERE t.guild_id = ? AND (p.name LIKE ? OR p.discord_id LIKE ?)
+		  ORDER BY p.name
+		  LIMIT ?`
+
+	pattern := likeEscape(query)
+	rows, err := m.DB.Query(q, guildID, "%"+pattern+"%", pattern+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	players := []Player{}
+	for rows.Next() {
+		var p Player
+		if err := rows.Scan(&p.ID, &p.Name, &p.DiscordID); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeEscape quotes the wildcards of a LIKE pattern so user input is matched literally
+func likeEscape(s string) string {
+	return likeEscaper.Replace(s)
+}
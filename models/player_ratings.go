@@ -0,0 +1,127 @@
+package models
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type PlayerRating struct {
+	ID         int
+	GuildID    string
+	Game       string
+	PlayerID   string
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+	Elo        float64
+	Wins       int
+	Losses     int
+	UpdatedAt  int64
+	Player     Player
+}
+
+// RatedMatch is a completed match between two players, walkovers are never rated
+type RatedMatch struct {
+	MatchID        int
+	Game           string
+	WinnerPlayerID string
+	LoserPlayerID  string
+	CompletedAt    int64
+}
+
+type PlayerRatingModel struct {
+	DB *sql.DB
+}
+
+func NewPlayerRatingModel(db *sql.DB) *PlayerRatingModel {
+	return &PlayerRatingModel{
+		DB: db,
+	}
+}
+
+const playerRatingColumns = `r.id, r.guild_id, r.game, r.player_id, r.rating, r.deviation, r.volatility, r.elo,
+	r.wins, r.losses, r.updated_at, p.id, p.name, p.discord_id`
+
+func scanPlayerRating(row rowScanner) (*PlayerRating, error) {
+	r := &PlayerRating{}
+	err := row.Scan(
+		&r.ID, &r.GuildID, &r.Game, &r.PlayerID, &r.Rating, &r.Deviation, &r.Volatility, &r.Elo,
+		&r.Wins, &r.Losses, &r.UpdatedAt, &r.Player.ID, &r.Player.Name, &r.Player.DiscordID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Get returns the rating of the player, game is empty for the rating across every game of the guild
+func (m *PlayerRatingModel) Get(tx *sql.Tx, guildID, game, playerID string) (*PlayerRating, error) {
+	q := `SELECT ` + playerRatingColumns + ` FROM player_ratings r JOIN players p ON p.id = r.player_id
+		  WHERE r.guild_id = ? AND r.game = ? AND r.player_id = ?`
+	if tx != nil {
+		return scanPlayerRating(tx.QueryRow(q+` FOR UPDATE`, guildID, game, playerID))
+	}
+	return scanPlayerRating(m.DB.QueryRow(q, guildID, game, playerID))
+}
+
+func (m *PlayerRatingModel) Save(tx *sql.Tx, r *PlayerRating) error {
+	q := `INSERT INTO player_ratings (guild_id, game, player_id, rating, deviation, volatility, elo, wins, losses,
+			updated_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE rating = VALUES(rating), deviation = VALUES(deviation),
+			volatility = VALUES(volatility), elo = VALUES(elo), wins = VALUES(wins), losses = VALUES(losses),
+			updated_at = VALUES(updated_at)`
+
+	r.UpdatedAt = time.Now().Unix()
+	_, err := tx.Exec(q, r.GuildID, r.Game, r.PlayerID, r.Rating, r.Deviation, r.Volatility, r.Elo, r.Wins,
+		r.Losses, r.UpdatedAt)
+	return err
+}
+
+// Reset removes every rating of the guild so they can be rebuilt from the match history
+func (m *PlayerRatingModel) Reset(tx *sql.Tx, guildID string) error {
+	_, err := tx.Exec(`DELETE FROM player_ratings WHERE guild_id = ?`, guildID)
+	return err
+}
+
+// ratedConditions picks the matches that count for the ratings, the same ones that are rated as their
+// result is reported. Team matches are not rated, their attendee is only the captain.
+var ratedConditions = []string{
+	"t.guild_id = ?",
+	"m.status = ?",
+	"t.team_size <= 1",
+}
+
+// RatedMatches returns every completed match played in the guild, oldest first. A transaction sees the
+// matches it changed itself.
+func (m *PlayerRatingModel) RatedMatches(tx *sql.Tx, guildID string) ([]RatedMatch, error) {
+	matches := []RatedMatch{}
+	q := `SELECT m.id, IFNULL(t.game, ''), w.player_id, l.player_id, m.completed_at
+		  FROM matches m
+		  JOIN tournaments t ON t.id = m.tournament_id
+		  JOIN attendees w ON w.id = m.winner_attendee_id
+		  JOIN attendees l ON l.id = IF(m.p1_attendee_id = m.winner_attendee_id, m.p2_attendee_id, m.p1_attendee_id)
+		  WHERE ` + strings.Join(ratedConditions, " AND ") + `
+		  ORDER BY m.completed_at, m.id`
+
+	var db querier = m.DB
+	if tx != nil {
+		db = tx
+	}
+	rows, err := db.Query(q, guildID, MATCH_COMPLETED)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rm RatedMatch
+		if err := rows.Scan(&rm.MatchID, &rm.Game, &rm.WinnerPlayerID, &rm.LoserPlayerID, &rm.CompletedAt); err != nil {
+			return nil, err
+		}
+		matches = append(matches, rm)
+	}
+
+	return matches, rows.Err()
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRatedConditions(t *testing.T) {
+	where := strings.Join(ratedConditions, " AND ")
+
+	// team tournaments are skipped when results are reported, a rebuild has to skip them too
+	for _, term := range []string{"m.status = ?", "t.team_size <= 1"} {
+		if !strings.Contains(where, term) {
+			t.Errorf("%q is missing %q", where, term)
+		}
+	}
+	if strings.Count(where, "?") != 2 {
+		t.Errorf("expected the guild and the status to be the only arguments of %q", where)
+	}
+}
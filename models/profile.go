@@ -0,0 +1,233 @@
+package models
+
+import (
+	"database/sql"
+	"sort"
+)
+
+// PlayerMatch is a finished match seen from one player, byes are left out since there is no opponent
+type PlayerMatch struct {
+	MatchID        int
+	TournamentID   string
+	TournamentName string
+	Round          int
+	Status         MatchStatus
+	Won            bool
+	Score          int
+	OpponentScore  int
+	Opponent       Player
+	CompletedAt    int64
+}
+
+// OpponentRecord is the record of a player against one opponent
+type OpponentRecord struct {
+	Opponent Player
+	Wins     int
+	Losses   int
+}
+
+func (r OpponentRecord) Played() int {
+	return r.Wins + r.Losses
+}
+
+type Profile struct {
+	Player      Player
+	Tournaments int
+	Wins        int
+	Losses      int
+	Placements  []Placement
+	Recent      []PlayerMatch
+	Opponents   []OpponentRecord
+}
+
+// Opponents groups the matches by opponent, the most played opponent first
+func Opponents(matches []PlayerMatch) []OpponentRecord {
+	byOpponent := make(map[string]*OpponentRecord)
+	for _, m := range matches {
+		key := string(m.Opponent.ID)
+		if _, ok := byOpponent[key]; !ok {
+			byOpponent[key] = &OpponentRecord{Opponent: m.Opponent}
+		}
+		if m.Won {
+			byOpponent[key].Wins++
+		} else {
+			byOpponent[key].Losses++
+		}
+	}
+
+	records := make([]OpponentRecord, 0, len(byOpponent))
+	for _, r := range byOpponent {
+		records = append(records, *r)
+	}
+	sort.Slice(records, func(a, b int) bool {
+		if records[a].Played() != records[b].Played() {
+			return records[a].Played() > records[b].Played()
+		}
+		if records[a].Wins != records[b].Wins {
+			return records[a].Wins > records[b].Wins
+		}
+		return records[a].Opponent.Name < records[b].Opponent.Name
+	})
+	return records
+}
+
+// BestPlacements orders the finished placements from the best one, ties go to the latest tournament
+// which is expected to come first in the given placements
+func BestPlacements(placements []Placement) []Placement {
+	best := []Placement{}
+	for _, p := range placements {
+		if p.Place > 0 {
+			best = append(best, p)
+		}
+	}
+	sort.SliceStable(best, func(a, b int) bool {
+		return best[a].Place < best[b].Place
+	})
+	return best
+}
+
+// ProfileModel reads the player history from the match records, match_histories only keeps the result
+// of each attendee and can't tell who the opponent was
+type ProfileModel struct {
+	DB *sql.DB
+}
+
+func NewProfileModel(db *sql.DB) *ProfileModel {
+	return &ProfileModel{
+		DB: db,
+	}
+}
+
+// Matches returns the finished matches the player played in the guild from the latest one, only the
+// matches against the opponent are returned when opponentID is set
+func (m *ProfileModel) Matches(guildID, playerID, opponentID string) ([]PlayerMatch, error) {
+	matches := []PlayerMatch{}
+	q := `SELECT m.id, t.id, t.name, m.round, m.status, IFNULL(m.completed_at, 0),
+			IF(m.winner_attendee_id = a.id, 1, 0),
+			IF(m.p1_attendee_id = a.id, m.p1_score, m.p2_score),
+			IF(m.p1_attendee_id = a.id, m.p2_score, m.p1_score),
+			op.id, op.name, op.discord_id
+		  FROM attendees a
+		  JOIN tournaments t ON t.id = a.tournament_id
+		  JOIN matches m ON (m.p1_attendee_id = a.id OR m.p2_attendee_id = a.id) AND m.status IN (?, ?)
+		  JOIN attendees oa ON oa.id = IF(m.p1_attendee_id = a.id, m.p2_attendee_id, m.p1_attendee_id)
+		  JOIN players op ON op.id = oa.player_id
+		  WHERE t.guild_id = ? AND a.player_id = ?`
+	args := []interface{}{MATCH_COMPLETED, MATCH_WALKOVER, guildID, playerID}
+
+	if opponentID != "" {
+		q += ` AND oa.player_id = ?`
+		args = append(args, opponentID)
+	}
+	q += ` ORDER BY m.completed_at DESC, m.id DESC`
+
+	rows, err := m.DB.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pm PlayerMatch
+		err := rows.Scan(&pm.MatchID, &pm.TournamentID, &pm.TournamentName, &pm.Round, &pm.Status, &pm.CompletedAt,
+			&pm.Won, &pm.Score, &pm.OpponentScore, &pm.Opponent.ID, &pm.Opponent.Name, &pm.Opponent.DiscordID)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, pm)
+	}
+
+	return matches, rows.Err()
+}
+
+// Placements returns where the player finished in every tournament of the guild they entered, from
+// the latest tournament
+func (m *ProfileModel) Placements(guildID, playerID string) ([]Placement, error) {
+	placements := []Placement{}
+	q := `SELECT t.id, t.name, a.id FROM attendees a
+		  JOIN tournaments t ON t.id = a.tournament_id
+		  WHERE t.guild_id = ? AND a.player_id = ? AND a.waitlisted_at IS NULL
+		  ORDER BY t.created_at DESC`
+
+	rows, err := m.DB.Query(q, guildID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p Placement
+		if err := rows.Scan(&p.TournamentID, &p.TournamentName, &p.AttendeeID); err != nil {
+			return nil, err
+		}
+		placements = append(placements, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	q = `SELECT ` + prefixColumns("m", matchColumns) + ` FROM matches m
+		 JOIN attendees a ON a.tournament_id = m.tournament_id
+		 JOIN tournaments t ON t.id = a.tournament_id
+		 WHERE t.guild_id = ? AND a.player_id = ?
+		 ORDER BY m.tournament_id, m.number`
+
+	recordRows, err := m.DB.Query(q, guildID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer recordRows.Close()
+
+	byTournament := make(map[string][]MatchRecord)
+	for recordRows.Next() {
+		r, err := scanMatch(recordRows)
+		if err != nil {
+			return nil, err
+		}
+		byTournament[r.TournamentID] = append(byTournament[r.TournamentID], *r)
+	}
+	if err := recordRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for idx, p := range placements {
+		for _, st := range Standings(byTournament[p.TournamentID]) {
+			if st.AttendeeID == p.AttendeeID {
+				placements[idx].Place = st.Place
+				break
+			}
+		}
+	}
+
+	return placements, nil
+}
+
+// Get builds the profile of the player in the guild, recentLimit caps how many recent matches are kept
+func (m *ProfileModel) Get(guildID string, p *Player, recentLimit int) (*Profile, error) {
+	matches, err := m.Matches(guildID, string(p.ID), "")
+	if err != nil {
+		return nil, err
+	}
+
+	placements, err := m.Placements(guildID, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		Player:      *p,
+		Tournaments: len(placements),
+		Placements:  BestPlacements(placements),
+		Recent:      matches[:min(recentLimit, len(matches))],
+		Opponents:   Opponents(matches),
+	}
+	for _, pm := range matches {
+		if pm.Won {
+			profile.Wins++
+		} else {
+			profile.Losses++
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpponents(t *testing.T) {
+	bob := Player{ID: []uint8("bob"), Name: "bob"}
+	carol := Player{ID: []uint8("carol"), Name: "carol"}
+	dave := Player{ID: []uint8("dave"), Name: "dave"}
+
+	matches := []PlayerMatch{
+		{Opponent: bob, Won: true},
+		{Opponent: carol, Won: false},
+		{Opponent: bob, Won: false},
+		{Opponent: dave, Won: true},
+		{Opponent: carol, Won: true},
+		{Opponent: bob, Won: true},
+	}
+
+	expected := []OpponentRecord{
+		{Opponent: bob, Wins: 2, Losses: 1},
+		{Opponent: carol, Wins: 1, Losses: 1},
+		{Opponent: dave, Wins: 1, Losses: 0},
+	}
+	if got := Opponents(matches); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestBestPlacements(t *testing.T) {
+	placements := []Placement{
+		{TournamentID: "e", Place: 0},
+		{TournamentID: "d", Place: 3},
+		{TournamentID: "c", Place: 1},
+		{TournamentID: "b", Place: 3},
+		{TournamentID: "a", Place: 1},
+	}
+
+	var got []string
+	for _, p := range BestPlacements(placements) {
+		got = append(got, p.TournamentID)
+	}
+	if expected := []string{"c", "a", "d", "b"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
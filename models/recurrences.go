@@ -0,0 +1,123 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Recurrence struct {
+	ID               int
+	GuildID          string
+	TournamentID     string
+	LastTournamentID string
+	NamePattern      string
+	Edition          int
+	Weekday          time.Weekday
+	TimeOfDay        string
+	Timezone         string
+	OpenBeforeHours  int
+	CarrySeeding     bool
+	Enabled          bool
+	// LastOccurrenceAt is when the latest edition is held, unset until the first edition is created
+	LastOccurrenceAt sql.NullInt64
+	CreatedBy        string
+	CreatedAt        int64
+}
+
+// TournamentName fills the name pattern placeholders, {n} is the edition number and {date}
+// is the date of the edition
+func (r *Recurrence) TournamentName(edition int, at time.Time) string {
+	rep := strings.NewReplacer(
+		"{n}", strconv.Itoa(edition),
+		"{date}", at.Format("2006-01-02"),
+	)
+	return rep.Replace(r.NamePattern)
+}
+
+type RecurrenceModel struct {
+	DB *sql.DB
+}
+
+func NewRecurrenceModel(db *sql.DB) *RecurrenceModel {
+	return &RecurrenceModel{
+		DB: db,
+	}
+}
+
+const recurrenceColumns = `id, guild_id, tournament_id, last_tournament_id, name_pattern, edition, weekday,
+	time_of_day, timezone, open_before_hours, carry_seeding, enabled, last_occurrence_at, created_by, created_at`
+
+func scanRecurrence(row rowScanner) (*Recurrence, error) {
+	r := &Recurrence{}
+	err := row.Scan(
+		&r.ID, &r.GuildID, &r.TournamentID, &r.LastTournamentID, &r.NamePattern, &r.Edition, &r.Weekday,
+		&r.TimeOfDay, &r.Timezone, &r.OpenBeforeHours, &r.CarrySeeding, &r.Enabled, &r.LastOccurrenceAt, &r.CreatedBy,
+		&r.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *RecurrenceModel) Insert(r *Recurrence) error {
+	q := `INSERT INTO recurrences (guild_id, tournament_id, last_tournament_id, name_pattern, edition, weekday,
+			time_of_day, timezone, open_before_hours, carry_seeding, enabled, created_by, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	r.CreatedAt = time.Now().Unix()
+	result, err := m.DB.Exec(q, r.GuildID, r.TournamentID, r.LastTournamentID, r.NamePattern, r.Edition,
+		r.Weekday, r.TimeOfDay, r.Timezone, r.OpenBeforeHours, r.CarrySeeding, r.Enabled, r.CreatedBy, r.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.ID = int(id)
+	return nil
+}
+
+func (m *RecurrenceModel) GetById(id int) (*Recurrence, error) {
+	q := `SELECT ` + recurrenceColumns + ` FROM recurrences WHERE id = ?`
+	return scanRecurrence(m.DB.QueryRow(q, id))
+}
+
+// FindByTournament finds the enabled recurrence that the tournament is part of, either as the
+// tournament it was set on or as its latest edition
+func (m *RecurrenceModel) FindByTournament(tournamentID string) (*Recurrence, error) {
+	q := `SELECT ` + recurrenceColumns + ` FROM recurrences
+		  WHERE (tournament_id = ? OR last_tournament_id = ?) AND enabled = true
+		  ORDER BY id DESC LIMIT 1`
+	return scanRecurrence(m.DB.QueryRow(q, tournamentID, tournamentID))
+}
+
+// NextEdition records the tournament created for the edition held at occurrence, it fails when that
+// edition has been recorded already so an occurrence never gets two editions
+func (m *RecurrenceModel) NextEdition(tx *sql.Tx, id int, tournamentID string, occurrence int64) error {
+	q := `UPDATE recurrences SET last_tournament_id = ?, edition = edition + 1, last_occurrence_at = ?
+		  WHERE id = ? AND (last_occurrence_at IS NULL OR last_occurrence_at <> ?)`
+	result, err := tx.Exec(q, tournamentID, occurrence, id, occurrence)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("edition of recurrence %d held at %d already exists", id, occurrence)
+	}
+	return nil
+}
+
+func (m *RecurrenceModel) Disable(id int) error {
+	_, err := m.DB.Exec(`UPDATE recurrences SET enabled = false WHERE id = ?`, id)
+	return err
+}
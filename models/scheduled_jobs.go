@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	JOB_PENDING   = "pending"
+	JOB_RUNNING   = "running"
+	JOB_DONE      = "done"
+	JOB_FAILED    = "failed"
+	JOB_CANCELLED = "cancelled"
+)
+
+type ScheduledJob struct {
+	ID           int
+	Kind         string
+	TournamentID sql.NullString
+	ReferenceID  sql.NullInt64
+	RunAt        int64
+	Status       string
+	Attempts     int
+	LastError    sql.NullString
+	CreatedAt    int64
+}
+
+type ScheduledJobModel struct {
+	DB *sql.DB
+}
+
+func NewScheduledJobModel(db *sql.DB) *ScheduledJobModel {
+	return &ScheduledJobModel{
+		DB: db,
+	}
+}
+
+func (m *ScheduledJobModel) Insert(j *ScheduledJob) error {
+	q := `INSERT INTO scheduled_jobs (kind, tournament_id, reference_id, run_at, status, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?)`
+
+	j.Status = JOB_PENDING
+	j.CreatedAt = time.Now().Unix()
+	result, err := m.DB.Exec(q, j.Kind, j.TournamentID, j.ReferenceID, j.RunAt, j.Status, j.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	j.ID = int(id)
+	return nil
+}
+
+// Due returns pending jobs that should have been run by now, oldest first
+func (m *ScheduledJobModel) Due(now int64, limit int) ([]ScheduledJob, error) {
+	jobs := []ScheduledJob{}
+	q := `SELECT id, kind, tournament_id, reference_id, run_at, status, attempts, last_error, created_at
+		  FROM scheduled_jobs WHERE status = ? AND run_at <= ? ORDER BY run_at LIMIT ?`
+
+	rows, err := m.DB.Query(q, JOB_PENDING, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var j ScheduledJob
+		err := rows.Scan(
+			&j.ID, &j.Kind, &j.TournamentID, &j.ReferenceID, &j.RunAt, &j.Status, &j.Attempts,
+			&j.LastError, &j.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// Claim marks the job as running, it returns false when the job has been claimed or cancelled already
+func (m *ScheduledJobModel) Claim(id int) (bool, error) {
+	q := `UPDATE scheduled_jobs SET status = ?, attempts = attempts + 1 WHERE id = ? AND status = ?`
+	result, err := m.DB.Exec(q, JOB_RUNNING, id, JOB_PENDING)
+	if err != nil {
+		return false, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (m *ScheduledJobModel) Done(id int) error {
+	_, err := m.DB.Exec(`UPDATE scheduled_jobs SET status = ?, last_error = NULL WHERE id = ?`, JOB_DONE, id)
+	return err
+}
+
+// Fail stores the job error, the job is picked up again at retryAt unless retryAt is 0
+func (m *ScheduledJobModel) Fail(id int, jobErr error, retryAt int64) error {
+	if retryAt == 0 {
+		q := `UPDATE scheduled_jobs SET status = ?, last_error = ? WHERE id = ?`
+		_, err := m.DB.Exec(q, JOB_FAILED, jobErr.Error(), id)
+		return err
+	}
+	q := `UPDATE scheduled_jobs SET status = ?, last_error = ?, run_at = ? WHERE id = ?`
+	_, err := m.DB.Exec(q, JOB_PENDING, jobErr.Error(), retryAt, id)
+	return err
+}
+
+// ResetRunning puts back jobs that were interrupted by a shutdown so they get picked up again
+func (m *ScheduledJobModel) ResetRunning() error {
+	_, err := m.DB.Exec(`UPDATE scheduled_jobs SET status = ? WHERE status = ?`, JOB_PENDING, JOB_RUNNING)
+	return err
+}
+
+// Cancel cancels the pending jobs of a kind for the tournament, every kind is cancelled when kind is empty
+func (m *ScheduledJobModel) Cancel(tournamentID, kind string) error {
+	q := `UPDATE scheduled_jobs SET status = ? WHERE tournament_id = ? AND status = ?`
+	args := []interface{}{JOB_CANCELLED, tournamentID, JOB_PENDING}
+	if kind != "" {
+		q += ` AND kind = ?`
+		args = append(args, kind)
+	}
+	_, err := m.DB.Exec(q, args...)
+	return err
+}
+
+func (m *ScheduledJobModel) CancelByReference(kind string, referenceID int) error {
+	q := `UPDATE scheduled_jobs SET status = ? WHERE kind = ? AND reference_id = ? AND status = ?`
+	_, err := m.DB.Exec(q, JOB_CANCELLED, kind, referenceID, JOB_PENDING)
+	return err
+}
@@ -0,0 +1,261 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+// DEFAULT_POINT_TIERS rewards the top 16 of every tournament in a season
+const DEFAULT_POINT_TIERS = "1=100,2=70,3=50,5=30,9=15"
+
+type Season struct {
+	ID         int
+	GuildID    string
+	Name       string
+	StartsAt   int64
+	EndsAt     int64
+	PointTiers string
+	Archived   bool
+	ArchivedAt sql.NullInt64
+	CreatedBy  string
+	CreatedAt  int64
+}
+
+// PointTier awards Points to every place from Place until the place of the next tier
+type PointTier struct {
+	Place  int
+	Points int
+}
+
+// SeasonScore is what a player collected over the tournaments of a season
+type SeasonScore struct {
+	Points         int
+	Tournaments    int
+	TournamentWins int
+}
+
+// ParsePointTiers parses tiers written as place=points separated by commas, such as 1=100,2=70,3=50
+func ParsePointTiers(v string) ([]PointTier, error) {
+	tiers := []PointTier{}
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		place, points, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, i18n.NewError("season.tier_format", part)
+		}
+		p, err := strconv.Atoi(strings.TrimSpace(place))
+		if err != nil || p < 1 {
+			return nil, i18n.NewError("season.tier_place", part)
+		}
+		pts, err := strconv.Atoi(strings.TrimSpace(points))
+		if err != nil || pts < 0 {
+			return nil, i18n.NewError("season.tier_points", part)
+		}
+		if len(tiers) > 0 && p <= tiers[len(tiers)-1].Place {
+			return nil, i18n.NewError("season.tier_order")
+		}
+		tiers = append(tiers, PointTier{Place: p, Points: pts})
+	}
+
+	if len(tiers) == 0 || tiers[0].Place != 1 {
+		return nil, i18n.NewError("season.tier_first")
+	}
+	return tiers, nil
+}
+
+func FormatPointTiers(tiers []PointTier) string {
+	parts := make([]string, 0, len(tiers))
+	for _, t := range tiers {
+		parts = append(parts, fmt.Sprintf("%d=%d", t.Place, t.Points))
+	}
+	return strings.Join(parts, ",")
+}
+
+// PointsFor returns the points of the tier the place falls into
+func PointsFor(tiers []PointTier, place int) int {
+	points := 0
+	for _, t := range tiers {
+		if place < t.Place {
+			break
+		}
+		points = t.Points
+	}
+	return points
+}
+
+// ScoreSeason awards the points of every finished tournament in the records, scores are keyed by
+// player id since attendees are only unique within a tournament
+func ScoreSeason(records []MatchRecord, players map[int]string, tiers []PointTier) map[string]*SeasonScore {
+	byTournament := make(map[string][]MatchRecord)
+	for _, r := range records {
+		byTournament[r.TournamentID] = append(byTournament[r.TournamentID], r)
+	}
+
+	scores := make(map[string]*SeasonScore)
+	for _, tournamentRecords := range byTournament {
+		for _, st := range Standings(tournamentRecords) {
+			playerID, ok := players[st.AttendeeID]
+			if !ok {
+				continue
+			}
+			if _, ok := scores[playerID]; !ok {
+				scores[playerID] = &SeasonScore{}
+			}
+			score := scores[playerID]
+			score.Points += PointsFor(tiers, st.Place)
+			score.Tournaments++
+			if st.Place == 1 {
+				score.TournamentWins++
+			}
+		}
+	}
+	return scores
+}
+
+type SeasonModel struct {
+	DB *sql.DB
+}
+
+func NewSeasonModel(db *sql.DB) *SeasonModel {
+	return &SeasonModel{
+		DB: db,
+	}
+}
+
+const seasonColumns = `id, guild_id, name, starts_at, ends_at, point_tiers, archived, archived_at, created_by, created_at`
+
+func scanSeason(row rowScanner) (*Season, error) {
+	s := &Season{}
+	err := row.Scan(
+		&s.ID, &s.GuildID, &s.Name, &s.StartsAt, &s.EndsAt, &s.PointTiers, &s.Archived, &s.ArchivedAt,
+		&s.CreatedBy, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (m *SeasonModel) Insert(s *Season) error {
+	q := `INSERT INTO seasons (guild_id, name, starts_at, ends_at, point_tiers, created_by, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	s.CreatedAt = time.Now().Unix()
+	result, err := m.DB.Exec(q, s.GuildID, s.Name, s.StartsAt, s.EndsAt, s.PointTiers, s.CreatedBy, s.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	s.ID = int(id)
+	return nil
+}
+
+func (m *SeasonModel) GetById(id int) (*Season, error) {
+	q := `SELECT ` + seasonColumns + ` FROM seasons WHERE id = ?`
+	return scanSeason(m.DB.QueryRow(q, id))
+}
+
+func (m *SeasonModel) FindByName(guildID, name string) (*Season, error) {
+	q := `SELECT ` + seasonColumns + ` FROM seasons WHERE guild_id = ? AND name = ?`
+	return scanSeason(m.DB.QueryRow(q, guildID, name))
+}
+
+// Current returns the season that is running at the given time, or the latest season when none is
+func (m *SeasonModel) Current(guildID string, at int64) (*Season, error) {
+	q := `SELECT ` + seasonColumns + ` FROM seasons WHERE guild_id = ?
+		  ORDER BY (starts_at <= ? AND ends_at >= ?) DESC, starts_at DESC LIMIT 1`
+	return scanSeason(m.DB.QueryRow(q, guildID, at, at))
+}
+
+func (m *SeasonModel) List(guildID string) ([]Season, error) {
+	seasons := []Season{}
+	q := `SELECT ` + seasonColumns + ` FROM seasons WHERE guild_id = ? ORDER BY starts_at DESC`
+
+	rows, err := m.DB.Query(q, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s, err := scanSeason(rows)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, *s)
+	}
+
+	return seasons, rows.Err()
+}
+
+func (m *SeasonModel) UpdatePointTiers(id int, tiers string) error {
+	_, err := m.DB.Exec(`UPDATE seasons SET point_tiers = ? WHERE id = ?`, tiers, id)
+	return err
+}
+
+// Records returns the matches of the guild tournaments started within the season
+func (m *SeasonModel) Records(s *Season) ([]MatchRecord, error) {
+	records := []MatchRecord{}
+	q := `SELECT ` + prefixColumns("m", matchColumns) + ` FROM matches m
+		  JOIN tournaments t ON t.id = m.tournament_id
+		  WHERE t.guild_id = ? AND t.started_at BETWEEN ? AND ?
+		  ORDER BY m.tournament_id, m.number`
+
+	rows, err := m.DB.Query(q, s.GuildID, s.StartsAt, s.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *r)
+	}
+
+	return records, rows.Err()
+}
+
+// Archive freezes the season with its final standings, archived seasons are no longer recomputed
+func (m *SeasonModel) Archive(id int, standings []LeaderboardEntry) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM season_standings WHERE season_id = ?`, id); err != nil {
+		return err
+	}
+
+	q := `INSERT INTO season_standings (season_id, player_id, position, points, tournaments, tournament_wins)
+		  VALUES (?, ?, ?, ?, ?, ?)`
+	for _, st := range standings {
+		_, err := tx.Exec(q, id, st.Player.ID, st.Position, int(st.Value), st.Tournaments, st.TournamentWins)
+		if err != nil {
+			return err
+		}
+	}
+
+	q = `UPDATE seasons SET archived = true, archived_at = ? WHERE id = ?`
+	if _, err := tx.Exec(q, time.Now().Unix(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePointTiers(t *testing.T) {
+	tiers, err := ParsePointTiers(DEFAULT_POINT_TIERS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []PointTier{{1, 100}, {2, 70}, {3, 50}, {5, 30}, {9, 15}}
+	if !reflect.DeepEqual(tiers, expected) {
+		t.Errorf("expected %v, got %v", expected, tiers)
+	}
+	if got := FormatPointTiers(tiers); got != DEFAULT_POINT_TIERS {
+		t.Errorf("expected %q, got %q", DEFAULT_POINT_TIERS, got)
+	}
+
+	for _, v := range []string{"", "2=50", "1=100,1=50", "1=100,3=50,2=70", "1=-5", "first=100", "1:100"} {
+		if _, err := ParsePointTiers(v); err == nil {
+			t.Errorf("expected %q to be rejected", v)
+		}
+	}
+}
+
+func TestPointsFor(t *testing.T) {
+	tiers, _ := ParsePointTiers(DEFAULT_POINT_TIERS)
+	cases := map[int]int{1: 100, 2: 70, 3: 50, 4: 50, 5: 30, 8: 30, 9: 15, 32: 15}
+	for place, points := range cases {
+		if got := PointsFor(tiers, place); got != points {
+			t.Errorf("place %d: expected %d points, got %d", place, points, got)
+		}
+	}
+}
+
+func TestScoreSeason(t *testing.T) {
+	first := []MatchRecord{
+		finished(1, 1, 1, 2, 1),
+		finished(1, 2, 3, 4, 3),
+		finished(2, 3, 1, 3, 1),
+	}
+	// the second tournament has new attendee ids for the same players
+	second := []MatchRecord{
+		finished(1, 1, 5, 6, 6),
+		finished(1, 2, 7, 8, 7),
+		finished(2, 3, 6, 7, 7),
+	}
+	// an unfinished tournament awards no points
+	third := []MatchRecord{
+		finished(1, 1, 9, 10, 9),
+		{Round: 2, Number: 2, Status: MATCH_READY},
+	}
+	for idx := range first {
+		first[idx].TournamentID = "a"
+		second[idx].TournamentID = "b"
+	}
+	for idx := range third {
+		third[idx].TournamentID = "c"
+	}
+
+	records := append(append(first, second...), third...)
+	players := map[int]string{
+		1: "alice", 2: "bob", 3: "carol", 4: "dave",
+		5: "alice", 6: "bob", 7: "carol", 8: "dave",
+		9: "alice", 10: "bob",
+	}
+	tiers := []PointTier{{1, 10}, {2, 6}, {3, 3}}
+
+	expected := map[string]*SeasonScore{
+		"alice": {Points: 13, Tournaments: 2, TournamentWins: 1},
+		"bob":   {Points: 9, Tournaments: 2},
+		"carol": {Points: 16, Tournaments: 2, TournamentWins: 1},
+		"dave":  {Points: 6, Tournaments: 2},
+	}
+	if got := ScoreSeason(records, players, tiers); !reflect.DeepEqual(got, expected) {
+		for k, v := range got {
+			t.Logf("%s: %+v", k, *v)
+		}
+		t.Errorf("unexpected season scores")
+	}
+}
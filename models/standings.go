@@ -0,0 +1,76 @@
+package models
+
+import "sort"
+
+type Standing struct {
+	AttendeeID int
+	Place      int
+	Wins       int
+	Losses     int
+}
+
+// Standings ranks the participants of a finished single elimination bracket by the round they were
+// knocked out in, players knocked out in the same round share the place. Nothing is returned until
+// the final has been played.
+func Standings(records []MatchRecord) []Standing {
+	var final *MatchRecord
+	for idx := range records {
+		if final == nil || records[idx].Round > final.Round {
+			final = &records[idx]
+		}
+	}
+	if final == nil || !final.Done() || !final.WinnerAttendeeID.Valid {
+		return nil
+	}
+
+	stats := make(map[int]*Standing)
+	// the round a player got knocked out in, the champion is never knocked out
+	knockedOut := make(map[int]int)
+	standing := func(id int) *Standing {
+		if _, ok := stats[id]; !ok {
+			stats[id] = &Standing{AttendeeID: id}
+			knockedOut[id] = final.Round + 1
+		}
+		return stats[id]
+	}
+
+	for _, r := range records {
+		if !r.Done() || !r.WinnerAttendeeID.Valid {
+			continue
+		}
+		winner := int(r.WinnerAttendeeID.Int64)
+		standing(winner).Wins++
+		for _, p := range []int64{r.P1AttendeeID.Int64, r.P2AttendeeID.Int64} {
+			if p == 0 || int(p) == winner {
+				continue
+			}
+			standing(int(p)).Losses++
+			knockedOut[int(p)] = r.Round
+		}
+	}
+
+	standings := make([]Standing, 0, len(stats))
+	for _, s := range stats {
+		standings = append(standings, *s)
+	}
+	sort.Slice(standings, func(a, b int) bool {
+		ra, rb := knockedOut[standings[a].AttendeeID], knockedOut[standings[b].AttendeeID]
+		if ra != rb {
+			return ra > rb
+		}
+		if standings[a].Wins != standings[b].Wins {
+			return standings[a].Wins > standings[b].Wins
+		}
+		return standings[a].AttendeeID < standings[b].AttendeeID
+	})
+
+	for idx := range standings {
+		if idx > 0 && knockedOut[standings[idx].AttendeeID] == knockedOut[standings[idx-1].AttendeeID] {
+			standings[idx].Place = standings[idx-1].Place
+			continue
+		}
+		standings[idx].Place = idx + 1
+	}
+
+	return standings
+}
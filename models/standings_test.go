@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func finished(round, number int, p1, p2, winner int64) MatchRecord {
+	id := func(v int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: v, Valid: v != 0}
+	}
+	status := MATCH_COMPLETED
+	if p1 == 0 || p2 == 0 {
+		status = MATCH_WALKOVER
+	}
+	return MatchRecord{
+		Round:            round,
+		Number:           number,
+		P1AttendeeID:     id(p1),
+		P2AttendeeID:     id(p2),
+		WinnerAttendeeID: id(winner),
+		Status:           status,
+	}
+}
+
+func TestStandings(t *testing.T) {
+	records := []MatchRecord{
+		finished(1, 1, 1, 2, 1),
+		finished(1, 2, 3, 0, 3),
+		finished(2, 3, 1, 3, 3),
+	}
+
+	expected := []Standing{
+		{AttendeeID: 3, Place: 1, Wins: 2, Losses: 0},
+		{AttendeeID: 1, Place: 2, Wins: 1, Losses: 1},
+		{AttendeeID: 2, Place: 3, Wins: 0, Losses: 1},
+	}
+
+	if got := Standings(records); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestStandingsSharedPlace(t *testing.T) {
+	records := []MatchRecord{
+		finished(1, 1, 1, 2, 1),
+		finished(1, 2, 3, 4, 4),
+		finished(2, 3, 1, 4, 1),
+	}
+
+	got := Standings(records)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 standings, got %d", len(got))
+	}
+	if got[2].Place != 3 || got[3].Place != 3 {
+		t.Errorf("expected both first round losers to share third place, got %+v", got)
+	}
+}
+
+func TestStandingsUnfinished(t *testing.T) {
+	records := []MatchRecord{
+		finished(1, 1, 1, 2, 1),
+		{Round: 2, Number: 2, Status: MATCH_PENDING},
+	}
+
+	if got := Standings(records); got != nil {
+		t.Errorf("expected no standings before the final, got %+v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import "sort"
+
+// TournamentStatus is a snapshot of a running tournament, shown in its status message
+type TournamentStatus struct {
+	Round     int
+	Rounds    int
+	Live      []MatchRecord
+	Upcoming  []MatchRecord
+	Recent    []MatchRecord
+	Remaining []Attendee
+	Completed bool
+}
+
+// BuildStatus summarizes the matches of the tournament. The current round is the earliest round with
+// matches left to play, or the final once every match is done. Only the last recent results are kept,
+// newest first, and remaining are the attendees that have not lost a match yet.
+func BuildStatus(records []MatchRecord, attendees []Attendee, recent int) *TournamentStatus {
+	status := &TournamentStatus{}
+	eliminated := make(map[int]bool)
+
+	for _, r := range records {
+		if r.Round > status.Rounds {
+			status.Rounds = r.Round
+		}
+		if !r.Done() {
+			if status.Round == 0 || r.Round < status.Round {
+				status.Round = r.Round
+			}
+			switch r.Status {
+			case MATCH_LIVE:
+				status.Live = append(status.Live, r)
+			case MATCH_READY:
+				status.Upcoming = append(status.Upcoming, r)
+			}
+			continue
+		}
+
+		if !r.WinnerAttendeeID.Valid {
+			continue
+		}
+		for _, p := range []int64{r.P1AttendeeID.Int64, r.P2AttendeeID.Int64} {
+			if p != 0 && p != r.WinnerAttendeeID.Int64 {
+				eliminated[int(p)] = true
+			}
+		}
+		// walkovers are not results anyone played for
+		if r.Status == MATCH_COMPLETED {
+			status.Recent = append(status.Recent, r)
+		}
+	}
+
+	if status.Round == 0 {
+		status.Round = status.Rounds
+		status.Completed = status.Rounds > 0
+	}
+
+	sort.SliceStable(status.Recent, func(a, b int) bool {
+		return status.Recent[a].CompletedAt.Int64 > status.Recent[b].CompletedAt.Int64
+	})
+	if len(status.Recent) > recent {
+		status.Recent = status.Recent[:recent]
+	}
+
+	for _, a := range attendees {
+		if !eliminated[a.Id] {
+			status.Remaining = append(status.Remaining, a)
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func matchNumbers(records []MatchRecord) []int {
+	numbers := []int{}
+	for _, r := range records {
+		numbers = append(numbers, r.Number)
+	}
+	return numbers
+}
+
+func TestBuildStatus(t *testing.T) {
+	first := finished(1, 1, 1, 2, 1)
+	first.CompletedAt = sql.NullInt64{Int64: 100, Valid: true}
+	second := finished(1, 2, 3, 4, 4)
+	second.CompletedAt = sql.NullInt64{Int64: 200, Valid: true}
+	walkover := finished(1, 3, 5, 0, 5)
+	live := MatchRecord{Round: 1, Number: 4, Status: MATCH_LIVE}
+	ready := MatchRecord{Round: 2, Number: 5, Status: MATCH_READY}
+	pending := MatchRecord{Round: 3, Number: 6, Status: MATCH_PENDING}
+
+	attendees := []Attendee{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}, {Id: 5}}
+	status := BuildStatus([]MatchRecord{first, second, walkover, live, ready, pending}, attendees, 1)
+
+	if status.Round != 1 || status.Rounds != 3 || status.Completed {
+		t.Errorf("round %d of %d, completed %v", status.Round, status.Rounds, status.Completed)
+	}
+	if got := matchNumbers(status.Live); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("live matches %v", got)
+	}
+	if got := matchNumbers(status.Upcoming); !reflect.DeepEqual(got, []int{5}) {
+		t.Errorf("upcoming matches %v", got)
+	}
+	// newest result first, walkovers left out
+	if got := matchNumbers(status.Recent); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("recent results %v", got)
+	}
+
+	remaining := []int{}
+	for _, a := range status.Remaining {
+		remaining = append(remaining, a.Id)
+	}
+	if !reflect.DeepEqual(remaining, []int{1, 4, 5}) {
+		t.Errorf("remaining attendees %v", remaining)
+	}
+}
+
+func TestBuildStatusCompleted(t *testing.T) {
+	records := []MatchRecord{finished(1, 1, 1, 2, 1), finished(2, 2, 1, 3, 3)}
+	status := BuildStatus(records, nil, 5)
+	if !status.Completed || status.Round != 2 {
+		t.Errorf("round %d, completed %v", status.Round, status.Completed)
+	}
+
+	if status := BuildStatus(nil, nil, 5); status.Completed || status.Round != 0 {
+		t.Errorf("empty bracket is round %d, completed %v", status.Round, status.Completed)
+	}
+}
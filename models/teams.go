@@ -0,0 +1,173 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Team struct {
+	ID           int
+	TournamentID string
+	Name         string
+	CaptainID    string
+	CreatedAt    int64
+	Captain      Player
+	Members      []Player
+}
+
+type TeamModel struct {
+	DB *sql.DB
+}
+
+func NewTeamModel(db *sql.DB) *TeamModel {
+	return &TeamModel{
+		DB: db,
+	}
+}
+
+const teamColumns = `tm.id, tm.tournament_id, tm.name, tm.captain_id, tm.created_at, p.id, p.name, p.discord_id`
+
+func scanTeam(row rowScanner) (*Team, error) {
+	t := &Team{}
+	err := row.Scan(
+		&t.ID, &t.TournamentID, &t.Name, &t.CaptainID, &t.CreatedAt, &t.Captain.ID, &t.Captain.Name,
+		&t.Captain.DiscordID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Insert creates the team with its captain as the first member and registers it as an attendee
+func (m *TeamModel) Insert(tx *sql.Tx, t *Team) error {
+	t.CreatedAt = time.Now().Unix()
+	q := `INSERT INTO teams (tournament_id, name, captain_id, created_at) VALUES (?, ?, ?, ?)`
+	result, err := tx.Exec(q, t.TournamentID, t.Name, t.CaptainID, t.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = int(id)
+
+	if err := m.AddMember(tx, t, t.CaptainID); err != nil {
+		return err
+	}
+
+	// the captain stands in for the team wherever a player is expected
+	q = `INSERT INTO attendees (tournament_id, player_id, team_id, current_seat) VALUES (?, ?, ?, NULL)`
+	_, err = tx.Exec(q, t.TournamentID, t.CaptainID, t.ID)
+	return err
+}
+
+func (m *TeamModel) AddMember(tx *sql.Tx, t *Team, playerID string) error {
+	q := `INSERT INTO team_members (team_id, tournament_id, player_id, joined_at) VALUES (?, ?, ?, ?)`
+	if _, err := tx.Exec(q, t.ID, t.TournamentID, playerID, time.Now().Unix()); err != nil {
+		return err
+	}
+
+	// pending invites of other teams are no longer needed once the player joined a team
+	q = `DELETE ti FROM team_invites ti JOIN teams tm ON tm.id = ti.team_id
+		 WHERE tm.tournament_id = ? AND ti.player_id = ?`
+	_, err := tx.Exec(q, t.TournamentID, playerID)
+	return err
+}
+
+func (m *TeamModel) FindByName(tournamentID, name string) (*Team, error) {
+	q := `SELECT ` + teamColumns + ` FROM teams tm JOIN players p ON p.id = tm.captain_id
+		  WHERE tm.tournament_id = ? AND tm.name = ?`
+	return scanTeam(m.DB.QueryRow(q, tournamentID, name))
+}
+
+// FindByPlayer returns the team the player is a member of in the tournament
+func (m *TeamModel) FindByPlayer(tournamentID, playerID string) (*Team, error) {
+	q := `SELECT ` + teamColumns + ` FROM teams tm
+		  JOIN players p ON p.id = tm.captain_id
+		  JOIN team_members mb ON mb.team_id = tm.id
+		  WHERE tm.tournament_id = ? AND mb.player_id = ?`
+	return scanTeam(m.DB.QueryRow(q, tournamentID, playerID))
+}
+
+// FindByAttendee returns the team the attendee stands for
+func (m *TeamModel) FindByAttendee(attendeeID int) (*Team, error) {
+	q := `SELECT ` + teamColumns + ` FROM teams tm
+		  JOIN players p ON p.id = tm.captain_id
+		  JOIN attendees a ON a.team_id = tm.id
+		  WHERE a.id = ?`
+	return scanTeam(m.DB.QueryRow(q, attendeeID))
+}
+
+// List returns the teams of the tournament along with their rosters
+func (m *TeamModel) List(tournamentID string) ([]Team, error) {
+	teams := []Team{}
+	q := `SELECT ` + teamColumns + ` FROM teams tm JOIN players p ON p.id = tm.captain_id
+		  WHERE tm.tournament_id = ? ORDER BY tm.name`
+
+	rows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := make(map[int]int)
+	for rows.Next() {
+		t, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[t.ID] = len(teams)
+		teams = append(teams, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	q = `SELECT mb.team_id, p.id, p.name, p.discord_id FROM team_members mb
+		 JOIN players p ON p.id = mb.player_id
+		 WHERE mb.tournament_id = ? ORDER BY mb.joined_at, mb.id`
+	memberRows, err := m.DB.Query(q, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var (
+			teamID int
+			p      Player
+		)
+		if err := memberRows.Scan(&teamID, &p.ID, &p.Name, &p.DiscordID); err != nil {
+			return nil, err
+		}
+		if idx, ok := index[teamID]; ok {
+			teams[idx].Members = append(teams[idx].Members, p)
+		}
+	}
+
+	return teams, memberRows.Err()
+}
+
+func (m *TeamModel) CountMembers(tx *sql.Tx, teamID int) (int, error) {
+	var count int
+	q := `SELECT COUNT(*) FROM team_members WHERE team_id = ? FOR UPDATE`
+	err := tx.QueryRow(q, teamID).Scan(&count)
+	return count, err
+}
+
+func (m *TeamModel) Invite(teamID int, playerID, invitedBy string) error {
+	q := `INSERT INTO team_invites (team_id, player_id, invited_by, created_at) VALUES (?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE invited_by = VALUES(invited_by), created_at = VALUES(created_at)`
+	_, err := m.DB.Exec(q, teamID, playerID, invitedBy, time.Now().Unix())
+	return err
+}
+
+func (m *TeamModel) IsInvited(teamID int, playerID string) (bool, error) {
+	var count int
+	q := `SELECT COUNT(*) FROM team_invites WHERE team_id = ? AND player_id = ?`
+	err := m.DB.QueryRow(q, teamID, playerID).Scan(&count)
+	return count > 0, err
+}
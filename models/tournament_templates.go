@@ -0,0 +1,135 @@
+package models
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TournamentTemplate struct {
+	ID                  int
+	GuildID             string
+	Name                string
+	NamePattern         string
+	Description         sql.NullString
+	Rules               sql.NullString
+	Tournament_Types_ID int
+	BestOf              int
+	SelfRegister        bool
+	Game                sql.NullString
+	TeamSize            int
+	RosterLimit         sql.NullInt64
+	CheckInMinutes      sql.NullInt64
+	AutoReferee         bool
+	MatchThreads        bool
+	Uses                int
+	CreatedBy           string
+	CreatedAt           int64
+	TournamentType      TournamentType
+}
+
+// TournamentName fills the name pattern placeholders, {n} is the edition number of the
+// tournament created from this template and {date} is the creation date
+func (t *TournamentTemplate) TournamentName(now time.Time) string {
+	r := strings.NewReplacer(
+		"{n}", strconv.Itoa(t.Uses+1),
+		"{date}", now.Format("2006-01-02"),
+	)
+	return r.Replace(t.NamePattern)
+}
+
+type TournamentTemplatesModel struct {
+	DB *sql.DB
+}
+
+func NewTournamentTemplatesModel(db *sql.DB) *TournamentTemplatesModel {
+	return &TournamentTemplatesModel{
+		DB: db,
+	}
+}
+
+const templateColumns = `tp.id, tp.guild_id, tp.name, tp.name_pattern, tp.description, tp.rules,
+	tp.tournament_types_id, tp.best_of, tp.self_register, tp.game, tp.team_size, tp.roster_limit,
+	tp.check_in_minutes, tp.auto_referee, tp.match_threads, tp.uses, tp.created_by, tp.created_at,
+	tt.id, tt.size, tt.bracket_type, tt.has_third_winner`
+
+func scanTemplate(row rowScanner) (*TournamentTemplate, error) {
+	t := &TournamentTemplate{}
+	err := row.Scan(
+		&t.ID, &t.GuildID, &t.Name, &t.NamePattern, &t.Description, &t.Rules,
+		&t.Tournament_Types_ID, &t.BestOf, &t.SelfRegister, &t.Game, &t.TeamSize, &t.RosterLimit,
+		&t.CheckInMinutes, &t.AutoReferee, &t.MatchThreads, &t.Uses, &t.CreatedBy, &t.CreatedAt,
+		&t.TournamentType.ID, &t.TournamentType.Size, &t.TournamentType.Bracket_Type,
+		&t.TournamentType.Has_Third_Winner,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Save stores the template, saving with a name that already exists in the guild overwrites it
+func (m *TournamentTemplatesModel) Save(t *TournamentTemplate) error {
+	q := `INSERT INTO tournament_templates (guild_id, name, name_pattern, description, rules, tournament_types_id,
+			best_of, self_register, game, team_size, roster_limit, check_in_minutes, auto_referee, match_threads,
+			created_by, created_at)
+		  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		  ON DUPLICATE KEY UPDATE name_pattern = VALUES(name_pattern), description = VALUES(description),
+			rules = VALUES(rules), tournament_types_id = VALUES(tournament_types_id), best_of = VALUES(best_of),
+			self_register = VALUES(self_register), game = VALUES(game),
+			team_size = VALUES(team_size), roster_limit = VALUES(roster_limit),
+			check_in_minutes = VALUES(check_in_minutes), auto_referee = VALUES(auto_referee),
+			match_threads = VALUES(match_threads), created_by = VALUES(created_by), created_at = VALUES(created_at)`
+
+	t.CreatedAt = time.Now().Unix()
+	_, err := m.DB.Exec(q, t.GuildID, t.Name, t.NamePattern, t.Description, t.Rules, t.Tournament_Types_ID,
+		t.BestOf, t.SelfRegister, t.Game, t.TeamSize, t.RosterLimit, t.CheckInMinutes, t.AutoReferee, t.MatchThreads,
+		t.CreatedBy, t.CreatedAt)
+	return err
+}
+
+func (m *TournamentTemplatesModel) FindByName(guildID, name string) (*TournamentTemplate, error) {
+	q := `SELECT ` + templateColumns + `
+		  FROM tournament_templates tp
+		  JOIN tournament_types tt ON tp.tournament_types_id = tt.id
+		  WHERE tp.guild_id = ? AND tp.name = ?`
+	return scanTemplate(m.DB.QueryRow(q, guildID, name))
+}
+
+func (m *TournamentTemplatesModel) GetById(id int) (*TournamentTemplate, error) {
+	q := `SELECT ` + templateColumns + `
+		  FROM tournament_templates tp
+		  JOIN tournament_types tt ON tp.tournament_types_id = tt.id
+		  WHERE tp.id = ?`
+	return scanTemplate(m.DB.QueryRow(q, id))
+}
+
+func (m *TournamentTemplatesModel) List(guildID string) ([]TournamentTemplate, error) {
+	templates := []TournamentTemplate{}
+	q := `SELECT ` + templateColumns + `
+		  FROM tournament_templates tp
+		  JOIN tournament_types tt ON tp.tournament_types_id = tt.id
+		  WHERE tp.guild_id = ? ORDER BY tp.name`
+
+	rows, err := m.DB.Query(q, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (m *TournamentTemplatesModel) IncrementUses(id int) error {
+	_, err := m.DB.Exec(`UPDATE tournament_templates SET uses = uses + 1 WHERE id = ?`, id)
+	return err
+}
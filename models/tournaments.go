@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Tournament struct {
@@ -16,6 +19,26 @@ type Tournament struct {
 	Published           bool
 	Starting_At         sql.NullInt64
 	Created_At          string
+	Guild_ID            sql.NullString
+	Best_Of             int
+	Self_Register       bool
+	Template_ID         sql.NullInt64
+	Seed_From           sql.NullString
+	Started_At          sql.NullInt64
+	Registration_Open   bool
+	Game                sql.NullString
+	Completed           bool
+	Completed_At        sql.NullInt64
+	Team_Size           int
+	Roster_Limit        sql.NullInt64
+	Signup_Message_ID   sql.NullString
+	Check_In_Minutes    sql.NullInt64
+	Check_In_Opened_At  sql.NullInt64
+	Check_In_Message_ID sql.NullString
+	Owner_ID            sql.NullString
+	Auto_Referee        bool
+	Match_Threads       bool
+	Status_Message_ID   sql.NullString
 	TournamentType      TournamentType
 }
 
@@ -53,26 +76,78 @@ func (tm *TournamentsModel) GetTournamentIDInThread(threadID string) ([]uint8, e
 	return tournamentID, nil
 }
 
-func (tm *TournamentsModel) GetById(id string) (*Tournament, error) {
-	t := &Tournament{}
-	var published int
-	q := `
-		SELECT t.id, t.name, t.tournament_types_id, t.starting_at, t.created_at, t.published,
-			t.thread_id, t.description, t.rules,
-			tt.id, tt.size, tt.bracket_type, tt.has_third_winner
-	 	FROM tournaments t
-		JOIN tournament_types tt ON t.tournament_types_id = tt.id
-		WHERE t.id = ?`
+const tournamentColumns = `t.id, t.name, t.tournament_types_id, t.starting_at, t.created_at, t.published,
+	t.thread_id, t.description, t.rules, t.guild_id, t.best_of, t.self_register, t.template_id, t.seed_from,
+	t.started_at, t.registration_open, t.game, t.completed, t.completed_at, t.team_size, t.roster_limit,
+	t.signup_message_id, t.check_in_minutes, t.check_in_opened_at, t.check_in_message_id, t.owner_id,
+	t.auto_referee, t.match_threads, t.status_message_id, tt.id, tt.size, tt.bracket_type, tt.has_third_winner`
 
-	err := tm.DB.QueryRow(q, id).Scan(
-		&t.ID, &t.Name, &t.Tournament_Types_ID, &t.Starting_At, &t.Created_At, &published, &t.Thread_ID,
-		&t.Description, &t.Rules,
+func scanTournament(row rowScanner) (*Tournament, error) {
+	t := &Tournament{}
+	err := row.Scan(
+		&t.ID, &t.Name, &t.Tournament_Types_ID, &t.Starting_At, &t.Created_At, &t.Published, &t.Thread_ID,
+		&t.Description, &t.Rules, &t.Guild_ID, &t.Best_Of, &t.Self_Register, &t.Template_ID, &t.Seed_From,
+		&t.Started_At, &t.Registration_Open, &t.Game, &t.Completed, &t.Completed_At, &t.Team_Size, &t.Roster_Limit,
+		&t.Signup_Message_ID, &t.Check_In_Minutes, &t.Check_In_Opened_At, &t.Check_In_Message_ID, &t.Owner_ID,
+		&t.Auto_Referee, &t.Match_Threads, &t.Status_Message_ID,
 		&t.TournamentType.ID, &t.TournamentType.Size, &t.TournamentType.Bracket_Type,
 		&t.TournamentType.Has_Third_Winner,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
 
-	t.Published = published == 1
+// Cap is how many players, or teams in team tournaments, can take part before the waitlist is used
+func (t *Tournament) Cap() int {
+	size, _ := strconv.Atoi(t.TournamentType.Size)
+	return size
+}
+
+// IsTeam reports whether the entrants of the tournament are teams rather than single players
+func (t *Tournament) IsTeam() bool {
+	return t.Team_Size > 1
+}
+
+// RosterLimit is the most players a team can have, substitutes included
+func (t *Tournament) RosterLimit() int {
+	if t.Roster_Limit.Valid && int(t.Roster_Limit.Int64) > t.Team_Size {
+		return int(t.Roster_Limit.Int64)
+	}
+	return t.Team_Size
+}
+
+type TournamentState = string
+
+const (
+	TOURNAMENT_DRAFT     TournamentState = "draft"
+	TOURNAMENT_OPEN      TournamentState = "open"
+	TOURNAMENT_RUNNING   TournamentState = "running"
+	TOURNAMENT_COMPLETED TournamentState = "completed"
+)
+
+// State tells where the tournament is in its lifecycle, drafts are tournaments that are not published yet
+func (t *Tournament) State() TournamentState {
+	switch {
+	case t.Completed:
+		return TOURNAMENT_COMPLETED
+	case t.Started_At.Valid:
+		return TOURNAMENT_RUNNING
+	case t.Published:
+		return TOURNAMENT_OPEN
+	}
+	return TOURNAMENT_DRAFT
+}
 
+func (tm *TournamentsModel) GetById(id string) (*Tournament, error) {
+	q := `
+		SELECT ` + tournamentColumns + `
+	 	FROM tournaments t
+		JOIN tournament_types tt ON t.tournament_types_id = tt.id
+		WHERE t.id = ?`
+
+	t, err := scanTournament(tm.DB.QueryRow(q, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New(fmt.Sprintf("Could not find any record that have id of %s", id))
@@ -83,15 +158,215 @@ func (tm *TournamentsModel) GetById(id string) (*Tournament, error) {
 	return t, nil
 }
 
+func (tm *TournamentsModel) Insert(t *Tournament) error {
+	return insertTournament(tm.DB, t)
+}
+
+// InsertTx inserts the tournament as part of a transaction, used when the tournament is created along
+// with its attendees and matches
+func (tm *TournamentsModel) InsertTx(tx *sql.Tx, t *Tournament) error {
+	return insertTournament(tx, t)
+}
+
+func insertTournament(db execer, t *Tournament) error {
+	q := `
+		INSERT INTO tournaments (id, name, description, rules, tournament_types_id, starting_at, created_at,
+			guild_id, best_of, self_register, template_id, seed_from, started_at, registration_open, game,
+			completed, completed_at, team_size, roster_limit, check_in_minutes, owner_id, auto_referee,
+			match_threads)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := db.Exec(q, t.ID, t.Name, t.Description, t.Rules, t.Tournament_Types_ID, t.Starting_At,
+		t.Created_At, t.Guild_ID, t.Best_Of, t.Self_Register, t.Template_ID, t.Seed_From, t.Started_At,
+		t.Registration_Open, t.Game, t.Completed, t.Completed_At, t.Team_Size, t.Roster_Limit,
+		t.Check_In_Minutes, t.Owner_ID, t.Auto_Referee, t.Match_Threads)
+	return err
+}
+
 func (tm *TournamentsModel) Update(t *Tournament) error {
-	q := "UPDATE tournaments SET name = ?, description = ?, rules = ?, published = ?, thread_id = ? WHERE id = ?"
-	_, err := tm.DB.Exec(q, t.Name, t.Description, t.Rules, t.Published, t.Thread_ID, t.ID)
+	q := `UPDATE tournaments SET name = ?, description = ?, rules = ?, published = ?, thread_id = ?,
+			tournament_types_id = ?, best_of = ?, self_register = ?, starting_at = ?, registration_open = ?
+		  WHERE id = ?`
+	_, err := tm.DB.Exec(q, t.Name, t.Description, t.Rules, t.Published, t.Thread_ID,
+		t.Tournament_Types_ID, t.Best_Of, t.Self_Register, t.Starting_At, t.Registration_Open, t.ID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// SetAutoReferee turns the rotation of the tournament referees across live matches on or off
+func (tm *TournamentsModel) SetAutoReferee(id string, enabled bool) error {
+	_, err := tm.DB.Exec(`UPDATE tournaments SET auto_referee = ? WHERE id = ?`, enabled, id)
+	return err
+}
+
+func (tm *TournamentsModel) SetSignupMessage(id, messageID string) error {
+	_, err := tm.DB.Exec(`UPDATE tournaments SET signup_message_id = ? WHERE id = ?`, messageID, id)
+	return err
+}
+
+// SetStatusMessage records the pinned status message of the tournament
+func (tm *TournamentsModel) SetStatusMessage(id, messageID string) error {
+	_, err := tm.DB.Exec(`UPDATE tournaments SET status_message_id = ? WHERE id = ?`, messageID, id)
+	return err
+}
+
+// OpenCheckIn records that the check-in window of the tournament is open along with its message
+func (tm *TournamentsModel) OpenCheckIn(id, messageID string) error {
+	q := `UPDATE tournaments SET check_in_opened_at = ?, check_in_message_id = ? WHERE id = ?`
+	_, err := tm.DB.Exec(q, time.Now().Unix(), messageID, id)
+	return err
+}
+
+// Search lists the tournaments of the guild whose name contains query, upcoming and running
+// tournaments are listed before the completed ones
+func (tm *TournamentsModel) Search(guildID, query string, limit int) ([]Tournament, error) {
+	q := `
+		SELECT ` + tournamentColumns + `
+		FROM tournaments t
+		JOIN tournament_types tt ON t.tournament_types_id = tt.id
+		WHERE t.guild_id = ? AND t.name LIKE ?
+		ORDER BY t.completed, t.created_at DESC
+		LIMIT ?`
+
+	rows, err := tm.DB.Query(q, guildID, "%"+likeEscape(query)+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := []Tournament{}
+	for rows.Next() {
+		t, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, *t)
+	}
+	return tournaments, rows.Err()
+}
+
+// TournamentFilter narrows the tournaments of a guild, zero values don't filter
+type TournamentFilter struct {
+	GuildID string
+	State   TournamentState
+	TypeID  int
+	// Upcoming keeps the tournaments planned to start later that have not started yet
+	Upcoming bool
+	// Member keeps the tournaments the member owns, is staff of or plays in
+	Member string
+}
+
+func (f TournamentFilter) where(now int64) (string, []interface{}) {
+	conditions := []string{"t.guild_id = ?"}
+	args := []interface{}{f.GuildID}
+
+	// drafts are only listed when they are asked for, only organizers may see them
+	switch f.State {
+	case TOURNAMENT_DRAFT:
+		conditions = append(conditions, "t.published = false")
+	default:
+		conditions = append(conditions, "t.published = true")
+	}
+
+	switch f.State {
+	case TOURNAMENT_OPEN:
+		conditions = append(conditions, "t.started_at IS NULL AND t.completed = false")
+	case TOURNAMENT_RUNNING:
+		conditions = append(conditions, "t.started_at IS NOT NULL AND t.completed = false")
+	case TOURNAMENT_COMPLETED:
+		conditions = append(conditions, "t.completed = true")
+	}
+
+	if f.TypeID != 0 {
+		conditions = append(conditions, "t.tournament_types_id = ?")
+		args = append(args, f.TypeID)
+	}
+
+	if f.Upcoming {
+		conditions = append(conditions, "t.starting_at > ? AND t.started_at IS NULL")
+		args = append(args, now)
+	}
+
+	if f.Member != "" {
+		conditions = append(conditions, `(t.owner_id = ?
+			OR EXISTS (SELECT 1 FROM tournament_staff ts WHERE ts.tournament_id = t.id AND ts.user_id = ?)
+			OR EXISTS (SELECT 1 FROM attendees a JOIN players p ON p.id = a.player_id
+				LEFT JOIN team_members mb ON mb.team_id = a.team_id
+				LEFT JOIN players mp ON mp.id = mb.player_id
+				WHERE a.tournament_id = t.id AND (p.discord_id = ? OR mp.discord_id = ?)))`)
+		args = append(args, f.Member, f.Member, f.Member, f.Member)
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// TournamentListing is a tournament along with how many entrants took a spot in it
+type TournamentListing struct {
+	Tournament
+	Entrants int
+}
+
+// scanWith scans the columns selected after the ones the wrapped row is scanned into
+type scanWith struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s scanWith) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// Count returns how many tournaments match the filter
+func (tm *TournamentsModel) Count(f TournamentFilter) (int, error) {
+	where, args := f.where(time.Now().Unix())
+	var count int
+	err := tm.DB.QueryRow(`SELECT COUNT(*) FROM tournaments t `+where, args...).Scan(&count)
+	return count, err
+}
+
+// listingOrder sorts the tournaments that are not completed first. The ones that have not started lead,
+// the planned ones in the order they start, and every other tournament follows from the newest by when it
+// started or was created. Started tournaments always have a planned start so only the start counts.
+var listingOrder = []string{
+	"t.completed",
+	"t.started_at IS NOT NULL",
+	"t.started_at IS NULL AND t.starting_at IS NULL",
+	"CASE WHEN t.started_at IS NULL THEN t.starting_at END",
+	"COALESCE(t.started_at, t.created_at) DESC",
+}
+
+// List returns a page of the tournaments matching the filter in the order of listingOrder
+func (tm *TournamentsModel) List(f TournamentFilter, limit, offset int) ([]TournamentListing, error) {
+	where, args := f.where(time.Now().Unix())
+	q := `
+		SELECT ` + tournamentColumns + `,
+			(SELECT COUNT(*) FROM attendees a WHERE a.tournament_id = t.id AND a.waitlisted_at IS NULL)
+		FROM tournaments t
+		JOIN tournament_types tt ON t.tournament_types_id = tt.id
+		` + where + `
+		ORDER BY ` + strings.Join(listingOrder, ", ") + `
+		LIMIT ? OFFSET ?`
+
+	rows, err := tm.DB.Query(q, append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listings := []TournamentListing{}
+	for rows.Next() {
+		var entrants int
+		t, err := scanTournament(scanWith{rows, []interface{}{&entrants}})
+		if err != nil {
+			return nil, err
+		}
+		listings = append(listings, TournamentListing{Tournament: *t, Entrants: entrants})
+	}
+	return listings, rows.Err()
+}
+
 func (tm *TournamentsModel) Delete(id string) (*Tournament, error) {
 	t, err := tm.GetById(id)
 
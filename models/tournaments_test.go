@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTournamentState(t *testing.T) {
+	started := sql.NullInt64{Int64: 1, Valid: true}
+	tests := []struct {
+		t        Tournament
+		expected TournamentState
+	}{
+		{Tournament{}, TOURNAMENT_DRAFT},
+		{Tournament{Published: true}, TOURNAMENT_OPEN},
+		{Tournament{Published: true, Started_At: started}, TOURNAMENT_RUNNING},
+		{Tournament{Published: true, Started_At: started, Completed: true}, TOURNAMENT_COMPLETED},
+	}
+
+	for _, test := range tests {
+		if got := test.t.State(); got != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, got)
+		}
+	}
+}
+
+func TestTournamentFilter(t *testing.T) {
+	where, args := TournamentFilter{GuildID: "1"}.where(100)
+	if where != "WHERE t.guild_id = ? AND t.published = true" || !reflect.DeepEqual(args, []interface{}{"1"}) {
+		t.Errorf("unfiltered guild gives %q %v", where, args)
+	}
+
+	// drafts are left out unless they are asked for
+	where, _ = TournamentFilter{GuildID: "1", State: TOURNAMENT_DRAFT}.where(100)
+	if where != "WHERE t.guild_id = ? AND t.published = false" {
+		t.Errorf("draft filter gives %q", where)
+	}
+
+	f := TournamentFilter{GuildID: "1", State: TOURNAMENT_OPEN, TypeID: 3, Upcoming: true, Member: "42"}
+	where, args = f.where(100)
+	for _, condition := range []string{"t.published = true", "t.tournament_types_id = ?", "t.starting_at > ?", "t.owner_id = ?"} {
+		if !strings.Contains(where, condition) {
+			t.Errorf("%q is missing %q", where, condition)
+		}
+	}
+	expected := []interface{}{"1", 3, int64(100), "42", "42", "42", "42"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected arguments %v, got %v", expected, args)
+	}
+	if strings.Count(where, "?") != len(args) {
+		t.Errorf("%d placeholders for %d arguments", strings.Count(where, "?"), len(args))
+	}
+}
+
+func TestListingOrder(t *testing.T) {
+	order := strings.Join(listingOrder, ", ")
+
+	// the planned start only orders the tournaments that have not started, completed ones are newest first
+	terms := []string{
+		"t.completed",
+		"t.started_at IS NOT NULL",
+		"CASE WHEN t.started_at IS NULL THEN t.starting_at END",
+		"COALESCE(t.started_at, t.created_at) DESC",
+	}
+	last := -1
+	for _, term := range terms {
+		idx := strings.Index(order, term)
+		if idx < 0 {
+			t.Fatalf("%q is missing %q", order, term)
+		}
+		if idx < last {
+			t.Errorf("%q comes too early in %q", term, order)
+		}
+		last = idx
+	}
+
+	for _, term := range listingOrder {
+		if strings.Contains(term, "starting_at") && !strings.Contains(term, "started_at IS NULL") {
+			t.Errorf("%q orders started tournaments by their planned start", term)
+		}
+	}
+}
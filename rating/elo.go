@@ -0,0 +1,16 @@
+package rating
+
+import "math"
+
+const (
+	DefaultElo = 1500
+	// eloK is how many points a single game can move a rating at most
+	eloK = 32
+)
+
+// Elo returns the ratings of both players after the winner beat the loser
+func Elo(winner, loser float64) (float64, float64) {
+	expectedWin := 1 / (1 + math.Pow(10, (loser-winner)/400))
+	change := eloK * (1 - expectedWin)
+	return winner + change, loser - change
+}
@@ -0,0 +1,117 @@
+package rating
+
+import "math"
+
+const (
+	// glicko2Scale converts ratings between the glicko and glicko-2 scales
+	glicko2Scale = 173.7178
+	// tau constrains how much the volatility can change, smaller values suit games with less upsets
+	tau = 0.5
+	// convergence tolerance of the volatility iteration
+	epsilon = 0.000001
+
+	DefaultRating     = 1500
+	DefaultDeviation  = 350
+	DefaultVolatility = 0.06
+)
+
+// Glicko is a player rating in the glicko-2 system
+type Glicko struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// Result is the outcome of one game against an opponent, Score is 1 for a win, 0 for a loss and 0.5 for a draw
+type Result struct {
+	Opponent Glicko
+	Score    float64
+}
+
+func NewGlicko() Glicko {
+	return Glicko{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expected(mu, muJ, phiJ float64) float64 {
+	return 1 / (1 + math.Exp(-g(phiJ)*(mu-muJ)))
+}
+
+// Update returns the rating after a rating period with the given results, following the steps in
+// Glickman's "Example of the Glicko-2 system". A period without results only grows the deviation.
+func (p Glicko) Update(results []Result) Glicko {
+	mu := (p.Rating - DefaultRating) / glicko2Scale
+	phi := p.Deviation / glicko2Scale
+
+	if len(results) == 0 {
+		phiStar := math.Sqrt(phi*phi + p.Volatility*p.Volatility)
+		return Glicko{Rating: p.Rating, Deviation: phiStar * glicko2Scale, Volatility: p.Volatility}
+	}
+
+	var vInv, sum float64
+	for _, r := range results {
+		muJ := (r.Opponent.Rating - DefaultRating) / glicko2Scale
+		phiJ := r.Opponent.Deviation / glicko2Scale
+		e := expected(mu, muJ, phiJ)
+		vInv += g(phiJ) * g(phiJ) * e * (1 - e)
+		sum += g(phiJ) * (r.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * sum
+
+	sigma := p.volatility(phi, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phiPrime := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muPrime := mu + phiPrime*phiPrime*sum
+
+	return Glicko{
+		Rating:     muPrime*glicko2Scale + DefaultRating,
+		Deviation:  phiPrime * glicko2Scale,
+		Volatility: sigma,
+	}
+}
+
+// volatility finds the new volatility with the Illinois algorithm
+func (p Glicko) volatility(phi, v, delta float64) float64 {
+	a := math.Log(p.Volatility * p.Volatility)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA = fA / 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
+
+// Match rates a single game as its own rating period for both players
+func Match(winner, loser Glicko) (Glicko, Glicko) {
+	return winner.Update([]Result{{Opponent: loser, Score: 1}}), loser.Update([]Result{{Opponent: winner, Score: 0}})
+}
@@ -0,0 +1,61 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+// example from Glickman's "Example of the Glicko-2 system"
+func TestGlickoUpdate(t *testing.T) {
+	player := Glicko{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	got := player.Update([]Result{
+		{Opponent: Glicko{Rating: 1400, Deviation: 30}, Score: 1},
+		{Opponent: Glicko{Rating: 1550, Deviation: 100}, Score: 0},
+		{Opponent: Glicko{Rating: 1700, Deviation: 300}, Score: 0},
+	})
+
+	if !near(got.Rating, 1464.06, 0.01) {
+		t.Errorf("expected rating 1464.06, got %.2f", got.Rating)
+	}
+	if !near(got.Deviation, 151.52, 0.01) {
+		t.Errorf("expected deviation 151.52, got %.2f", got.Deviation)
+	}
+	if !near(got.Volatility, 0.05999, 0.00001) {
+		t.Errorf("expected volatility 0.05999, got %.5f", got.Volatility)
+	}
+}
+
+func TestGlickoInactive(t *testing.T) {
+	player := NewGlicko()
+	got := player.Update(nil)
+	if got.Rating != player.Rating || got.Deviation <= player.Deviation {
+		t.Errorf("expected only the deviation to grow, got %+v", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	winner, loser := Match(NewGlicko(), NewGlicko())
+	if winner.Rating <= DefaultRating || loser.Rating >= DefaultRating {
+		t.Errorf("expected the winner to gain and the loser to lose, got %.2f and %.2f", winner.Rating, loser.Rating)
+	}
+	if !near(winner.Rating-DefaultRating, DefaultRating-loser.Rating, 0.0001) {
+		t.Errorf("expected a symmetric change between equal players, got %.2f and %.2f", winner.Rating, loser.Rating)
+	}
+}
+
+func TestElo(t *testing.T) {
+	winner, loser := Elo(1500, 1500)
+	if winner != 1516 || loser != 1484 {
+		t.Errorf("expected 1516 and 1484, got %.2f and %.2f", winner, loser)
+	}
+
+	// beating a much weaker player is worth little
+	winner, _ = Elo(2000, 1200)
+	if winner-2000 > 1 {
+		t.Errorf("expected less than a point for an expected win, got %.2f", winner-2000)
+	}
+}
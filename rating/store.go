@@ -0,0 +1,127 @@
+package rating
+
+import (
+	"database/sql"
+
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/models"
+)
+
+// games returns the rating pools a match of the game counts towards, every match counts towards the
+// guild wide pool and towards the pool of its game when it has one
+func games(game string) []string {
+	if game == "" {
+		return []string{""}
+	}
+	return []string{"", game}
+}
+
+func newPlayerRating(guildID, game, playerID string) *models.PlayerRating {
+	g := NewGlicko()
+	return &models.PlayerRating{
+		GuildID:    guildID,
+		Game:       game,
+		PlayerID:   playerID,
+		Rating:     g.Rating,
+		Deviation:  g.Deviation,
+		Volatility: g.Volatility,
+		Elo:        DefaultElo,
+	}
+}
+
+// apply updates both ratings with the outcome of a single match
+func apply(winner, loser *models.PlayerRating) {
+	w, l := Match(
+		Glicko{Rating: winner.Rating, Deviation: winner.Deviation, Volatility: winner.Volatility},
+		Glicko{Rating: loser.Rating, Deviation: loser.Deviation, Volatility: loser.Volatility},
+	)
+	winner.Rating, winner.Deviation, winner.Volatility = w.Rating, w.Deviation, w.Volatility
+	loser.Rating, loser.Deviation, loser.Volatility = l.Rating, l.Deviation, l.Volatility
+	winner.Elo, loser.Elo = Elo(winner.Elo, loser.Elo)
+	winner.Wins++
+	loser.Losses++
+}
+
+// Record rates a completed match as part of the transaction that stores its result
+func Record(tx *sql.Tx, guildID, game, winnerPlayerID, loserPlayerID string) error {
+	rm := models.NewPlayerRatingModel(database.GetDB())
+
+	for _, pool := range games(game) {
+		ratings := make([]*models.PlayerRating, 0, 2)
+		for _, playerID := range []string{winnerPlayerID, loserPlayerID} {
+			r, err := rm.Get(tx, guildID, pool, playerID)
+			if err == sql.ErrNoRows {
+				r, err = newPlayerRating(guildID, pool, playerID), nil
+			}
+			if err != nil {
+				return err
+			}
+			ratings = append(ratings, r)
+		}
+
+		apply(ratings[0], ratings[1])
+		for _, r := range ratings {
+			if err := rm.Save(tx, r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Rebuild recomputes every rating of the guild by replaying its completed matches in the order they
+// were played, it returns how many matches were replayed
+func Rebuild(guildID string) (int, error) {
+	tx, err := database.GetDB().Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count, err := RebuildTx(tx, guildID)
+	if err != nil {
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+// RebuildTx is Rebuild as part of a transaction, such as the one that removes the matches of a
+// restarted tournament
+func RebuildTx(tx *sql.Tx, guildID string) (int, error) {
+	rm := models.NewPlayerRatingModel(database.GetDB())
+
+	matches, err := rm.RatedMatches(tx, guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	type key struct {
+		game     string
+		playerID string
+	}
+	ratings := make(map[key]*models.PlayerRating)
+	get := func(game, playerID string) *models.PlayerRating {
+		k := key{game, playerID}
+		if _, ok := ratings[k]; !ok {
+			ratings[k] = newPlayerRating(guildID, game, playerID)
+		}
+		return ratings[k]
+	}
+
+	for _, m := range matches {
+		for _, pool := range games(m.Game) {
+			apply(get(pool, m.WinnerPlayerID), get(pool, m.LoserPlayerID))
+		}
+	}
+
+	if err := rm.Reset(tx, guildID); err != nil {
+		return 0, err
+	}
+	for _, r := range ratings {
+		if err := rm.Save(tx, r); err != nil {
+			return 0, err
+		}
+	}
+	return len(matches), nil
+}
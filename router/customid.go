@@ -0,0 +1,138 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dimfu/spade/i18n"
+)
+
+const (
+	// VERSION is written in front of every custom id, ids of another version are rejected instead of
+	// being read with the wrong layout
+	VERSION = 1
+	// MAX_CUSTOM_ID_LENGTH is the longest custom id discord accepts
+	MAX_CUSTOM_ID_LENGTH = 100
+
+	separator = ":"
+)
+
+var (
+	ERR_CUSTOM_ID_TOO_LONG  = errors.New("custom id is longer than discord allows")
+	ERR_CUSTOM_ID_MALFORMED = errors.New("custom id is malformed")
+	ERR_CUSTOM_ID_OUTDATED  = i18n.NewError("error.custom_id_outdated")
+	ERR_UNKNOWN_ACTION      = i18n.NewError("common.unknown_action")
+	ERR_INTERNAL            = i18n.NewError("error.internal")
+)
+
+// argEscaper keeps the separator out of the arguments, names such as games can contain anything
+var (
+	argEscaper   = strings.NewReplacer("%", "%25", separator, "%3A")
+	argUnescaper = strings.NewReplacer("%3A", separator, "%25", "%")
+)
+
+// CustomID is the route of a component or modal and the arguments it is built with. Ids of buttons
+// posted before the version was introduced are decoded with Version 0 and every part of the id in
+// Args, the router resolves their route.
+type CustomID struct {
+	Version int
+	Route   string
+	Args    []string
+}
+
+// NewCustomID builds the custom id of route, arguments are written with fmt.Sprint except for []byte ids
+// which are written as text
+func NewCustomID(route string, args ...interface{}) CustomID {
+	c := CustomID{Version: VERSION, Route: route, Args: make([]string, 0, len(args))}
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			c.Args = append(c.Args, v)
+		case []byte:
+			c.Args = append(c.Args, string(v))
+		default:
+			c.Args = append(c.Args, fmt.Sprint(v))
+		}
+	}
+	return c
+}
+
+// ID encodes the custom id of route, it panics when the id does not fit which only happens when a
+// route is built with arguments it was not meant for. Ids with arguments typed by members are built
+// with Encode instead so the error can be answered.
+func ID(route string, args ...interface{}) string {
+	id, err := NewCustomID(route, args...).Encode()
+	if err != nil {
+		panic(fmt.Sprintf("%v: %s", err, route))
+	}
+	return id
+}
+
+func (c CustomID) Encode() (string, error) {
+	if c.Route == "" || strings.Contains(c.Route, separator) {
+		return "", ERR_CUSTOM_ID_MALFORMED
+	}
+
+	parts := make([]string, 0, len(c.Args)+2)
+	parts = append(parts, strconv.Itoa(VERSION), c.Route)
+	for _, arg := range c.Args {
+		parts = append(parts, argEscaper.Replace(arg))
+	}
+
+	id := strings.Join(parts, separator)
+	if len(id) > MAX_CUSTOM_ID_LENGTH {
+		return "", ERR_CUSTOM_ID_TOO_LONG
+	}
+	return id, nil
+}
+
+// Decode reads a custom id written by Encode. Ids without a version are returned as version 0 with
+// the parts split by underscores.
+func Decode(raw string) (CustomID, error) {
+	if len(raw) == 0 || len(raw) > MAX_CUSTOM_ID_LENGTH {
+		return CustomID{}, ERR_CUSTOM_ID_MALFORMED
+	}
+
+	version, rest, ok := strings.Cut(raw, separator)
+	if !ok {
+		return CustomID{Args: strings.Split(raw, "_")}, nil
+	}
+
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		return CustomID{}, ERR_CUSTOM_ID_MALFORMED
+	}
+	if v != VERSION {
+		return CustomID{}, ERR_CUSTOM_ID_OUTDATED
+	}
+
+	parts := strings.Split(rest, separator)
+	if parts[0] == "" {
+		return CustomID{}, ERR_CUSTOM_ID_MALFORMED
+	}
+
+	c := CustomID{Version: v, Route: parts[0], Args: make([]string, 0, len(parts)-1)}
+	for _, arg := range parts[1:] {
+		c.Args = append(c.Args, argUnescaper.Replace(arg))
+	}
+	return c, nil
+}
+
+// Arg returns the argument at idx, or an empty string when there is none
+func (c CustomID) Arg(idx int) string {
+	if idx < 0 || idx >= len(c.Args) {
+		return ""
+	}
+	return c.Args[idx]
+}
+
+// Int returns the argument at idx as a number
+func (c CustomID) Int(idx int) (int, error) {
+	n, err := strconv.Atoi(c.Arg(idx))
+	if err != nil {
+		return 0, fmt.Errorf("%w: argument %d of %s is not a number", ERR_CUSTOM_ID_MALFORMED, idx, c.Route)
+	}
+	return n, nil
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCustomIDRoundTrip(t *testing.T) {
+	id, err := NewCustomID("leaderboard", "rating", 2, []byte("Street Fighter: 6 100%")).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1:leaderboard:rating:2:Street Fighter%3A 6 100%25"; id != want {
+		t.Errorf("got %q, want %q", id, want)
+	}
+
+	c, err := Decode(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Version != VERSION || c.Route != "leaderboard" {
+		t.Errorf("got version %d route %q", c.Version, c.Route)
+	}
+	if want := []string{"rating", "2", "Street Fighter: 6 100%"}; !reflect.DeepEqual(c.Args, want) {
+		t.Errorf("got %v, want %v", c.Args, want)
+	}
+
+	page, err := c.Int(1)
+	if err != nil || page != 2 {
+		t.Errorf("Int(1) = %d, %v, want 2", page, err)
+	}
+	if _, err := c.Int(0); !errors.Is(err, ERR_CUSTOM_ID_MALFORMED) {
+		t.Errorf("Int(0) error = %v, want malformed", err)
+	}
+	if c.Arg(5) != "" {
+		t.Errorf("Arg past the end = %q, want empty", c.Arg(5))
+	}
+}
+
+func TestCustomIDSize(t *testing.T) {
+	if _, err := NewCustomID("route", strings.Repeat("a", MAX_CUSTOM_ID_LENGTH)).Encode(); !errors.Is(err, ERR_CUSTOM_ID_TOO_LONG) {
+		t.Errorf("got %v, want too long", err)
+	}
+	if _, err := NewCustomID("bad:route").Encode(); !errors.Is(err, ERR_CUSTOM_ID_MALFORMED) {
+		t.Errorf("got %v, want malformed", err)
+	}
+	// escaped separators take three bytes, a name within the option limit can still be too long
+	game := strings.Repeat(":", 12) + strings.Repeat("a", 52)
+	if _, err := NewCustomID("leaderboard", "rating", 2, game).Encode(); !errors.Is(err, ERR_CUSTOM_ID_TOO_LONG) {
+		t.Errorf("got %v, want too long", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ID did not panic on an id that does not fit")
+		}
+	}()
+	ID("route", strings.Repeat("a", MAX_CUSTOM_ID_LENGTH))
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want CustomID
+		err  error
+	}{
+		{raw: "1:checkin:abc", want: CustomID{Version: 1, Route: "checkin", Args: []string{"abc"}}},
+		{raw: "1:audit.page", want: CustomID{Version: 1, Route: "audit.page", Args: []string{}}},
+		{raw: "signup_join_abc", want: CustomID{Args: []string{"signup", "join", "abc"}}},
+		{raw: "2:checkin:abc", err: ERR_CUSTOM_ID_OUTDATED},
+		{raw: "x:checkin", err: ERR_CUSTOM_ID_MALFORMED},
+		{raw: "1::abc", err: ERR_CUSTOM_ID_MALFORMED},
+		{raw: "", err: ERR_CUSTOM_ID_MALFORMED},
+	}
+
+	for _, tt := range tests {
+		got, err := Decode(tt.raw)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Decode(%q) error = %v, want %v", tt.raw, err, tt.err)
+			continue
+		}
+		if tt.err == nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Decode(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/models"
+)
+
+const (
+	// DEFER_AFTER is how long a handler can take before the router defers its response, discord
+	// drops interactions that are not answered within three seconds
+	DEFER_AFTER = 2 * time.Second
+	// SLOW_HANDLER is how long a handler can take before it is logged as slow
+	SLOW_HANDLER = 5 * time.Second
+)
+
+// Recover answers with an error instead of letting a panicking handler take the bot down
+func Recover(r Route, next HandlerFunc) HandlerFunc {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+		defer func() {
+			if p := recover(); p != nil {
+				slog.Error("handler panicked", "route", r.Name, "interaction", i.ID,
+					"panic", fmt.Sprint(p), "stack", string(debug.Stack()))
+				reply(s, i, ERR_INTERNAL)
+			}
+		}()
+		next(s, i, id)
+	}
+}
+
+// Logging logs every interaction with the route, the actor and where it was used
+func Logging(r Route, next HandlerFunc) HandlerFunc {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+		var userID string
+		if i.Member != nil {
+			userID = i.Member.User.ID
+		} else if i.User != nil {
+			userID = i.User.ID
+		}
+		slog.Info("interaction", "route", r.Name, "args", id.Args, "user", userID,
+			"guild", i.GuildID, "channel", i.ChannelID)
+		next(s, i, id)
+	}
+}
+
+// Timing logs how long handlers take, slow handlers are logged as warnings
+func Timing(r Route, next HandlerFunc) HandlerFunc {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+		start := time.Now()
+		next(s, i, id)
+		elapsed := time.Since(start)
+
+		level := slog.LevelDebug
+		if elapsed > SLOW_HANDLER {
+			level = slog.LevelWarn
+		}
+		slog.Log(context.Background(), level, "interaction handled", "route", r.Name, "interaction", i.ID, "elapsed", elapsed)
+	}
+}
+
+// Defer acknowledges interactions whose handler has not answered after DEFER_AFTER, routes with
+// NoDefer are left alone
+func Defer(r Route, next HandlerFunc) HandlerFunc {
+	if r.NoDefer {
+		return next
+	}
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+		a := track(i)
+		defer untrack(i)
+
+		timer := time.AfterFunc(DEFER_AFTER, func() {
+			if err := deferResponse(s, i, a); err != nil {
+				slog.Error("error deferring interaction", "route", r.Name, "interaction", i.ID, "error", err)
+			}
+		})
+		defer timer.Stop()
+
+		next(s, i, id)
+	}
+}
+
+// Permission checks the role a route requires before its handler runs
+func Permission(authorize Authorizer) Middleware {
+	return func(r Route, next HandlerFunc) HandlerFunc {
+		if r.Role == models.ROLE_NONE {
+			return next
+		}
+		return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+			if err := authorize(s, i, r, id); err != nil {
+				reply(s, i, err)
+				return
+			}
+			next(s, i, id)
+		}
+	}
+}
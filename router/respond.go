@@ -0,0 +1,146 @@
+package router
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var ERR_ALREADY_DEFERRED = errors.New("interaction was deferred and can't be answered with this response")
+
+// ack is whether an interaction that is being handled has been answered, the defer middleware
+// answers for handlers that take too long and Respond turns their response into an edit or followup
+type ack struct {
+	sync.Mutex
+	responded bool
+	deferred  bool
+	// thinking is whether the deferred response is a public message still waiting for its content
+	thinking bool
+}
+
+var acks = struct {
+	sync.Mutex
+	pending map[string]*ack
+}{pending: make(map[string]*ack)}
+
+func track(i *discordgo.InteractionCreate) *ack {
+	acks.Lock()
+	defer acks.Unlock()
+	a := &ack{}
+	acks.pending[i.ID] = a
+	return a
+}
+
+func untrack(i *discordgo.InteractionCreate) {
+	acks.Lock()
+	defer acks.Unlock()
+	delete(acks.pending, i.ID)
+}
+
+func tracked(i *discordgo.InteractionCreate) *ack {
+	acks.Lock()
+	defer acks.Unlock()
+	return acks.pending[i.ID]
+}
+
+// Respond answers the interaction. When the router deferred the interaction already, the first public
+// message fills the deferred response and later ones are sent as followups. Ephemeral messages can't be
+// shown in the public deferred response, it is removed and they are sent as followups. A message update
+// edits the deferred response.
+func Respond(s *discordgo.Session, i *discordgo.InteractionCreate, resp *discordgo.InteractionResponse) error {
+	a := tracked(i)
+	if a == nil {
+		return s.InteractionRespond(i.Interaction, resp)
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	if !a.deferred {
+		if err := s.InteractionRespond(i.Interaction, resp); err != nil {
+			return err
+		}
+		// responses that follow a deferral the handler made itself are sent the same way
+		switch resp.Type {
+		case discordgo.InteractionResponseDeferredChannelMessageWithSource, discordgo.InteractionResponseDeferredMessageUpdate:
+			a.deferred = true
+		default:
+			a.responded = true
+		}
+		return nil
+	}
+
+	data := resp.Data
+	if data == nil {
+		data = &discordgo.InteractionResponseData{}
+	}
+
+	switch resp.Type {
+	case discordgo.InteractionResponseDeferredChannelMessageWithSource, discordgo.InteractionResponseDeferredMessageUpdate:
+		return nil
+	case discordgo.InteractionResponseChannelMessageWithSource:
+		if a.thinking {
+			a.thinking = false
+			if data.Flags&discordgo.MessageFlagsEphemeral == 0 {
+				_, err := s.InteractionResponseEdit(i.Interaction, responseEdit(data))
+				return err
+			}
+			if err := s.InteractionResponseDelete(i.Interaction); err != nil {
+				return err
+			}
+		}
+		_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content:         data.Content,
+			Embeds:          data.Embeds,
+			Components:      data.Components,
+			Files:           data.Files,
+			AllowedMentions: data.AllowedMentions,
+			Flags:           data.Flags,
+		})
+		return err
+	case discordgo.InteractionResponseUpdateMessage:
+		a.thinking = false
+		_, err := s.InteractionResponseEdit(i.Interaction, responseEdit(data))
+		return err
+	default:
+		return ERR_ALREADY_DEFERRED
+	}
+}
+
+// responseEdit writes the parts of the response that are set over the deferred response
+func responseEdit(data *discordgo.InteractionResponseData) *discordgo.WebhookEdit {
+	edit := &discordgo.WebhookEdit{AllowedMentions: data.AllowedMentions, Files: data.Files}
+	if data.Content != "" {
+		edit.Content = &data.Content
+	}
+	if data.Embeds != nil {
+		edit.Embeds = &data.Embeds
+	}
+	if data.Components != nil {
+		edit.Components = &data.Components
+	}
+	return edit
+}
+
+// deferResponse acknowledges the interaction unless the handler answered it already. Commands and
+// modals are deferred with a public message since the deferral decides whether the response is
+// ephemeral, Respond replaces it when the handler answers with an ephemeral message.
+func deferResponse(s *discordgo.Session, i *discordgo.InteractionCreate, a *ack) error {
+	a.Lock()
+	defer a.Unlock()
+	if a.responded || a.deferred {
+		return nil
+	}
+
+	resp := &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredChannelMessageWithSource}
+	if i.Type == discordgo.InteractionMessageComponent {
+		resp = &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredMessageUpdate}
+	}
+
+	if err := s.InteractionRespond(i.Interaction, resp); err != nil {
+		return err
+	}
+	a.deferred = true
+	a.thinking = i.Type != discordgo.InteractionMessageComponent
+	return nil
+}
@@ -0,0 +1,163 @@
+package router
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/models"
+)
+
+// HandlerFunc handles an interaction, id is empty for commands
+type HandlerFunc func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID)
+
+// Middleware wraps the handler of a route, it is applied once when the route is registered
+type Middleware func(r Route, next HandlerFunc) HandlerFunc
+
+// Route is a command, or a component or modal custom id the router dispatches to its handler
+type Route struct {
+	Name string
+	// Args is how many arguments the custom id of the route carries, ids with another count are rejected
+	Args int
+	// Role is required by the permission middleware, ROLE_NONE leaves the check to the handler
+	Role models.Role
+	// Tournament makes the permission middleware check Role against the tournament whose id is the
+	// first argument, the role is checked for the whole guild otherwise
+	Tournament bool
+	// NoDefer keeps the router from deferring the response, routes that answer with a modal can't
+	// be deferred
+	NoDefer bool
+	Handler HandlerFunc
+}
+
+// Authorizer checks the role of a route for the actor of the interaction
+type Authorizer func(s *discordgo.Session, i *discordgo.InteractionCreate, r Route, id CustomID) error
+
+type Router struct {
+	commands      map[string]HandlerFunc
+	autocompletes map[string]HandlerFunc
+	routes        map[string]Route
+	middleware    []Middleware
+}
+
+func New(middleware ...Middleware) *Router {
+	return &Router{
+		commands:      make(map[string]HandlerFunc),
+		autocompletes: make(map[string]HandlerFunc),
+		routes:        make(map[string]Route),
+		middleware:    middleware,
+	}
+}
+
+// chain wraps the handler of the route in the middleware, the first middleware runs first
+func (rt *Router) chain(r Route) HandlerFunc {
+	h := r.Handler
+	for idx := len(rt.middleware) - 1; idx >= 0; idx-- {
+		h = rt.middleware[idx](r, h)
+	}
+	return h
+}
+
+// Command routes the slash command of name to handler
+func (rt *Router) Command(r Route) {
+	rt.commands[r.Name] = rt.chain(r)
+}
+
+// Autocomplete routes the autocomplete of the command of name, it only recovers from panics since
+// suggestions are answered right away and are not worth logging
+func (rt *Router) Autocomplete(name string, handler HandlerFunc) {
+	rt.autocompletes[name] = Recover(Route{Name: name}, handler)
+}
+
+// Handle routes the components and modals with the route name of r
+func (rt *Router) Handle(r Route) {
+	if _, ok := rt.routes[r.Name]; ok {
+		panic("route registered twice: " + r.Name)
+	}
+	r.Handler = rt.chain(r)
+	rt.routes[r.Name] = r
+}
+
+// Dispatch is the interaction handler of the discord session
+func (rt *Router) Dispatch(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		if h, ok := rt.commands[i.ApplicationCommandData().Name]; ok {
+			h(s, i, CustomID{})
+		}
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		if h, ok := rt.autocompletes[i.ApplicationCommandData().Name]; ok {
+			h(s, i, CustomID{})
+		}
+	case discordgo.InteractionMessageComponent:
+		rt.dispatchCustomID(s, i, i.MessageComponentData().CustomID)
+	case discordgo.InteractionModalSubmit:
+		rt.dispatchCustomID(s, i, i.ModalSubmitData().CustomID)
+	}
+}
+
+func (rt *Router) dispatchCustomID(s *discordgo.Session, i *discordgo.InteractionCreate, raw string) {
+	id, err := Decode(raw)
+	if err == nil {
+		var r Route
+		if r, err = rt.resolve(&id); err == nil {
+			r.Handler(s, i, id)
+			return
+		}
+	}
+
+	slog.Warn("interaction rejected", "custom_id", raw, "guild", i.GuildID, "error", err)
+	if errors.Is(err, ERR_CUSTOM_ID_OUTDATED) {
+		reply(s, i, err)
+		return
+	}
+	reply(s, i, ERR_UNKNOWN_ACTION)
+}
+
+// resolve finds the route of the custom id and checks its arguments. Ids of version 0 were written
+// as name_action_args or name_args, both are looked up against the registered routes.
+func (rt *Router) resolve(id *CustomID) (Route, error) {
+	if id.Version == 0 {
+		parts := id.Args
+		candidates := []CustomID{{Route: parts[0], Args: parts[1:]}}
+		if len(parts) > 1 {
+			candidates = append([]CustomID{{Route: parts[0] + "." + parts[1], Args: parts[2:]}}, candidates...)
+		}
+		for _, c := range candidates {
+			if r, ok := rt.routes[c.Route]; ok && r.Args == len(c.Args) {
+				*id = c
+				return r, nil
+			}
+		}
+		return Route{}, ERR_CUSTOM_ID_OUTDATED
+	}
+
+	r, ok := rt.routes[id.Route]
+	if !ok {
+		return Route{}, errors.New("no route " + id.Route)
+	}
+	if len(id.Args) != r.Args {
+		return Route{}, ERR_CUSTOM_ID_MALFORMED
+	}
+	return r, nil
+}
+
+// ErrorText is the text of an error the router answers with, it is replaced to translate the errors
+// into the language of the member
+var ErrorText = func(i *discordgo.InteractionCreate, err error) string {
+	return err.Error()
+}
+
+// reply answers the interaction with the error in an ephemeral message
+func reply(s *discordgo.Session, i *discordgo.InteractionCreate, reason error) {
+	err := Respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: ErrorText(i, reason),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		slog.Error("error replying to interaction", "interaction", i.ID, "error", err)
+	}
+}
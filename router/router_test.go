@@ -0,0 +1,99 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/models"
+)
+
+func noop(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {}
+
+func TestResolve(t *testing.T) {
+	rt := New()
+	rt.Handle(Route{Name: "tournament.processresult", Args: 3, Handler: noop})
+	rt.Handle(Route{Name: "checkin", Args: 1, Handler: noop})
+	rt.Handle(Route{Name: "leaderboard", Args: 3, Handler: noop})
+
+	tests := []struct {
+		raw   string
+		route string
+		args  []string
+		err   error
+	}{
+		{raw: "1:checkin:abc", route: "checkin", args: []string{"abc"}},
+		{raw: "1:checkin", err: ERR_CUSTOM_ID_MALFORMED},
+		{raw: "tournament_processresult_abc_4_7", route: "tournament.processresult", args: []string{"abc", "4", "7"}},
+		{raw: "checkin_abc", route: "checkin", args: []string{"abc"}},
+		{raw: "leaderboard_rating_2_Tekken", route: "leaderboard", args: []string{"rating", "2", "Tekken"}},
+		// the game used to be allowed to contain underscores
+		{raw: "leaderboard_rating_2_Tekken_8", err: ERR_CUSTOM_ID_OUTDATED},
+		{raw: "modals-tournament_edit_abc", err: ERR_CUSTOM_ID_OUTDATED},
+	}
+
+	for _, tt := range tests {
+		id, err := Decode(tt.raw)
+		if err != nil {
+			t.Fatalf("Decode(%q): %v", tt.raw, err)
+		}
+		r, err := rt.resolve(&id)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("resolve(%q) error = %v, want %v", tt.raw, err, tt.err)
+			continue
+		}
+		if tt.err != nil {
+			continue
+		}
+		if r.Name != tt.route || id.Route != tt.route || !reflect.DeepEqual(id.Args, tt.args) {
+			t.Errorf("resolve(%q) = %s %v, want %s %v", tt.raw, id.Route, id.Args, tt.route, tt.args)
+		}
+	}
+
+	id := CustomID{Version: VERSION, Route: "unknown"}
+	if _, err := rt.resolve(&id); err == nil {
+		t.Error("resolved a route that is not registered")
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(r Route, next HandlerFunc) HandlerFunc {
+			return func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+				calls = append(calls, name+" "+r.Name)
+				next(s, i, id)
+			}
+		}
+	}
+
+	rt := New(trace("first"), trace("second"))
+	rt.Command(Route{Name: "ping", Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) {
+		calls = append(calls, "handler")
+	}})
+	rt.commands["ping"](nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{}}, CustomID{})
+
+	if want := []string{"first ping", "second ping", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+}
+
+func TestPermission(t *testing.T) {
+	checked := 0
+	mw := Permission(func(s *discordgo.Session, i *discordgo.InteractionCreate, r Route, id CustomID) error {
+		checked++
+		return nil
+	})
+
+	ran := 0
+	handler := func(s *discordgo.Session, i *discordgo.InteractionCreate, id CustomID) { ran++ }
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{}}
+
+	mw(Route{Name: "open"}, handler)(nil, i, CustomID{})
+	mw(Route{Name: "guarded", Role: models.ROLE_ORGANIZER}, handler)(nil, i, CustomID{})
+
+	if checked != 1 || ran != 2 {
+		t.Errorf("checked %d times and ran %d times, want 1 and 2", checked, ran)
+	}
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces bursts of triggers for the same key into a single call of its function. The
+// call runs Wait after the first trigger of a burst and never sooner than Interval after the previous
+// call of the key. Triggers that arrive while the call runs schedule one more call.
+type Debouncer struct {
+	Wait     time.Duration
+	Interval time.Duration
+	fn       func(key string) error
+	keys     map[string]*debounced
+	mutex    sync.Mutex
+}
+
+type debounced struct {
+	timer   *time.Timer
+	evict   *time.Timer
+	running bool
+	dirty   bool
+	last    time.Time
+}
+
+func NewDebouncer(wait, interval time.Duration, fn func(key string) error) *Debouncer {
+	return &Debouncer{
+		Wait:     wait,
+		Interval: interval,
+		fn:       fn,
+		keys:     make(map[string]*debounced),
+	}
+}
+
+// Trigger asks for a call of the key, it returns right away
+func (d *Debouncer) Trigger(key string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	k, ok := d.keys[key]
+	if !ok {
+		k = &debounced{}
+		d.keys[key] = k
+	}
+	if k.evict != nil {
+		k.evict.Stop()
+		k.evict = nil
+	}
+	if k.running {
+		k.dirty = true
+		return
+	}
+	if k.timer == nil {
+		d.schedule(key, k, d.Wait)
+	}
+}
+
+// schedule must be called with the mutex held
+func (d *Debouncer) schedule(key string, k *debounced, delay time.Duration) {
+	if wait := time.Until(k.last.Add(d.Interval)); wait > delay {
+		delay = wait
+	}
+	k.timer = time.AfterFunc(delay, func() { d.flush(key) })
+}
+
+func (d *Debouncer) flush(key string) {
+	d.mutex.Lock()
+	k := d.keys[key]
+	k.timer, k.running, k.last = nil, true, time.Now()
+	d.mutex.Unlock()
+
+	err := d.fn(key)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	k.running = false
+
+	if err != nil {
+		log.Printf("error running debounced call of %s: %v", key, err)
+	}
+
+	if k.dirty {
+		k.dirty = false
+		d.schedule(key, k, d.Wait)
+		return
+	}
+	// an idle key is forgotten once the interval has passed, a trigger before that still has to wait
+	// for it
+	k.evict = time.AfterFunc(d.Interval, func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if d.keys[key] == k && k.timer == nil && !k.running {
+			delete(d.keys, key)
+		}
+	})
+}
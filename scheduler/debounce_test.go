@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type calls struct {
+	mutex sync.Mutex
+	keys  []string
+	at    []time.Time
+}
+
+func (c *calls) record(key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.keys = append(c.keys, key)
+	c.at = append(c.at, time.Now())
+	return nil
+}
+
+func (c *calls) count() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.keys)
+}
+
+func TestDebouncerCoalesces(t *testing.T) {
+	c := &calls{}
+	d := NewDebouncer(20*time.Millisecond, 0, c.record)
+
+	for range 10 {
+		d.Trigger("a")
+	}
+	d.Trigger("b")
+	time.Sleep(80 * time.Millisecond)
+
+	if c.count() != 2 {
+		t.Fatalf("expected a single call per key, got %v", c.keys)
+	}
+}
+
+func TestDebouncerRunsAgainAfterTriggerDuringCall(t *testing.T) {
+	var (
+		c       = &calls{}
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	first := true
+	d := NewDebouncer(time.Millisecond, 30*time.Millisecond, func(key string) error {
+		if first {
+			first = false
+			close(started)
+			<-release
+		}
+		return c.record(key)
+	})
+
+	d.Trigger("a")
+	<-started
+	d.Trigger("a")
+	d.Trigger("a")
+	close(release)
+	time.Sleep(100 * time.Millisecond)
+
+	if c.count() != 2 {
+		t.Fatalf("expected one more call after the running one, got %d", c.count())
+	}
+	if gap := c.at[1].Sub(c.at[0]); gap < 20*time.Millisecond {
+		t.Errorf("second call ran %v after the first, sooner than the interval", gap)
+	}
+}
+
+func TestDebouncerKeepsIntervalAfterIdle(t *testing.T) {
+	c := &calls{}
+	d := NewDebouncer(time.Millisecond, 50*time.Millisecond, c.record)
+
+	d.Trigger("a")
+	time.Sleep(10 * time.Millisecond)
+	if c.count() != 1 {
+		t.Fatalf("expected the first call to have run, got %d", c.count())
+	}
+
+	// the key is idle now, the next call still has to wait for the interval
+	d.Trigger("a")
+	time.Sleep(100 * time.Millisecond)
+
+	if c.count() != 2 {
+		t.Fatalf("expected a second call, got %d", c.count())
+	}
+	if gap := c.at[1].Sub(c.at[0]); gap < 45*time.Millisecond {
+		t.Errorf("second call ran %v after the first, sooner than the interval", gap)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.keys["a"]; ok {
+		t.Error("expected the idle key to be forgotten once the interval passed")
+	}
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseTimeOfDay parses a 24 hour HH:MM time
+func ParseTimeOfDay(v string) (int, int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q, use the 24 hour HH:MM format", v)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// NextOccurrence returns the first weekday at hour:minute in loc that is strictly after the given time
+func NextOccurrence(after time.Time, weekday time.Weekday, hour, minute int, loc *time.Location) time.Time {
+	local := after.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+	next := time.Date(local.Year(), local.Month(), local.Day()+days, hour, minute, 0, 0, loc)
+	if !next.After(after) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+days+7, hour, minute, 0, 0, loc)
+	}
+	return next
+}
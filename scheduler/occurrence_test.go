@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		name     string
+		after    time.Time
+		weekday  time.Weekday
+		hour     int
+		minute   int
+		loc      *time.Location
+		expected time.Time
+	}
+
+	tests := []testCase{
+		{
+			name:     "later in the same week",
+			after:    time.Date(2024, 11, 4, 10, 0, 0, 0, time.UTC), // monday
+			weekday:  time.Friday,
+			hour:     20,
+			loc:      time.UTC,
+			expected: time.Date(2024, 11, 8, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "same day before the time",
+			after:    time.Date(2024, 11, 8, 19, 59, 0, 0, time.UTC),
+			weekday:  time.Friday,
+			hour:     20,
+			loc:      time.UTC,
+			expected: time.Date(2024, 11, 8, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exactly at the time rolls over to next week",
+			after:    time.Date(2024, 11, 8, 20, 0, 0, 0, time.UTC),
+			weekday:  time.Friday,
+			hour:     20,
+			loc:      time.UTC,
+			expected: time.Date(2024, 11, 15, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday is resolved in the given time zone",
+			after:    time.Date(2024, 11, 7, 18, 0, 0, 0, time.UTC), // friday 01:00 in jakarta
+			weekday:  time.Friday,
+			hour:     20,
+			minute:   30,
+			loc:      jakarta,
+			expected: time.Date(2024, 11, 8, 20, 30, 0, 0, jakarta),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NextOccurrence(tc.after, tc.weekday, tc.hour, tc.minute, tc.loc)
+			if !got.Equal(tc.expected) {
+				t.Fatalf("expected %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	hour, minute, err := ParseTimeOfDay("07:45")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hour != 7 || minute != 45 {
+		t.Fatalf("expected 07:45 but got %02d:%02d", hour, minute)
+	}
+
+	for _, v := range []string{"7pm", "24:00", "12:60", ""} {
+		if _, _, err := ParseTimeOfDay(v); err == nil {
+			t.Fatalf("expected %q to be invalid", v)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dimfu/spade/database"
+	"github.com/dimfu/spade/models"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	maxAttempts  = 3
+	batchSize    = 20
+)
+
+// Job runs the scheduled jobs of its kind, returning an error retries the job later
+type Job interface {
+	Kind() string
+	Run(s *discordgo.Session, job *models.ScheduledJob) error
+}
+
+type Scheduler struct {
+	jobs  map[string]Job
+	mutex sync.Mutex
+}
+
+var (
+	instance *Scheduler
+	once     sync.Once
+)
+
+func GetScheduler() *Scheduler {
+	once.Do(func() {
+		instance = &Scheduler{
+			jobs: make(map[string]Job),
+		}
+	})
+	return instance
+}
+
+func (sc *Scheduler) Register(job Job) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.jobs[job.Kind()] = job
+}
+
+// Schedule persists a job so it still runs after the bot restarts
+func Schedule(kind, tournamentID string, referenceID int, runAt time.Time) error {
+	j := &models.ScheduledJob{
+		Kind:         kind,
+		TournamentID: sql.NullString{String: tournamentID, Valid: tournamentID != ""},
+		ReferenceID:  sql.NullInt64{Int64: int64(referenceID), Valid: referenceID != 0},
+		RunAt:        runAt.Unix(),
+	}
+	return models.NewScheduledJobModel(database.GetDB()).Insert(j)
+}
+
+// Run polls the due jobs until the context is cancelled
+func (sc *Scheduler) Run(ctx context.Context, s *discordgo.Session) {
+	jm := models.NewScheduledJobModel(database.GetDB())
+
+	// jobs left running means the bot was stopped halfway through them
+	if err := jm.ResetRunning(); err != nil {
+		log.Printf("error resetting interrupted jobs: %v", err)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		sc.tick(s, jm)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sc *Scheduler) tick(s *discordgo.Session, jm *models.ScheduledJobModel) {
+	now := time.Now()
+	jobs, err := jm.Due(now.Unix(), batchSize)
+	if err != nil {
+		log.Printf("error fetching due jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		sc.mutex.Lock()
+		handler, ok := sc.jobs[job.Kind]
+		sc.mutex.Unlock()
+
+		if !ok {
+			if err := jm.Fail(job.ID, fmt.Errorf("no handler registered for job %s", job.Kind), 0); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+
+		claimed, err := jm.Claim(job.ID)
+		if err != nil {
+			log.Printf("error claiming job %d: %v", job.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		job.Attempts++
+
+		if err := run(handler, s, &job); err != nil {
+			log.Printf("job %d (%s) failed: %v", job.ID, job.Kind, err)
+			var retryAt int64
+			if job.Attempts < maxAttempts {
+				retryAt = now.Add(time.Duration(job.Attempts) * time.Minute).Unix()
+			}
+			if err := jm.Fail(job.ID, err, retryAt); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+
+		if err := jm.Done(job.ID); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func run(handler Job, s *discordgo.Session, job *models.ScheduledJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler.Run(s, job)
+}